@@ -1,9 +1,11 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -42,6 +44,19 @@ func GetEnvBool(key string, defaultValue bool) (result bool) {
 	return
 }
 
+func GetEnvFloat(key string, defaultValue float64) (result float64) {
+	value := os.Getenv(key)
+	if value != "" {
+		var err error
+		result, err = strconv.ParseFloat(value, 64)
+		if err == nil {
+			return
+		}
+	}
+	result = defaultValue
+	return
+}
+
 func GetEnvDuration(key string, defaultValue time.Duration) (result time.Duration) {
 	value := os.Getenv(key)
 	if value != "" {
@@ -55,6 +70,51 @@ func GetEnvDuration(key string, defaultValue time.Duration) (result time.Duratio
 	return
 }
 
+func GetEnvMap(key string) (result map[string]string) {
+	result = make(map[string]string)
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		name, entry, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			continue
+		}
+		result[name] = strings.TrimSpace(entry)
+	}
+	return
+}
+
+func GetEnvIntMap(key string) (result map[string]int) {
+	result = make(map[string]int)
+	for name, value := range GetEnvMap(key) {
+		parsed, err := strconv.Atoi(value)
+		if err == nil {
+			result[name] = parsed
+		}
+	}
+	return
+}
+
+func LoadJSONFile(path string, target interface{}) (err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("failed to read %s: %w", path, err)
+		return
+	}
+
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		err = fmt.Errorf("failed to parse %s: %w", path, err)
+		return
+	}
+	return
+}
+
 func MustGetEnv(key string) (value string) {
 	value = os.Getenv(key)
 	if value == "" {
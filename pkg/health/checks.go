@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var ErrNotProbed = errors.New("probe has not run yet")
+
+type PeriodicCheck struct {
+	check    CheckFunc
+	interval time.Duration
+	timeout  time.Duration
+	mu       sync.RWMutex
+	lastErr  error
+	lastRun  time.Time
+}
+
+func NewPeriodicCheck(check CheckFunc, interval, timeout time.Duration) (periodic *PeriodicCheck) {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+
+	periodic = &PeriodicCheck{
+		check:    check,
+		interval: interval,
+		timeout:  timeout,
+		lastErr:  ErrNotProbed,
+	}
+	return
+}
+
+func (p *PeriodicCheck) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			p.probe(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (p *PeriodicCheck) probe(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err := p.check(probeCtx)
+
+	p.mu.Lock()
+	p.lastErr = err
+	p.lastRun = time.Now()
+	p.mu.Unlock()
+}
+
+func (p *PeriodicCheck) Check(ctx context.Context) (err error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	err = p.lastErr
+	if err != nil && !p.lastRun.IsZero() {
+		err = fmt.Errorf("last probe at %s failed: %w", p.lastRun.UTC().Format(time.RFC3339), err)
+	}
+	return
+}
+
+func TCPReachable(rawURL string) (check CheckFunc) {
+	check = func(ctx context.Context) (err error) {
+		var parsed *url.URL
+		parsed, err = url.Parse(rawURL)
+		if err != nil {
+			err = fmt.Errorf("invalid url: %w", err)
+			return
+		}
+
+		address := parsed.Host
+		if parsed.Port() == "" {
+			port := "80"
+			if parsed.Scheme == "https" {
+				port = "443"
+			}
+			address = net.JoinHostPort(parsed.Hostname(), port)
+		}
+
+		var dialer net.Dialer
+		var conn net.Conn
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return
+		}
+		err = conn.Close()
+		return
+	}
+	return
+}
+
+func DirWritable(dir string) (check CheckFunc) {
+	check = func(ctx context.Context) (err error) {
+		var file *os.File
+		file, err = os.CreateTemp(dir, ".healthcheck-*")
+		if err != nil {
+			return
+		}
+
+		name := file.Name()
+		defer os.Remove(filepath.Clean(name))
+
+		_, err = file.Write([]byte("ok"))
+		if err != nil {
+			file.Close()
+			return
+		}
+		err = file.Close()
+		return
+	}
+	return
+}
@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPeriodicCheck(t *testing.T) {
+	var healthy atomic.Bool
+	periodic := NewPeriodicCheck(func(ctx context.Context) (err error) {
+		if !healthy.Load() {
+			err = errors.New("destination unreachable")
+		}
+		return
+	}, 10*time.Millisecond, time.Second)
+
+	if err := periodic.Check(context.Background()); !errors.Is(err, ErrNotProbed) {
+		t.Fatalf("Check before the first probe = %v, want %v", err, ErrNotProbed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	periodic.Start(ctx)
+
+	awaitCheck(t, periodic, func(err error) bool { return err != nil && !errors.Is(err, ErrNotProbed) })
+
+	healthy.Store(true)
+	awaitCheck(t, periodic, func(err error) bool { return err == nil })
+}
+
+func awaitCheck(t *testing.T, periodic *PeriodicCheck, done func(err error) bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := periodic.Check(context.Background())
+		if done(err) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Check = %v, want a different probe result", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTCPReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "listening", url: "http://" + listener.Addr().String() + "/external/alerts"},
+		{name: "nothing listening", url: "http://" + closedAddr, wantErr: true},
+		{name: "invalid url", url: "http://[::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := TCPReachable(tt.url)(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TCPReachable(%s) = %v, want error %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDirWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	err := DirWritable(dir)(context.Background())
+	if err != nil {
+		t.Fatalf("DirWritable returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("directory entries = %v, err %v, want the probe file removed", entries, err)
+	}
+
+	err = DirWritable(filepath.Join(dir, "missing"))(context.Background())
+	if err == nil {
+		t.Errorf("DirWritable on a missing directory returned no error")
+	}
+}
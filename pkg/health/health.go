@@ -0,0 +1,186 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrShuttingDown = errors.New("service is shutting down")
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+)
+
+type CheckFunc func(ctx context.Context) (err error)
+
+type Registry struct {
+	service      string
+	timeout      time.Duration
+	mu           sync.RWMutex
+	liveness     []namedCheck
+	readiness    []namedCheck
+	shuttingDown atomic.Bool
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+type CheckResult struct {
+	Name      string  `json:"name"`
+	Status    Status  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Service string        `json:"service"`
+	Status  Status        `json:"status"`
+	Checks  []CheckResult `json:"checks"`
+}
+
+const DefaultCheckTimeout = 2 * time.Second
+
+func NewRegistry(service string, timeout time.Duration) (registry *Registry) {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+
+	registry = &Registry{
+		service: service,
+		timeout: timeout,
+	}
+	return
+}
+
+func (r *Registry) AddLiveness(name string, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.liveness = append(r.liveness, namedCheck{name: name, check: check})
+}
+
+func (r *Registry) AddReadiness(name string, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.readiness = append(r.readiness, namedCheck{name: name, check: check})
+}
+
+func (r *Registry) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+func (r *Registry) Live(ctx context.Context) (report Report) {
+	r.mu.RLock()
+	checks := append([]namedCheck(nil), r.liveness...)
+	r.mu.RUnlock()
+
+	report = r.run(ctx, checks)
+	return
+}
+
+func (r *Registry) Ready(ctx context.Context) (report Report) {
+	r.mu.RLock()
+	checks := []namedCheck{{name: "shutdown", check: r.checkShutdown}}
+	checks = append(checks, r.readiness...)
+	r.mu.RUnlock()
+
+	report = r.run(ctx, checks)
+	return
+}
+
+func (r *Registry) checkShutdown(ctx context.Context) (err error) {
+	if r.shuttingDown.Load() {
+		err = ErrShuttingDown
+	}
+	return
+}
+
+func (r *Registry) run(ctx context.Context, checks []namedCheck) (report Report) {
+	report = Report{
+		Service: r.service,
+		Status:  StatusPass,
+		Checks:  make([]CheckResult, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = r.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status == StatusFail {
+			report.Status = StatusFail
+		}
+	}
+	return
+}
+
+func (r *Registry) runCheck(ctx context.Context, check namedCheck) (result CheckResult) {
+	checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- check.check(checkCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-errs:
+	case <-checkCtx.Done():
+		err = checkCtx.Err()
+	}
+
+	result = CheckResult{
+		Name:      check.name,
+		Status:    StatusPass,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return
+}
+
+func (r *Registry) LivenessHandler() (handler http.Handler) {
+	handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, r.Live(req.Context()))
+	})
+	return
+}
+
+func (r *Registry) ReadinessHandler() (handler http.Handler) {
+	handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, r.Ready(req.Context()))
+	})
+	return
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	statusCode := http.StatusOK
+	if report.Status != StatusPass {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func passing(ctx context.Context) (err error) {
+	return
+}
+
+func failing(ctx context.Context) (err error) {
+	err = errors.New("queue is full")
+	return
+}
+
+func blocking(ctx context.Context) (err error) {
+	<-ctx.Done()
+	err = ctx.Err()
+	return
+}
+
+func TestRegistryReports(t *testing.T) {
+	tests := []struct {
+		name      string
+		liveness  map[string]CheckFunc
+		readiness map[string]CheckFunc
+		shutdown  bool
+		live      Status
+		ready     Status
+	}{
+		{name: "no checks", live: StatusPass, ready: StatusPass},
+		{
+			name:      "all passing",
+			liveness:  map[string]CheckFunc{"process": passing},
+			readiness: map[string]CheckFunc{"queue": passing, "store": passing},
+			live:      StatusPass,
+			ready:     StatusPass,
+		},
+		{
+			name:      "failing readiness check",
+			liveness:  map[string]CheckFunc{"process": passing},
+			readiness: map[string]CheckFunc{"queue": failing, "store": passing},
+			live:      StatusPass,
+			ready:     StatusFail,
+		},
+		{
+			name:     "failing liveness check",
+			liveness: map[string]CheckFunc{"process": failing},
+			live:     StatusFail,
+			ready:    StatusPass,
+		},
+		{
+			name:      "shutting down",
+			readiness: map[string]CheckFunc{"queue": passing},
+			shutdown:  true,
+			live:      StatusPass,
+			ready:     StatusFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry("middleware", time.Second)
+			for name, check := range tt.liveness {
+				registry.AddLiveness(name, check)
+			}
+			for name, check := range tt.readiness {
+				registry.AddReadiness(name, check)
+			}
+			if tt.shutdown {
+				registry.SetShuttingDown()
+			}
+
+			live := registry.Live(context.Background())
+			if live.Status != tt.live || len(live.Checks) != len(tt.liveness) {
+				t.Errorf("Live = %+v, want status %s with %d checks", live, tt.live, len(tt.liveness))
+			}
+
+			ready := registry.Ready(context.Background())
+			if ready.Status != tt.ready || len(ready.Checks) != len(tt.readiness)+1 {
+				t.Errorf("Ready = %+v, want status %s with %d checks", ready, tt.ready, len(tt.readiness)+1)
+			}
+			if ready.Service != "middleware" {
+				t.Errorf("Service = %q, want %q", ready.Service, "middleware")
+			}
+		})
+	}
+}
+
+func TestRegistryReportsFailingCheck(t *testing.T) {
+	registry := NewRegistry("middleware", 20*time.Millisecond)
+	registry.AddReadiness("queue", failing)
+	registry.AddReadiness("store", blocking)
+	registry.SetShuttingDown()
+
+	report := registry.Ready(context.Background())
+
+	want := map[string]string{
+		"shutdown": ErrShuttingDown.Error(),
+		"queue":    "queue is full",
+		"store":    context.DeadlineExceeded.Error(),
+	}
+	for i, result := range report.Checks {
+		if i == 0 && result.Name != "shutdown" {
+			t.Errorf("first check = %q, want shutdown", result.Name)
+		}
+		if result.Status != StatusFail || result.Error != want[result.Name] {
+			t.Errorf("check %s = %+v, want fail with %q", result.Name, result, want[result.Name])
+		}
+	}
+}
+
+func TestRegistryHandlers(t *testing.T) {
+	registry := NewRegistry("middleware", time.Second)
+	registry.AddLiveness("process", passing)
+	registry.AddReadiness("queue", failing)
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		code    int
+		status  Status
+	}{
+		{name: "liveness", handler: registry.LivenessHandler(), code: http.StatusOK, status: StatusPass},
+		{name: "readiness", handler: registry.ReadinessHandler(), code: http.StatusServiceUnavailable, status: StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tt.code {
+				t.Errorf("status code = %d, want %d", rec.Code, tt.code)
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", contentType)
+			}
+
+			var report Report
+			err := json.Unmarshal(rec.Body.Bytes(), &report)
+			if err != nil || report.Status != tt.status {
+				t.Errorf("report = %s, err %v, want status %s", rec.Body.String(), err, tt.status)
+			}
+		})
+	}
+}
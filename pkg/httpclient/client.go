@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/smartcom/integration-platform/pkg/tlsconfig"
 )
 
 type Client struct {
@@ -20,13 +22,25 @@ type Config struct {
 	Timeout    time.Duration
 	MaxRetries int
 	BaseDelay  time.Duration
+	TLS        *tlsconfig.ClientConfig
 }
 
-func New(cfg Config) (client *Client) {
+func New(cfg Config) (client *Client, err error) {
+	httpClient := &http.Client{
+		Timeout: cfg.Timeout,
+	}
+
+	if cfg.TLS != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig, err = tlsconfig.NewClientConfig(*cfg.TLS)
+		if err != nil {
+			return
+		}
+		httpClient.Transport = transport
+	}
+
 	client = &Client{
-		httpClient: &http.Client{
-			Timeout: cfg.Timeout,
-		},
+		httpClient: httpClient,
 		maxRetries: cfg.MaxRetries,
 		baseDelay:  cfg.BaseDelay,
 	}
@@ -5,10 +5,15 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"sync/atomic"
+
+	"github.com/smartcom/integration-platform/pkg/redact"
 )
 
 type Logger struct {
 	*slog.Logger
+	level    *slog.LevelVar
+	redactor *atomic.Pointer[redact.Redactor]
 }
 
 func New(output io.Writer, level slog.Level) (l *Logger) {
@@ -16,13 +21,21 @@ func New(output io.Writer, level slog.Level) (l *Logger) {
 		output = os.Stdout
 	}
 
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(level)
+
+	redactor := &atomic.Pointer[redact.Redactor]{}
+
 	opts := &slog.HandlerOptions{
-		Level: level,
+		Level:       levelVar,
+		ReplaceAttr: redactAttr(redactor),
 	}
 
 	handler := slog.NewJSONHandler(output, opts)
 	l = &Logger{
-		Logger: slog.New(handler),
+		Logger:   slog.New(handler),
+		level:    levelVar,
+		redactor: redactor,
 	}
 	return
 }
@@ -32,6 +45,19 @@ func NewDefault() (l *Logger) {
 	return
 }
 
+func (l *Logger) SetLevel(level slog.Level) {
+	l.level.Set(level)
+}
+
+func (l *Logger) Level() (level slog.Level) {
+	level = l.level.Level()
+	return
+}
+
+func (l *Logger) SetRedactor(redactor *redact.Redactor) {
+	l.redactor.Store(redactor)
+}
+
 func (l *Logger) WithContext(ctx context.Context) (logger *slog.Logger) {
 	correlationID := ctx.Value("correlation_id")
 	if correlationID != nil {
@@ -61,3 +87,36 @@ func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
 	logger := l.WithContext(ctx)
 	logger.Debug(msg, args...)
 }
+
+func redactAttr(current *atomic.Pointer[redact.Redactor]) (replace func(groups []string, attr slog.Attr) slog.Attr) {
+	replace = func(groups []string, attr slog.Attr) (replaced slog.Attr) {
+		replaced = attr
+
+		redactor := current.Load()
+		if redactor == nil {
+			return
+		}
+		if len(groups) == 0 && (attr.Key == slog.TimeKey || attr.Key == slog.LevelKey || attr.Key == slog.SourceKey) {
+			return
+		}
+
+		value := attr.Value.Resolve()
+		if redactor.SensitiveKey(attr.Key) {
+			replaced = slog.Any(attr.Key, redactor.Value(attr.Key, value.Any()))
+			return
+		}
+
+		switch value.Kind() {
+		case slog.KindString:
+			replaced = slog.String(attr.Key, redactor.String(value.String()))
+		case slog.KindAny:
+			if err, ok := value.Any().(error); ok {
+				replaced = slog.String(attr.Key, redactor.String(err.Error()))
+				return
+			}
+			replaced = slog.Any(attr.Key, redactor.Value("", value.Any()))
+		}
+		return
+	}
+	return
+}
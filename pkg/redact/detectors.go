@@ -0,0 +1,52 @@
+package redact
+
+import "regexp"
+
+const (
+	DetectorEmail       = "email"
+	DetectorCreditCard  = "credit_card"
+	DetectorBearerToken = "bearer_token"
+	DetectorIPv4        = "ipv4"
+)
+
+var detectors = map[string]rule{
+	DetectorEmail: {
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	},
+	DetectorCreditCard: {
+		pattern:  regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		validate: luhnValid,
+	},
+	DetectorBearerToken: {
+		pattern: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`),
+	},
+	DetectorIPv4: {
+		pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`),
+	},
+}
+
+func luhnValid(match string) (ok bool) {
+	sum := 0
+	digits := 0
+	double := false
+	for i := len(match) - 1; i >= 0; i-- {
+		c := match[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+		double = !double
+	}
+
+	ok = digits >= 13 && digits <= 19 && sum%10 == 0
+	return
+}
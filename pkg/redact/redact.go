@@ -0,0 +1,248 @@
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidConfig = errors.New("invalid redaction config")
+
+const (
+	StrategyMask = "mask"
+	StrategyHash = "hash"
+
+	hashLength = 16
+)
+
+type Pattern struct {
+	Name     string `json:"name"`
+	Regex    string `json:"regex"`
+	Strategy string `json:"strategy,omitempty"`
+}
+
+type Config struct {
+	Keys      []string  `json:"keys,omitempty"`
+	Patterns  []Pattern `json:"patterns,omitempty"`
+	Detectors []string  `json:"detectors,omitempty"`
+	Strategy  string    `json:"strategy,omitempty"`
+	HashKey   string    `json:"hash_key,omitempty"`
+}
+
+type rule struct {
+	name     string
+	pattern  *regexp.Regexp
+	strategy string
+	validate func(match string) (ok bool)
+}
+
+type Redactor struct {
+	keys     map[string]bool
+	rules    []rule
+	strategy string
+	hashKey  []byte
+}
+
+func DefaultConfig() (cfg Config) {
+	cfg = Config{
+		Keys:      []string{"password", "passwd", "secret", "token", "api_key", "access_token", "authorization", "cookie", "set_cookie"},
+		Detectors: []string{DetectorEmail, DetectorCreditCard, DetectorBearerToken},
+		Strategy:  StrategyMask,
+	}
+	return
+}
+
+func New(cfg Config) (redactor *Redactor, err error) {
+	if cfg.Strategy == "" {
+		cfg.Strategy = StrategyMask
+	}
+	err = validateStrategy(cfg.Strategy, cfg.HashKey)
+	if err != nil {
+		return
+	}
+
+	redactor = &Redactor{
+		keys:     make(map[string]bool, len(cfg.Keys)),
+		strategy: cfg.Strategy,
+		hashKey:  []byte(cfg.HashKey),
+	}
+
+	for _, key := range cfg.Keys {
+		redactor.keys[normalizeKey(key)] = true
+	}
+
+	for _, name := range cfg.Detectors {
+		detector, ok := detectors[name]
+		if !ok {
+			redactor = nil
+			err = fmt.Errorf("%w: unknown detector %q", ErrInvalidConfig, name)
+			return
+		}
+		detector.name = name
+		detector.strategy = cfg.Strategy
+		redactor.rules = append(redactor.rules, detector)
+	}
+
+	for _, pattern := range cfg.Patterns {
+		strategy := pattern.Strategy
+		if strategy == "" {
+			strategy = cfg.Strategy
+		}
+
+		err = validateStrategy(strategy, cfg.HashKey)
+		if err == nil && pattern.Name == "" {
+			err = fmt.Errorf("%w: pattern name is required", ErrInvalidConfig)
+		}
+		if err != nil {
+			redactor = nil
+			return
+		}
+
+		var compiled *regexp.Regexp
+		compiled, err = regexp.Compile(pattern.Regex)
+		if err != nil {
+			redactor = nil
+			err = fmt.Errorf("%w: pattern %s: %w", ErrInvalidConfig, pattern.Name, err)
+			return
+		}
+
+		redactor.rules = append(redactor.rules, rule{
+			name:     pattern.Name,
+			pattern:  compiled,
+			strategy: strategy,
+		})
+	}
+	return
+}
+
+func (r *Redactor) WithStrategy(strategy string) (redactor *Redactor, err error) {
+	err = validateStrategy(strategy, string(r.hashKey))
+	if err != nil {
+		return
+	}
+
+	copied := *r
+	copied.strategy = strategy
+	copied.rules = make([]rule, len(r.rules))
+	for i, existing := range r.rules {
+		existing.strategy = strategy
+		copied.rules[i] = existing
+	}
+
+	redactor = &copied
+	return
+}
+
+func (r *Redactor) SensitiveKey(key string) (sensitive bool) {
+	if len(r.keys) == 0 {
+		return
+	}
+
+	if r.keys[normalizeKey(key)] {
+		sensitive = true
+		return
+	}
+
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		sensitive = r.keys[normalizeKey(key[i+1:])]
+	}
+	return
+}
+
+func (r *Redactor) String(value string) (redacted string) {
+	redacted = value
+	for _, rule := range r.rules {
+		redacted = rule.pattern.ReplaceAllStringFunc(redacted, func(match string) (replacement string) {
+			if rule.validate != nil && !rule.validate(match) {
+				replacement = match
+				return
+			}
+			replacement = r.replace(rule.name, rule.strategy, match)
+			return
+		})
+	}
+	return
+}
+
+func (r *Redactor) Value(key string, value interface{}) (redacted interface{}) {
+	if key != "" && r.SensitiveKey(key) {
+		redacted = r.replace("key", r.strategy, fmt.Sprint(value))
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		redacted = r.String(v)
+	case map[string]interface{}:
+		redacted = r.Map(v)
+	case map[string]string:
+		m := make(map[string]string, len(v))
+		for name, item := range v {
+			m[name] = fmt.Sprint(r.Value(name, item))
+		}
+		redacted = m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, item := range v {
+			s[i] = r.Value("", item)
+		}
+		redacted = s
+	case []string:
+		s := make([]string, len(v))
+		for i, item := range v {
+			s[i] = r.String(item)
+		}
+		redacted = s
+	default:
+		redacted = value
+	}
+	return
+}
+
+func (r *Redactor) Map(values map[string]interface{}) (redacted map[string]interface{}) {
+	if values == nil {
+		return
+	}
+
+	redacted = make(map[string]interface{}, len(values))
+	for name, value := range values {
+		redacted[name] = r.Value(name, value)
+	}
+	return
+}
+
+func (r *Redactor) replace(name, strategy, value string) (replacement string) {
+	if strategy == StrategyHash {
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(value))
+		replacement = "[" + name + ":" + hex.EncodeToString(mac.Sum(nil))[:hashLength] + "]"
+		return
+	}
+
+	replacement = "[REDACTED:" + name + "]"
+	return
+}
+
+func validateStrategy(strategy, hashKey string) (err error) {
+	switch {
+	case strategy != StrategyMask && strategy != StrategyHash:
+		err = fmt.Errorf("%w: strategy must be %s or %s", ErrInvalidConfig, StrategyMask, StrategyHash)
+	case strategy == StrategyHash && hashKey == "":
+		err = fmt.Errorf("%w: strategy %s requires a hash_key", ErrInvalidConfig, StrategyHash)
+	}
+	return
+}
+
+func normalizeKey(key string) (normalized string) {
+	normalized = strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+	return
+}
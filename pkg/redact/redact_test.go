@@ -0,0 +1,161 @@
+package redact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLuhnValid(t *testing.T) {
+	cases := map[string]bool{
+		"4111 1111 1111 1111":  true,
+		"4111-1111-1111-1111":  true,
+		"5500005555555559":     true,
+		"378282246310005":      true,
+		"4111 1111 1111 1112":  false,
+		"123456789012":         false,
+		"12345678901234567890": false,
+	}
+
+	for number, want := range cases {
+		if got := luhnValid(number); got != want {
+			t.Errorf("luhnValid(%q) = %v, want %v", number, got, want)
+		}
+	}
+}
+
+func TestDetectors(t *testing.T) {
+	redactor, err := New(Config{Detectors: []string{DetectorEmail, DetectorCreditCard, DetectorBearerToken, DetectorIPv4}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	cases := map[string]string{
+		"mail ops@example.com now":              "mail [REDACTED:email] now",
+		"card 4111 1111 1111 1111 declined":     "card [REDACTED:credit_card] declined",
+		"order 4111 1111 1111 1112 shipped":     "order 4111 1111 1111 1112 shipped",
+		"Authorization: Bearer abc.def-123==":   "Authorization: [REDACTED:bearer_token]",
+		"from 10.0.12.7 and 256.1.1.1":          "from [REDACTED:ipv4] and 256.1.1.1",
+		"nothing sensitive here, version 1.2.3": "nothing sensitive here, version 1.2.3",
+	}
+
+	for input, want := range cases {
+		if got := redactor.String(input); got != want {
+			t.Errorf("String(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestHashStrategyIsStableAndKeyed(t *testing.T) {
+	first, err := New(Config{Detectors: []string{DetectorEmail}, Strategy: StrategyHash, HashKey: "one"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	second, err := New(Config{Detectors: []string{DetectorEmail}, Strategy: StrategyHash, HashKey: "two"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	a := first.String("ops@example.com")
+	if a != first.String("ops@example.com") {
+		t.Error("hash is not stable for the same value")
+	}
+	if !strings.HasPrefix(a, "[email:") || len(a) != len("[email:]")+hashLength {
+		t.Errorf("hash replacement = %q", a)
+	}
+	if a == second.String("ops@example.com") {
+		t.Error("hash does not depend on the hash key")
+	}
+	if a == first.String("dev@example.com") {
+		t.Error("different values share a hash")
+	}
+}
+
+func TestSensitiveKeysAndNestedValues(t *testing.T) {
+	redactor, err := New(Config{Keys: []string{"api_key", "password"}, Detectors: []string{DetectorEmail}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	for _, key := range []string{"api_key", "API-Key", "apikey", "labels.api_key", "Password"} {
+		if !redactor.SensitiveKey(key) {
+			t.Errorf("SensitiveKey(%q) = false", key)
+		}
+	}
+	if redactor.SensitiveKey("api_key_id.name") {
+		t.Error("SensitiveKey matched an unrelated key")
+	}
+
+	redacted := redactor.Map(map[string]interface{}{
+		"password": "hunter2",
+		"user":     map[string]interface{}{"email": "ops@example.com", "password": 42},
+		"tags":     []interface{}{"ops@example.com", 7},
+		"labels":   map[string]string{"api_key": "abc"},
+	})
+
+	if redacted["password"] != "[REDACTED:key]" {
+		t.Errorf("password = %v", redacted["password"])
+	}
+	user := redacted["user"].(map[string]interface{})
+	if user["email"] != "[REDACTED:email]" || user["password"] != "[REDACTED:key]" {
+		t.Errorf("user = %v", user)
+	}
+	tags := redacted["tags"].([]interface{})
+	if tags[0] != "[REDACTED:email]" || tags[1] != 7 {
+		t.Errorf("tags = %v", tags)
+	}
+	if redacted["labels"].(map[string]string)["api_key"] != "[REDACTED:key]" {
+		t.Errorf("labels = %v", redacted["labels"])
+	}
+}
+
+func TestCustomPatternsAndStrategies(t *testing.T) {
+	redactor, err := New(Config{
+		Patterns: []Pattern{{Name: "ticket", Regex: `TCK-\d+`, Strategy: StrategyHash}},
+		HashKey:  "k",
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	got := redactor.String("see TCK-1234")
+	if !strings.HasPrefix(got, "see [ticket:") {
+		t.Errorf("String = %q", got)
+	}
+
+	masked, err := redactor.WithStrategy(StrategyMask)
+	if err != nil {
+		t.Fatalf("WithStrategy returned error: %v", err)
+	}
+	if got := masked.String("see TCK-1234"); got != "see [REDACTED:ticket]" {
+		t.Errorf("masked String = %q", got)
+	}
+
+	unkeyed, err := New(Config{Patterns: []Pattern{{Name: "ticket", Regex: `TCK-\d+`}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = unkeyed.WithStrategy(StrategyHash); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("WithStrategy(hash) without a hash key: error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	cases := map[string]Config{
+		"unknown strategy":         {Strategy: "rot13"},
+		"unknown detector":         {Detectors: []string{"ssn"}},
+		"unnamed pattern":          {Patterns: []Pattern{{Regex: "x"}}},
+		"bad regex":                {Patterns: []Pattern{{Name: "p", Regex: "("}}},
+		"hash without key":         {Strategy: StrategyHash},
+		"pattern hash without key": {Patterns: []Pattern{{Name: "p", Regex: "x", Strategy: StrategyHash}}},
+	}
+
+	for name, cfg := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(cfg)
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
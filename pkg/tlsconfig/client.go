@@ -0,0 +1,48 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+type ClientConfig struct {
+	CAFile             string `json:"ca_file,omitempty"`
+	CertFile           string `json:"cert_file,omitempty"`
+	KeyFile            string `json:"key_file,omitempty"`
+	ServerName         string `json:"server_name,omitempty"`
+	MinVersion         string `json:"min_version,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+func NewClientConfig(cfg ClientConfig) (tlsConfig *tls.Config, err error) {
+	tlsConfig = &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	tlsConfig.MinVersion, err = ParseVersion(cfg.MinVersion)
+	if err != nil {
+		tlsConfig = nil
+		return
+	}
+
+	if cfg.CAFile != "" {
+		tlsConfig.RootCAs, err = LoadCertPool(cfg.CAFile)
+		if err != nil {
+			tlsConfig = nil
+			return
+		}
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		var reloader *Reloader
+		reloader, err = NewReloader(cfg.CertFile, cfg.KeyFile, "", DefaultReloadInterval, nil)
+		if err != nil {
+			tlsConfig = nil
+			err = fmt.Errorf("failed to load client certificate: %w", err)
+			return
+		}
+		tlsConfig.GetClientCertificate = reloader.GetClientCertificate
+	}
+	return
+}
@@ -0,0 +1,209 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrInvalidConfig = errors.New("invalid TLS config")
+
+const DefaultReloadInterval = 30 * time.Second
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	interval time.Duration
+	logger   Logger
+
+	certificate atomic.Pointer[tls.Certificate]
+	pool        atomic.Pointer[x509.CertPool]
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	modTimes  map[string]time.Time
+}
+
+func NewReloader(certFile, keyFile, caFile string, interval time.Duration, logger Logger) (reloader *Reloader, err error) {
+	if (certFile == "") != (keyFile == "") {
+		err = fmt.Errorf("%w: certificate and key files must be set together", ErrInvalidConfig)
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	reloader = &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		interval: interval,
+		logger:   logger,
+		modTimes: make(map[string]time.Time),
+	}
+
+	err = reloader.Reload(context.Background())
+	if err != nil {
+		reloader = nil
+	}
+	return
+}
+
+func (r *Reloader) Reload(ctx context.Context) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err = r.reload(ctx)
+	return
+}
+
+func (r *Reloader) Certificate() (certificate *tls.Certificate) {
+	certificate = r.certificate.Load()
+	return
+}
+
+func (r *Reloader) CertPool() (pool *x509.CertPool) {
+	pool = r.pool.Load()
+	return
+}
+
+func (r *Reloader) GetCertificate(hello *tls.ClientHelloInfo) (certificate *tls.Certificate, err error) {
+	r.check(hello.Context())
+
+	certificate = r.certificate.Load()
+	if certificate == nil {
+		err = fmt.Errorf("%w: no server certificate configured", ErrInvalidConfig)
+	}
+	return
+}
+
+func (r *Reloader) GetClientCertificate(request *tls.CertificateRequestInfo) (certificate *tls.Certificate, err error) {
+	r.check(request.Context())
+
+	certificate = r.certificate.Load()
+	if certificate == nil {
+		certificate = &tls.Certificate{}
+	}
+	return
+}
+
+func (r *Reloader) check(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) < r.interval {
+		return
+	}
+	r.checkedAt = time.Now()
+
+	changed := false
+	for _, path := range r.files() {
+		info, err := os.Stat(path)
+		if err != nil {
+			r.logError(ctx, "failed to stat TLS file, keeping previous certificates", path, err)
+			return
+		}
+		if !info.ModTime().Equal(r.modTimes[path]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+
+	err := r.reload(ctx)
+	if err != nil {
+		r.logError(ctx, "failed to reload TLS files, keeping previous certificates", r.certFile, err)
+	}
+}
+
+func (r *Reloader) reload(ctx context.Context) (err error) {
+	r.checkedAt = time.Now()
+
+	modTimes := make(map[string]time.Time, 3)
+	for _, path := range r.files() {
+		var info os.FileInfo
+		info, err = os.Stat(path)
+		if err != nil {
+			err = fmt.Errorf("failed to stat %s: %w", path, err)
+			return
+		}
+		modTimes[path] = info.ModTime()
+	}
+
+	var certificate *tls.Certificate
+	if r.certFile != "" {
+		var loaded tls.Certificate
+		loaded, err = tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			err = fmt.Errorf("failed to load certificate %s: %w", r.certFile, err)
+			return
+		}
+		certificate = &loaded
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pool, err = LoadCertPool(r.caFile)
+		if err != nil {
+			return
+		}
+	}
+
+	r.modTimes = modTimes
+	r.certificate.Store(certificate)
+	r.pool.Store(pool)
+
+	if certificate != nil && certificate.Leaf != nil && r.logger != nil {
+		r.logger.InfoContext(ctx, "TLS certificate loaded",
+			"file", r.certFile,
+			"subject", certificate.Leaf.Subject.String(),
+			"not_after", certificate.Leaf.NotAfter.Format(time.RFC3339),
+		)
+	}
+	return
+}
+
+func (r *Reloader) files() (paths []string) {
+	for _, path := range []string{r.certFile, r.keyFile, r.caFile} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return
+}
+
+func (r *Reloader) logError(ctx context.Context, msg, path string, err error) {
+	if r.logger == nil {
+		return
+	}
+	r.logger.ErrorContext(ctx, msg, "file", path, "error", err.Error())
+}
+
+func LoadCertPool(path string) (pool *x509.CertPool, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("failed to read CA bundle %s: %w", path, err)
+		return
+	}
+
+	pool = x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		pool = nil
+		err = fmt.Errorf("%w: no certificates found in CA bundle %s", ErrInvalidConfig, path)
+	}
+	return
+}
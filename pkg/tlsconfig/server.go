@@ -0,0 +1,126 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/config"
+)
+
+const (
+	ClientAuthNone          = "none"
+	ClientAuthVerifyIfGiven = "verify_if_given"
+	ClientAuthRequire       = "require"
+)
+
+type ServerConfig struct {
+	CertFile       string
+	KeyFile        string
+	ClientCAFile   string
+	ClientAuth     string
+	MinVersion     string
+	ReloadInterval time.Duration
+}
+
+func ServerConfigFromEnv(prefix string) (cfg ServerConfig) {
+	cfg = ServerConfig{
+		CertFile:       config.GetEnv(prefix+"TLS_CERT_FILE", ""),
+		KeyFile:        config.GetEnv(prefix+"TLS_KEY_FILE", ""),
+		ClientCAFile:   config.GetEnv(prefix+"TLS_CLIENT_CA_FILE", ""),
+		ClientAuth:     config.GetEnv(prefix+"TLS_CLIENT_AUTH", ""),
+		MinVersion:     config.GetEnv(prefix+"TLS_MIN_VERSION", ""),
+		ReloadInterval: config.GetEnvDuration(prefix+"TLS_RELOAD_INTERVAL", DefaultReloadInterval),
+	}
+	return
+}
+
+func (c ServerConfig) Enabled() (enabled bool) {
+	enabled = c.CertFile != "" || c.KeyFile != ""
+	return
+}
+
+func NewServerConfig(cfg ServerConfig, logger Logger) (tlsConfig *tls.Config, err error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		err = fmt.Errorf("%w: server certificate and key files are required", ErrInvalidConfig)
+		return
+	}
+
+	var minVersion uint16
+	minVersion, err = ParseVersion(cfg.MinVersion)
+	if err != nil {
+		return
+	}
+
+	var clientAuth tls.ClientAuthType
+	clientAuth, err = parseClientAuth(cfg.ClientAuth, cfg.ClientCAFile)
+	if err != nil {
+		return
+	}
+
+	var reloader *Reloader
+	reloader, err = NewReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile, cfg.ReloadInterval, logger)
+	if err != nil {
+		return
+	}
+
+	base := &tls.Config{
+		MinVersion:     minVersion,
+		ClientAuth:     clientAuth,
+		GetCertificate: reloader.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	tlsConfig = &tls.Config{
+		MinVersion: minVersion,
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (handshake *tls.Config, err error) {
+			reloader.check(hello.Context())
+
+			handshake = base.Clone()
+			handshake.ClientCAs = reloader.CertPool()
+			return
+		},
+	}
+	return
+}
+
+func ParseVersion(value string) (version uint16, err error) {
+	switch strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "TLS") {
+	case "", "1.2":
+		version = tls.VersionTLS12
+	case "1.3":
+		version = tls.VersionTLS13
+	case "1.1":
+		version = tls.VersionTLS11
+	case "1.0":
+		version = tls.VersionTLS10
+	default:
+		err = fmt.Errorf("%w: unknown minimum TLS version %q", ErrInvalidConfig, value)
+	}
+	return
+}
+
+func parseClientAuth(value, caFile string) (clientAuth tls.ClientAuthType, err error) {
+	if value == "" && caFile != "" {
+		value = ClientAuthRequire
+	}
+
+	switch value {
+	case "", ClientAuthNone:
+		clientAuth = tls.NoClientCert
+		return
+	case ClientAuthVerifyIfGiven:
+		clientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		clientAuth = tls.RequireAndVerifyClientCert
+	default:
+		err = fmt.Errorf("%w: unknown client auth mode %q", ErrInvalidConfig, value)
+		return
+	}
+
+	if caFile == "" {
+		err = fmt.Errorf("%w: client auth mode %s requires a client CA file", ErrInvalidConfig, value)
+	}
+	return
+}
@@ -11,8 +11,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/smartcom/integration-platform/pkg/config"
+	"github.com/smartcom/integration-platform/pkg/health"
 	"github.com/smartcom/integration-platform/pkg/logger"
-	"github.com/smartcom/integration-platform/services/external-endpoint/internal/handler"
+	"github.com/smartcom/integration-platform/pkg/redact"
+	"github.com/smartcom/integration-platform/pkg/tlsconfig"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/journal"
+	"github.com/smartcom/integration-platform/services/external-endpoint/server"
 )
 
 func main() {
@@ -27,25 +31,68 @@ func run() (err error) {
 	log := logger.NewDefault()
 	log.Info("starting external alert endpoint service")
 
+	redactionConfig := redact.DefaultConfig()
+	if redactionFile := config.GetEnv("REDACTION_FILE", ""); redactionFile != "" {
+		redactionConfig = redact.Config{}
+		err = config.LoadJSONFile(redactionFile, &redactionConfig)
+		if err != nil {
+			return
+		}
+	}
+
+	var redactor *redact.Redactor
+	redactor, err = redact.New(redactionConfig)
+	if err != nil {
+		return
+	}
+	log.SetRedactor(redactor)
+
 	port := config.GetEnv("PORT", "8081")
 
-	alertHandler := handler.NewAlertHandler(log)
+	serverConfig := server.Config{
+		Logger:             log,
+		Redactor:           redactor,
+		JournalCapacity:    config.GetEnvInt("JOURNAL_CAPACITY", journal.DefaultCapacity),
+		HealthCheckTimeout: config.GetEnvDuration("HEALTH_CHECK_TIMEOUT", health.DefaultCheckTimeout),
+	}
+
+	faultConfigFile := config.GetEnv("FAULT_CONFIG_FILE", "")
+	if faultConfigFile != "" {
+		var faultConfig server.FaultConfig
+		err = config.LoadJSONFile(faultConfigFile, &faultConfig)
+		if err != nil {
+			err = fmt.Errorf("failed to load fault config: %w", err)
+			return
+		}
+		serverConfig.Faults = &faultConfig
+	}
 
 	gin.SetMode(gin.ReleaseMode)
-	router := gin.New()
-	router.Use(gin.Recovery())
 
-	alertHandler.RegisterRoutes(router)
+	var endpoint *server.Server
+	endpoint, err = server.New(serverConfig)
+	if err != nil {
+		err = fmt.Errorf("failed to build server: %w", err)
+		return
+	}
 
-	server := &http.Server{
+	httpServer := &http.Server{
 		Addr:    ":" + port,
-		Handler: router,
+		Handler: endpoint.Router,
+	}
+
+	if tlsConfig := tlsconfig.ServerConfigFromEnv(""); tlsConfig.Enabled() {
+		httpServer.TLSConfig, err = tlsconfig.NewServerConfig(tlsConfig, log)
+		if err != nil {
+			err = fmt.Errorf("failed to configure server TLS: %w", err)
+			return
+		}
 	}
 
 	serverErrors := make(chan error, 1)
 	go func() {
-		log.Info("http server listening", "port", port)
-		serverErrors <- server.ListenAndServe()
+		log.Info("http server listening", "port", port, "tls", httpServer.TLSConfig != nil)
+		serverErrors <- listenAndServe(httpServer)
 	}()
 
 	shutdown := make(chan os.Signal, 1)
@@ -58,12 +105,15 @@ func run() (err error) {
 	case sig := <-shutdown:
 		log.Info("received shutdown signal", "signal", sig.String())
 
+		endpoint.Health.SetShuttingDown()
+		time.Sleep(config.GetEnvDuration("SHUTDOWN_READINESS_DELAY", 0))
+
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer shutdownCancel()
 
-		err = server.Shutdown(shutdownCtx)
+		err = httpServer.Shutdown(shutdownCtx)
 		if err != nil {
-			err = server.Close()
+			err = httpServer.Close()
 			err = fmt.Errorf("failed to gracefully shutdown server: %w", err)
 			return
 		}
@@ -73,3 +123,12 @@ func run() (err error) {
 
 	return
 }
+
+func listenAndServe(server *http.Server) (err error) {
+	if server.TLSConfig != nil {
+		err = server.ListenAndServeTLS("", "")
+		return
+	}
+	err = server.ListenAndServe()
+	return
+}
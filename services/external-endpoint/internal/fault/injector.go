@@ -0,0 +1,251 @@
+package fault
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ScenarioHeader = "X-Fault-Scenario"
+
+type Injector struct {
+	mu       sync.RWMutex
+	cfg      Config
+	counters map[string]int
+	logger   InjectorLogger
+}
+
+type InjectorLogger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+type decision struct {
+	name     string
+	scenario Scenario
+	fail     bool
+}
+
+const MaxTimeoutHold = 5 * time.Minute
+
+func NewInjector(logger InjectorLogger) (injector *Injector) {
+	injector = &Injector{
+		cfg:      Config{Scenarios: make(map[string]Scenario)},
+		counters: make(map[string]int),
+		logger:   logger,
+	}
+	return
+}
+
+func (i *Injector) Config() (cfg Config) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	cfg = Config{
+		Scenarios: make(map[string]Scenario, len(i.cfg.Scenarios)),
+		Global:    i.cfg.Global,
+		Paths:     make(map[string]string, len(i.cfg.Paths)),
+	}
+	for name, scenario := range i.cfg.Scenarios {
+		cfg.Scenarios[name] = scenario
+	}
+	for path, name := range i.cfg.Paths {
+		cfg.Paths[path] = name
+	}
+	return
+}
+
+func (i *Injector) SetConfig(cfg Config) (err error) {
+	if cfg.Scenarios == nil {
+		cfg.Scenarios = make(map[string]Scenario)
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.cfg = cfg
+	i.counters = make(map[string]int)
+	return
+}
+
+func (i *Injector) PutScenario(name string, scenario Scenario) (err error) {
+	err = scenario.Validate()
+	if err != nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.cfg.Scenarios[name] = scenario
+	delete(i.counters, name)
+	return
+}
+
+func (i *Injector) DeleteScenario(name string) (err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, ok := i.cfg.Scenarios[name]; !ok {
+		err = fmt.Errorf("%w: %s", ErrUnknownScenario, name)
+		return
+	}
+
+	delete(i.cfg.Scenarios, name)
+	delete(i.counters, name)
+	if i.cfg.Global == name {
+		i.cfg.Global = ""
+	}
+	for path, scenarioName := range i.cfg.Paths {
+		if scenarioName == name {
+			delete(i.cfg.Paths, path)
+		}
+	}
+	return
+}
+
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.cfg = Config{Scenarios: make(map[string]Scenario)}
+	i.counters = make(map[string]int)
+}
+
+func (i *Injector) decide(r *http.Request, path string) (d decision, ok bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	d.name = r.Header.Get(ScenarioHeader)
+	if d.name == "" {
+		d.name = i.cfg.Paths[path]
+	}
+	if d.name == "" {
+		d.name = i.cfg.Global
+	}
+	if d.name == "" {
+		return
+	}
+
+	d.scenario, ok = i.cfg.Scenarios[d.name]
+	if !ok {
+		return
+	}
+
+	i.counters[d.name]++
+	count := i.counters[d.name]
+
+	switch {
+	case d.scenario.FailFirst > 0 && count <= d.scenario.FailFirst:
+		d.fail = true
+	case d.scenario.FailEvery > 0 && count%d.scenario.FailEvery == 0:
+		d.fail = true
+	case d.scenario.ErrorRate > 0 && rand.Float64() < d.scenario.ErrorRate:
+		d.fail = true
+	case !d.scenario.HasRate():
+		d.fail = true
+	}
+	return
+}
+
+func (i *Injector) Middleware() (middleware gin.HandlerFunc) {
+	middleware = func(c *gin.Context) {
+		d, ok := i.decide(c.Request, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		scenario := d.scenario
+
+		delay := scenario.Latency.Sample()
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				c.Abort()
+				return
+			}
+		}
+
+		switch {
+		case d.fail && scenario.Timeout:
+			i.logger.InfoContext(ctx, "fault injected", "scenario", d.name, "fault", "timeout")
+			select {
+			case <-ctx.Done():
+			case <-time.After(MaxTimeoutHold):
+			}
+			c.AbortWithStatus(http.StatusGatewayTimeout)
+			return
+		case d.fail && scenario.ConnectionReset:
+			i.logger.InfoContext(ctx, "fault injected", "scenario", d.name, "fault", "connection_reset")
+			i.resetConnection(c)
+			return
+		case d.fail && scenario.Malformed:
+			i.logger.InfoContext(ctx, "fault injected", "scenario", d.name, "fault", "malformed")
+			c.Data(http.StatusOK, "application/json", []byte(`{"status": "rec`))
+			c.Abort()
+			return
+		case d.fail && (scenario.StatusCode != 0 || scenario.HasRate()):
+			statusCode := scenario.StatusCode
+			if statusCode == 0 {
+				statusCode = http.StatusInternalServerError
+			}
+			if scenario.RetryAfter != "" {
+				c.Header("Retry-After", scenario.RetryAfter)
+			}
+
+			i.logger.InfoContext(ctx, "fault injected",
+				"scenario", d.name,
+				"fault", "status",
+				"status_code", statusCode,
+			)
+			c.AbortWithStatusJSON(statusCode, gin.H{
+				"error":    "injected fault",
+				"scenario": d.name,
+			})
+			return
+		}
+
+		c.Header("X-Fault-Scenario-Applied", d.name)
+		c.Next()
+	}
+	return
+}
+
+func (i *Injector) resetConnection(c *gin.Context) {
+	hijacker, ok := c.Writer.(http.Hijacker)
+	if !ok {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		i.logger.ErrorContext(c.Request.Context(), "failed to hijack connection", "error", err.Error())
+		c.Abort()
+		return
+	}
+
+	if tlsConn, isTLS := conn.(*tls.Conn); isTLS {
+		conn = tlsConn.NetConn()
+	}
+	if tcpConn, isTCP := conn.(*net.TCPConn); isTCP {
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+	c.Abort()
+}
@@ -0,0 +1,109 @@
+package fault
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/logger"
+)
+
+func newTestServer(t *testing.T, scenario Scenario, useTLS bool) (server *httptest.Server) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	injector := NewInjector(logger.New(io.Discard, slog.LevelError))
+	err := injector.SetConfig(Config{Scenarios: map[string]Scenario{"test": scenario}, Global: "test"})
+	if err != nil {
+		t.Fatalf("SetConfig returned error: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(injector.Middleware())
+	router.GET("/alerts", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "received"})
+	})
+
+	if useTLS {
+		server = httptest.NewTLSServer(router)
+	} else {
+		server = httptest.NewServer(router)
+	}
+	t.Cleanup(server.Close)
+	return
+}
+
+func get(server *httptest.Server, timeout time.Duration) (status int, body string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/alerts", nil)
+	if err != nil {
+		return
+	}
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	status = resp.StatusCode
+	body = string(data)
+	return
+}
+
+func TestMiddlewareGatesFaultModesOnRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario Scenario
+		want     []int
+	}{
+		{"status every third", Scenario{FailEvery: 3, StatusCode: 503}, []int{200, 200, 503, 200}},
+		{"status without rate", Scenario{StatusCode: 502}, []int{502, 502}},
+		{"latency only", Scenario{Latency: &Latency{Distribution: "fixed", FixedMs: 1}}, []int{200, 200}},
+		{"malformed first", Scenario{FailFirst: 1, Malformed: true}, []int{-1, 200, 200}},
+		{"malformed without rate", Scenario{Malformed: true}, []int{-1, -1}},
+		{"timeout every second", Scenario{FailEvery: 2, Timeout: true}, []int{200, 0, 200}},
+		{"reset first", Scenario{FailFirst: 1, ConnectionReset: true}, []int{0, 200}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.scenario, false)
+
+			for i, want := range tt.want {
+				status, body, err := get(server, 200*time.Millisecond)
+				switch {
+				case want == 0:
+					if err == nil {
+						t.Errorf("request %d: status = %d, want failed request", i+1, status)
+					}
+				case err != nil:
+					t.Errorf("request %d: error = %v", i+1, err)
+				case want == -1:
+					if status != http.StatusOK || body != `{"status": "rec` {
+						t.Errorf("request %d: got %d %q, want malformed body", i+1, status, body)
+					}
+				case status != want:
+					t.Errorf("request %d: status = %d, want %d", i+1, status, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddlewareResetsTLSConnection(t *testing.T) {
+	server := newTestServer(t, Scenario{ConnectionReset: true}, true)
+
+	status, _, err := get(server, time.Second)
+	if err == nil {
+		t.Fatalf("status = %d, want connection reset", status)
+	}
+}
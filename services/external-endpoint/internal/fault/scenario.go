@@ -0,0 +1,122 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+var (
+	ErrUnknownScenario = errors.New("unknown fault scenario")
+	ErrInvalidScenario = errors.New("invalid fault scenario")
+)
+
+type Scenario struct {
+	ErrorRate       float64  `json:"error_rate,omitempty"`
+	FailEvery       int      `json:"fail_every,omitempty"`
+	FailFirst       int      `json:"fail_first,omitempty"`
+	StatusCode      int      `json:"status_code,omitempty"`
+	RetryAfter      string   `json:"retry_after,omitempty"`
+	Latency         *Latency `json:"latency,omitempty"`
+	Timeout         bool     `json:"timeout,omitempty"`
+	ConnectionReset bool     `json:"connection_reset,omitempty"`
+	Malformed       bool     `json:"malformed,omitempty"`
+}
+
+type Latency struct {
+	Distribution string  `json:"distribution"`
+	FixedMs      float64 `json:"fixed_ms,omitempty"`
+	MinMs        float64 `json:"min_ms,omitempty"`
+	MaxMs        float64 `json:"max_ms,omitempty"`
+	MeanMs       float64 `json:"mean_ms,omitempty"`
+	StdDevMs     float64 `json:"stddev_ms,omitempty"`
+}
+
+type Config struct {
+	Scenarios map[string]Scenario `json:"scenarios"`
+	Global    string              `json:"global,omitempty"`
+	Paths     map[string]string   `json:"paths,omitempty"`
+}
+
+func (s Scenario) Validate() (err error) {
+	if s.ErrorRate < 0 || s.ErrorRate > 1 {
+		err = fmt.Errorf("%w: error_rate must be between 0 and 1", ErrInvalidScenario)
+		return
+	}
+	if s.FailEvery < 0 || s.FailFirst < 0 {
+		err = fmt.Errorf("%w: fail_every and fail_first must not be negative", ErrInvalidScenario)
+		return
+	}
+	if s.StatusCode != 0 && (s.StatusCode < 100 || s.StatusCode > 599) {
+		err = fmt.Errorf("%w: status_code must be a valid HTTP status", ErrInvalidScenario)
+		return
+	}
+	if s.Latency != nil {
+		switch s.Latency.Distribution {
+		case "fixed", "uniform", "normal", "exponential":
+		default:
+			err = fmt.Errorf("%w: latency distribution must be fixed, uniform, normal or exponential", ErrInvalidScenario)
+			return
+		}
+	}
+	return
+}
+
+func (s Scenario) HasRate() (hasRate bool) {
+	hasRate = s.ErrorRate > 0 || s.FailEvery > 0 || s.FailFirst > 0
+	return
+}
+
+func (c Config) Validate() (err error) {
+	for name, scenario := range c.Scenarios {
+		err = scenario.Validate()
+		if err != nil {
+			err = fmt.Errorf("scenario %s: %w", name, err)
+			return
+		}
+	}
+
+	if c.Global != "" {
+		if _, ok := c.Scenarios[c.Global]; !ok {
+			err = fmt.Errorf("global: %w: %s", ErrUnknownScenario, c.Global)
+			return
+		}
+	}
+
+	for path, name := range c.Paths {
+		if _, ok := c.Scenarios[name]; !ok {
+			err = fmt.Errorf("path %s: %w: %s", path, ErrUnknownScenario, name)
+			return
+		}
+	}
+	return
+}
+
+func (l *Latency) Sample() (delay time.Duration) {
+	if l == nil {
+		return
+	}
+
+	var ms float64
+	switch l.Distribution {
+	case "fixed":
+		ms = l.FixedMs
+	case "uniform":
+		ms = l.MinMs + rand.Float64()*(l.MaxMs-l.MinMs)
+	case "normal":
+		ms = l.MeanMs + rand.NormFloat64()*l.StdDevMs
+	case "exponential":
+		ms = rand.ExpFloat64() * l.MeanMs
+	}
+
+	if l.MaxMs > 0 {
+		ms = math.Min(ms, l.MaxMs)
+	}
+	ms = math.Max(ms, l.MinMs)
+	ms = math.Max(ms, 0)
+
+	delay = time.Duration(ms * float64(time.Millisecond))
+	return
+}
@@ -2,13 +2,20 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/redact"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/journal"
 )
 
 type AlertHandler struct {
-	logger AlertLogger
+	logger   AlertLogger
+	faults   gin.HandlerFunc
+	journal  *journal.Journal
+	redactor *redact.Redactor
 }
 
 type AlertLogger interface {
@@ -16,9 +23,12 @@ type AlertLogger interface {
 	ErrorContext(ctx context.Context, msg string, args ...any)
 }
 
-func NewAlertHandler(logger AlertLogger) (handler *AlertHandler) {
+func NewAlertHandler(logger AlertLogger, faults gin.HandlerFunc, j *journal.Journal, redactor *redact.Redactor) (handler *AlertHandler) {
 	handler = &AlertHandler{
-		logger: logger,
+		logger:   logger,
+		faults:   faults,
+		journal:  j,
+		redactor: redactor,
 	}
 	return
 }
@@ -28,11 +38,32 @@ func (h *AlertHandler) HandleAlert(c *gin.Context) {
 	err := c.ShouldBindJSON(&payload)
 	if err != nil {
 		h.logger.ErrorContext(c.Request.Context(), "invalid payload", "error", err.Error())
-		c.JSON(http.StatusOK, gin.H{"status": "received"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
 		return
 	}
 
 	correlationID := c.GetHeader("X-Correlation-ID")
+
+	headers := make(map[string]string, len(c.Request.Header))
+	for key := range c.Request.Header {
+		headers[key] = c.Request.Header.Get(key)
+	}
+
+	var eventID string
+	if value, ok := payload["event_id"]; ok && value != nil {
+		eventID = fmt.Sprint(value)
+	}
+
+	payload = h.redact(payload)
+	headers = h.redactor.Value("", headers).(map[string]string)
+
+	h.journal.Record(journal.Entry{
+		Path:          c.Request.URL.Path,
+		CorrelationID: correlationID,
+		EventID:       eventID,
+		Headers:       headers,
+		Payload:       payload,
+	})
 	if correlationID != "" {
 		ctx := context.WithValue(c.Request.Context(), "correlation_id", correlationID)
 		h.logger.InfoContext(ctx, "alert received", "payload", payload)
@@ -43,14 +74,28 @@ func (h *AlertHandler) HandleAlert(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "received"})
 }
 
-func (h *AlertHandler) HandleHealth(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "external-endpoint",
-	})
+func (h *AlertHandler) redact(payload map[string]interface{}) (redacted map[string]interface{}) {
+	redacted = h.redactor.Map(payload)
+
+	encoded, ok := payload["metadata"].(string)
+	if !ok {
+		return
+	}
+
+	var metadata map[string]interface{}
+	err := json.Unmarshal([]byte(encoded), &metadata)
+	if err != nil {
+		return
+	}
+
+	var data []byte
+	data, err = json.Marshal(h.redactor.Map(metadata))
+	if err == nil {
+		redacted["metadata"] = string(data)
+	}
+	return
 }
 
 func (h *AlertHandler) RegisterRoutes(router *gin.Engine) {
-	router.GET("/health", h.HandleHealth)
-	router.POST("/external/alerts", h.HandleAlert)
+	router.POST("/external/alerts", h.faults, h.HandleAlert)
 }
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/pkg/redact"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/journal"
+)
+
+func TestHandleAlertRedactsJournal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	redactor, err := redact.New(redact.DefaultConfig())
+	if err != nil {
+		t.Fatalf("redact.New returned error: %v", err)
+	}
+
+	alertJournal := journal.New(10)
+	alertHandler := NewAlertHandler(logger.New(io.Discard, slog.LevelError), func(c *gin.Context) {}, alertJournal, redactor)
+	router := gin.New()
+	alertHandler.RegisterRoutes(router)
+
+	body := `{"event_id":"evt-1","message":"contact ops@example.com","metadata":"{\"password\":\"hunter2\",\"region\":\"eu\"}"}`
+	req := httptest.NewRequest(http.MethodPost, "/external/alerts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc.def.ghi")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	entries := alertJournal.List(journal.Filter{})
+	if len(entries) != 1 {
+		t.Fatalf("journal entries = %d, want 1", len(entries))
+	}
+	entry := entries[0]
+
+	if entry.EventID != "evt-1" {
+		t.Errorf("EventID = %q, want %q", entry.EventID, "evt-1")
+	}
+	if message := entry.Payload["message"]; message != "contact [REDACTED:email]" {
+		t.Errorf("message = %v, want %v", message, "contact [REDACTED:email]")
+	}
+	if header := entry.Headers["Authorization"]; header != "[REDACTED:key]" {
+		t.Errorf("Authorization header = %q, want %q", header, "[REDACTED:key]")
+	}
+
+	var metadata map[string]interface{}
+	err = json.Unmarshal([]byte(entry.Payload["metadata"].(string)), &metadata)
+	if err != nil {
+		t.Fatalf("metadata is not JSON: %v", err)
+	}
+	if metadata["password"] != "[REDACTED:key]" {
+		t.Errorf("metadata password = %v, want %v", metadata["password"], "[REDACTED:key]")
+	}
+	if metadata["region"] != "eu" {
+		t.Errorf("metadata region = %v, want %v", metadata["region"], "eu")
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/fault"
+)
+
+type FaultHandler struct {
+	injector *fault.Injector
+	logger   AlertLogger
+}
+
+func NewFaultHandler(injector *fault.Injector, logger AlertLogger) (handler *FaultHandler) {
+	handler = &FaultHandler{
+		injector: injector,
+		logger:   logger,
+	}
+	return
+}
+
+func (h *FaultHandler) HandleGetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, h.injector.Config())
+}
+
+func (h *FaultHandler) HandleSetConfig(c *gin.Context) {
+	var cfg fault.Config
+	err := c.ShouldBindJSON(&cfg)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	err = h.injector.SetConfig(cfg)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fault configuration replaced",
+		"scenarios", len(cfg.Scenarios),
+		"global", cfg.Global,
+	)
+	c.JSON(http.StatusOK, h.injector.Config())
+}
+
+func (h *FaultHandler) HandleReset(c *gin.Context) {
+	h.injector.Reset()
+	h.logger.InfoContext(c.Request.Context(), "fault configuration reset")
+	c.JSON(http.StatusOK, h.injector.Config())
+}
+
+func (h *FaultHandler) HandlePutScenario(c *gin.Context) {
+	var scenario fault.Scenario
+	err := c.ShouldBindJSON(&scenario)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	name := c.Param("name")
+	err = h.injector.PutScenario(name, scenario)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fault scenario updated", "scenario", name)
+	c.JSON(http.StatusOK, scenario)
+}
+
+func (h *FaultHandler) HandleDeleteScenario(c *gin.Context) {
+	name := c.Param("name")
+	err := h.injector.DeleteScenario(name)
+	if errors.Is(err, fault.ErrUnknownScenario) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "scenario not found"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "fault scenario deleted", "scenario", name)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FaultHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/control/faults", h.HandleGetConfig)
+	router.PUT("/control/faults", h.HandleSetConfig)
+	router.DELETE("/control/faults", h.HandleReset)
+	router.PUT("/control/faults/scenarios/:name", h.HandlePutScenario)
+	router.DELETE("/control/faults/scenarios/:name", h.HandleDeleteScenario)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/journal"
+)
+
+type JournalHandler struct {
+	journal    *journal.Journal
+	maxTimeout time.Duration
+}
+
+const (
+	DefaultWaitTimeout = 5 * time.Second
+	MaxWaitTimeout     = 2 * time.Minute
+)
+
+func NewJournalHandler(j *journal.Journal) (handler *JournalHandler) {
+	handler = &JournalHandler{
+		journal:    j,
+		maxTimeout: MaxWaitTimeout,
+	}
+	return
+}
+
+func (h *JournalHandler) HandleList(c *gin.Context) {
+	filter, err := parseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries := h.journal.List(filter)
+	c.JSON(http.StatusOK, gin.H{
+		"count":   len(entries),
+		"entries": entries,
+		"stats":   h.journal.Stats(),
+	})
+}
+
+func (h *JournalHandler) HandleWait(c *gin.Context) {
+	filter, err := parseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	count, err := parsePositiveInt(c.DefaultQuery("count", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count must be a positive integer"})
+		return
+	}
+
+	timeout := DefaultWaitTimeout
+	if raw := c.Query("timeout"); raw != "" {
+		timeout, err = time.ParseDuration(raw)
+		if err != nil || timeout <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "timeout must be a positive duration"})
+			return
+		}
+	}
+	if timeout > h.maxTimeout {
+		timeout = h.maxTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+
+	entries, err := h.journal.Wait(ctx, filter, count)
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(http.StatusRequestTimeout, gin.H{
+			"error":    "timed out waiting for entries",
+			"expected": count,
+			"received": h.journal.Count(filter),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":   len(entries),
+		"entries": entries,
+	})
+}
+
+func (h *JournalHandler) HandleAssert(c *gin.Context) {
+	filter, err := parseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expected, err := strconv.Atoi(c.Query("count"))
+	if err != nil || expected < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count must be a non-negative integer"})
+		return
+	}
+
+	actual := h.journal.Count(filter)
+	if actual != expected {
+		c.JSON(http.StatusExpectationFailed, gin.H{
+			"passed":   false,
+			"expected": expected,
+			"actual":   actual,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"passed":   true,
+		"expected": expected,
+		"actual":   actual,
+	})
+}
+
+func (h *JournalHandler) HandleReset(c *gin.Context) {
+	h.journal.Reset()
+	c.Status(http.StatusNoContent)
+}
+
+func (h *JournalHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/journal", h.HandleList)
+	router.GET("/journal/wait", h.HandleWait)
+	router.GET("/journal/assert", h.HandleAssert)
+	router.DELETE("/journal", h.HandleReset)
+}
+
+func parseFilter(c *gin.Context) (filter journal.Filter, err error) {
+	filter.CorrelationID = c.Query("correlation_id")
+	filter.EventID = c.Query("event_id")
+
+	if raw := c.Query("after"); raw != "" {
+		filter.AfterSequence, err = strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			err = errors.New("after must be a sequence number")
+			return
+		}
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		filter.Limit, err = parsePositiveInt(raw)
+		if err != nil {
+			err = errors.New("limit must be a positive integer")
+			return
+		}
+	}
+	return
+}
+
+func parsePositiveInt(raw string) (value int, err error) {
+	value, err = strconv.Atoi(raw)
+	if err == nil && value <= 0 {
+		err = errors.New("value must be positive")
+	}
+	return
+}
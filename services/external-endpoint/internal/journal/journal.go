@@ -0,0 +1,170 @@
+package journal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Entry struct {
+	Sequence      uint64                 `json:"sequence"`
+	ReceivedAt    time.Time              `json:"received_at"`
+	Path          string                 `json:"path"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	EventID       string                 `json:"event_id,omitempty"`
+	Headers       map[string]string      `json:"headers"`
+	Payload       map[string]interface{} `json:"payload"`
+}
+
+type Filter struct {
+	CorrelationID string
+	EventID       string
+	AfterSequence uint64
+	Limit         int
+}
+
+type Journal struct {
+	mu       sync.RWMutex
+	entries  []Entry
+	start    int
+	capacity int
+	sequence uint64
+	dropped  uint64
+	changed  chan struct{}
+}
+
+type Stats struct {
+	Size     int    `json:"size"`
+	Capacity int    `json:"capacity"`
+	Total    uint64 `json:"total"`
+	Dropped  uint64 `json:"dropped"`
+}
+
+const DefaultCapacity = 10000
+
+func New(capacity int) (j *Journal) {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	j = &Journal{
+		entries:  make([]Entry, 0, capacity),
+		capacity: capacity,
+		changed:  make(chan struct{}),
+	}
+	return
+}
+
+func (j *Journal) Record(entry Entry) (recorded Entry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.sequence++
+	entry.Sequence = j.sequence
+	if entry.ReceivedAt.IsZero() {
+		entry.ReceivedAt = time.Now().UTC()
+	}
+
+	if len(j.entries) < j.capacity {
+		j.entries = append(j.entries, entry)
+	} else {
+		j.entries[j.start] = entry
+		j.start = (j.start + 1) % j.capacity
+		j.dropped++
+	}
+
+	close(j.changed)
+	j.changed = make(chan struct{})
+
+	recorded = entry
+	return
+}
+
+func (j *Journal) List(filter Filter) (entries []Entry) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	entries = j.matchLocked(filter)
+	return
+}
+
+func (j *Journal) Count(filter Filter) (count int) {
+	filter.Limit = 0
+	count = len(j.List(filter))
+	return
+}
+
+func (j *Journal) Wait(ctx context.Context, filter Filter, count int) (entries []Entry, err error) {
+	for {
+		j.mu.RLock()
+		entries = j.matchLocked(Filter{
+			CorrelationID: filter.CorrelationID,
+			EventID:       filter.EventID,
+			AfterSequence: filter.AfterSequence,
+		})
+		changed := j.changed
+		j.mu.RUnlock()
+
+		if len(entries) >= count {
+			if filter.Limit > 0 && len(entries) > filter.Limit {
+				entries = entries[:filter.Limit]
+			}
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+	}
+}
+
+func (j *Journal) Reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	clear(j.entries)
+	j.entries = j.entries[:0]
+	j.start = 0
+	j.dropped = 0
+
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+func (j *Journal) Stats() (stats Stats) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	stats = Stats{
+		Size:     len(j.entries),
+		Capacity: j.capacity,
+		Total:    j.sequence,
+		Dropped:  j.dropped,
+	}
+	return
+}
+
+func (j *Journal) matchLocked(filter Filter) (entries []Entry) {
+	entries = make([]Entry, 0)
+	for i := range j.entries {
+		entry := j.entries[(j.start+i)%len(j.entries)]
+		if entry.Sequence <= filter.AfterSequence {
+			continue
+		}
+		if filter.CorrelationID != "" && entry.CorrelationID != filter.CorrelationID {
+			continue
+		}
+		if filter.EventID != "" && entry.EventID != filter.EventID {
+			continue
+		}
+
+		entries = append(entries, entry)
+		if filter.Limit > 0 && len(entries) >= filter.Limit {
+			return
+		}
+	}
+	return
+}
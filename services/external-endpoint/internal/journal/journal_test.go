@@ -0,0 +1,56 @@
+package journal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func sequences(entries []Entry) (seqs []uint64) {
+	for _, entry := range entries {
+		seqs = append(seqs, entry.Sequence)
+	}
+	return
+}
+
+func TestJournalRingBuffer(t *testing.T) {
+	j := New(3)
+	for i := 1; i <= 5; i++ {
+		j.Record(Entry{EventID: fmt.Sprintf("evt-%d", i), CorrelationID: fmt.Sprintf("corr-%d", i%2)})
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []uint64
+	}{
+		{name: "all", filter: Filter{}, want: []uint64{3, 4, 5}},
+		{name: "after sequence", filter: Filter{AfterSequence: 3}, want: []uint64{4, 5}},
+		{name: "limit", filter: Filter{Limit: 2}, want: []uint64{3, 4}},
+		{name: "event ID", filter: Filter{EventID: "evt-4"}, want: []uint64{4}},
+		{name: "correlation ID", filter: Filter{CorrelationID: "corr-1"}, want: []uint64{3, 5}},
+		{name: "dropped event ID", filter: Filter{EventID: "evt-1"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sequences(j.List(tt.filter))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("sequences = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	stats := j.Stats()
+	if stats.Size != 3 || stats.Capacity != 3 || stats.Total != 5 || stats.Dropped != 2 {
+		t.Errorf("stats = %+v, want size 3, capacity 3, total 5, dropped 2", stats)
+	}
+
+	j.Reset()
+	j.Record(Entry{EventID: "evt-6"})
+	if got := sequences(j.List(Filter{})); fmt.Sprint(got) != "[6]" {
+		t.Errorf("sequences after reset = %v, want [6]", got)
+	}
+	if stats = j.Stats(); stats.Size != 1 || stats.Dropped != 0 {
+		t.Errorf("stats after reset = %+v, want size 1, dropped 0", stats)
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/health"
+	"github.com/smartcom/integration-platform/pkg/redact"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/fault"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/handler"
+	"github.com/smartcom/integration-platform/services/external-endpoint/internal/journal"
+)
+
+type (
+	FaultConfig   = fault.Config
+	FaultScenario = fault.Scenario
+	FaultLatency  = fault.Latency
+	JournalEntry  = journal.Entry
+	JournalFilter = journal.Filter
+)
+
+type Logger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+type Config struct {
+	Logger             Logger
+	Redactor           *redact.Redactor
+	JournalCapacity    int
+	Faults             *FaultConfig
+	HealthCheckTimeout time.Duration
+}
+
+type Server struct {
+	Router  *gin.Engine
+	Health  *health.Registry
+	Faults  *fault.Injector
+	Journal *journal.Journal
+}
+
+func New(cfg Config) (srv *Server, err error) {
+	healthRegistry := health.NewRegistry("external-endpoint", cfg.HealthCheckTimeout)
+
+	faultInjector := fault.NewInjector(cfg.Logger)
+	if cfg.Faults != nil {
+		err = faultInjector.SetConfig(*cfg.Faults)
+		if err != nil {
+			return
+		}
+	}
+
+	redactor := cfg.Redactor
+	if redactor == nil {
+		redactor, err = redact.New(redact.DefaultConfig())
+		if err != nil {
+			return
+		}
+	}
+
+	alertJournal := journal.New(cfg.JournalCapacity)
+
+	alertHandler := handler.NewAlertHandler(cfg.Logger, faultInjector.Middleware(), alertJournal, redactor)
+	healthHandler := handler.NewHealthHandler(healthRegistry)
+	faultHandler := handler.NewFaultHandler(faultInjector, cfg.Logger)
+	journalHandler := handler.NewJournalHandler(alertJournal)
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	alertHandler.RegisterRoutes(router)
+	healthHandler.RegisterRoutes(router)
+	faultHandler.RegisterRoutes(router)
+	journalHandler.RegisterRoutes(router)
+
+	srv = &Server{
+		Router:  router,
+		Health:  healthRegistry,
+		Faults:  faultInjector,
+		Journal: alertJournal,
+	}
+	return
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+const subBucketBits = 7
+
+type Histogram struct {
+	mu     sync.Mutex
+	counts map[int]int64
+	total  int64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+}
+
+type Percentiles struct {
+	Count  int64   `json:"count"`
+	MinMs  float64 `json:"min_ms"`
+	MeanMs float64 `json:"mean_ms"`
+	P50Ms  float64 `json:"p50_ms"`
+	P75Ms  float64 `json:"p75_ms"`
+	P90Ms  float64 `json:"p90_ms"`
+	P95Ms  float64 `json:"p95_ms"`
+	P99Ms  float64 `json:"p99_ms"`
+	P999Ms float64 `json:"p999_ms"`
+	MaxMs  float64 `json:"max_ms"`
+}
+
+func NewHistogram() (h *Histogram) {
+	h = &Histogram{
+		counts: make(map[int]int64),
+	}
+	return
+}
+
+func (h *Histogram) Record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.counts[bucketIndex(uint64(d.Microseconds()))]++
+	h.total++
+	h.sum += d
+	if h.total == 1 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+}
+
+func (h *Histogram) Percentiles() (p Percentiles) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p.Count = h.total
+	if h.total == 0 {
+		return
+	}
+
+	p.MinMs = toMs(h.min)
+	p.MaxMs = toMs(h.max)
+	p.MeanMs = toMs(h.sum / time.Duration(h.total))
+	p.P50Ms = h.quantileLocked(0.50)
+	p.P75Ms = h.quantileLocked(0.75)
+	p.P90Ms = h.quantileLocked(0.90)
+	p.P95Ms = h.quantileLocked(0.95)
+	p.P99Ms = h.quantileLocked(0.99)
+	p.P999Ms = h.quantileLocked(0.999)
+	return
+}
+
+func (h *Histogram) quantileLocked(q float64) (ms float64) {
+	target := int64(math.Ceil(q * float64(h.total)))
+	maxIndex := bucketIndex(uint64(h.max.Microseconds()))
+
+	var seen int64
+	for index := 0; index <= maxIndex; index++ {
+		seen += h.counts[index]
+		if seen >= target {
+			ms = math.Min(float64(bucketUpperBound(index))/1000, toMs(h.max))
+			return
+		}
+	}
+
+	ms = toMs(h.max)
+	return
+}
+
+func bucketIndex(micros uint64) (index int) {
+	if micros < 1<<subBucketBits {
+		index = int(micros)
+		return
+	}
+
+	exponent := bits.Len64(micros) - subBucketBits
+	mantissa := micros >> uint(exponent)
+	index = exponent<<subBucketBits + int(mantissa)
+	return
+}
+
+func bucketUpperBound(index int) (micros uint64) {
+	exponent := index >> subBucketBits
+	mantissa := uint64(index & (1<<subBucketBits - 1))
+	if exponent == 0 {
+		micros = mantissa
+		return
+	}
+
+	micros = (mantissa+1)<<uint(exponent) - 1
+	return
+}
+
+func toMs(d time.Duration) (ms float64) {
+	ms = float64(d.Microseconds()) / 1000
+	return
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBucketBoundsContainValue(t *testing.T) {
+	for _, micros := range []uint64{0, 1, 127, 128, 129, 255, 256, 1000, 12345, 999999, 1 << 30} {
+		index := bucketIndex(micros)
+		upper := bucketUpperBound(index)
+
+		if upper < micros {
+			t.Errorf("bucket %d upper bound %d is below value %d", index, upper, micros)
+		}
+		if micros >= 1<<subBucketBits && float64(upper-micros)/float64(micros) > 1.0/64 {
+			t.Errorf("bucket %d upper bound %d is more than 1/64 above %d", index, upper, micros)
+		}
+		if index > 0 && bucketUpperBound(index-1) >= micros && bucketIndex(bucketUpperBound(index-1)) == index-1 {
+			t.Errorf("value %d also fits the previous bucket %d", micros, index-1)
+		}
+	}
+}
+
+func TestHistogramPercentiles(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	p := h.Percentiles()
+	if p.Count != 1000 || p.MinMs != 1 || p.MaxMs != 1000 {
+		t.Fatalf("count/min/max = %d/%v/%v", p.Count, p.MinMs, p.MaxMs)
+	}
+	if math.Abs(p.MeanMs-500.5) > 0.01 {
+		t.Errorf("mean = %v, want 500.5", p.MeanMs)
+	}
+
+	cases := map[string][2]float64{
+		"p50":  {p.P50Ms, 500},
+		"p90":  {p.P90Ms, 900},
+		"p99":  {p.P99Ms, 990},
+		"p999": {p.P999Ms, 999},
+	}
+	for name, c := range cases {
+		got, want := c[0], c[1]
+		if got < want || got > want*(1+1.0/64) {
+			t.Errorf("%s = %v, want within 1/64 above %v", name, got, want)
+		}
+	}
+}
+
+func TestHistogramEdgeCases(t *testing.T) {
+	h := NewHistogram()
+	if p := h.Percentiles(); p != (Percentiles{}) {
+		t.Errorf("empty histogram percentiles = %+v", p)
+	}
+
+	h.Record(-time.Second)
+	h.Record(3 * time.Millisecond)
+	p := h.Percentiles()
+	if p.MinMs != 0 || p.MaxMs != 3 || p.P999Ms > 3 {
+		t.Errorf("percentiles = %+v", p)
+	}
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+type options struct {
+	target       string
+	journal      string
+	rate         float64
+	concurrency  int
+	duration     time.Duration
+	timeout      time.Duration
+	drainTimeout time.Duration
+	jsonPath     string
+	baselinePath string
+}
+
+type loadRunner struct {
+	opts      options
+	mix       EventMix
+	client    *http.Client
+	ingestion *Histogram
+	sequence  atomic.Int64
+
+	mu          sync.Mutex
+	sentAt      map[string]time.Time
+	statusCodes map[string]int64
+
+	sent      atomic.Int64
+	accepted  atomic.Int64
+	skipped   atomic.Int64
+	transport atomic.Int64
+}
+
+type acceptedResponse struct {
+	EventID string `json:"event_id"`
+}
+
+type journalEntry struct {
+	EventID    string    `json:"event_id"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+type journalResponse struct {
+	Entries []journalEntry `json:"entries"`
+	Stats   struct {
+		Total uint64 `json:"total"`
+	} `json:"stats"`
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() (err error) {
+	var opts options
+	var sources, eventTypes, severities string
+	var mix EventMix
+
+	flag.StringVar(&opts.target, "target", "http://localhost:8080", "middleware base URL")
+	flag.StringVar(&opts.journal, "journal", "", "external endpoint base URL used to measure end-to-end delivery (optional)")
+	flag.Float64Var(&opts.rate, "rate", 0, "target request rate per second (0 runs closed-loop at -concurrency)")
+	flag.IntVar(&opts.concurrency, "concurrency", 10, "concurrent requests (max in flight in rate mode)")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "test duration")
+	flag.DurationVar(&opts.timeout, "timeout", 5*time.Second, "per-request timeout")
+	flag.DurationVar(&opts.drainTimeout, "drain-timeout", 30*time.Second, "how long to wait for deliveries after sending stops")
+	flag.StringVar(&opts.jsonPath, "json", "", "write the report as JSON to this file")
+	flag.StringVar(&opts.baselinePath, "baseline", "", "compare against a previous JSON report")
+	flag.StringVar(&sources, "sources", "loadgen", "weighted sources, e.g. api:3,db:1")
+	flag.StringVar(&eventTypes, "event-types", "load_test", "weighted event types")
+	flag.StringVar(&severities, "severities", "critical:1,high:2,medium:3,low:4", "weighted severities")
+	flag.IntVar(&mix.MetadataMin, "metadata-min", 0, "minimum metadata padding in bytes")
+	flag.IntVar(&mix.MetadataMax, "metadata-max", 256, "maximum metadata padding in bytes")
+	flag.BoolVar(&mix.PartitionKey, "partition-by-source", false, "set partition_key to the event source")
+	flag.Parse()
+
+	mix.Sources, err = parseWeighted(sources)
+	if err != nil {
+		return
+	}
+	mix.EventTypes, err = parseWeighted(eventTypes)
+	if err != nil {
+		return
+	}
+	mix.Severities, err = parseWeighted(severities)
+	if err != nil {
+		return
+	}
+	if opts.concurrency <= 0 {
+		err = errors.New("concurrency must be positive")
+		return
+	}
+
+	runner := &loadRunner{
+		opts:        opts,
+		mix:         mix,
+		client:      &http.Client{Timeout: opts.timeout},
+		ingestion:   NewHistogram(),
+		sentAt:      make(map[string]time.Time),
+		statusCodes: make(map[string]int64),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var journalBase uint64
+	if opts.journal != "" {
+		journalBase, err = runner.journalTotal(ctx)
+		if err != nil {
+			err = fmt.Errorf("failed to read journal: %w", err)
+			return
+		}
+	}
+
+	report := Report{
+		StartedAt:   time.Now().UTC(),
+		Target:      opts.target,
+		Mode:        "closed-loop",
+		Concurrency: opts.concurrency,
+	}
+	if opts.rate > 0 {
+		report.Mode = "open-loop"
+		report.Rate = opts.rate
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.duration)
+	defer cancel()
+
+	start := time.Now()
+	if opts.rate > 0 {
+		runner.runRate(runCtx)
+	} else {
+		runner.runClosedLoop(runCtx)
+	}
+	elapsed := time.Since(start)
+
+	report.DurationS = elapsed.Seconds()
+	report.Sent = runner.sent.Load()
+	report.Accepted = runner.accepted.Load()
+	report.Skipped = runner.skipped.Load()
+	report.TransportError = runner.transport.Load()
+	report.StatusCodes = runner.statusCodes
+	report.ThroughputRPS = float64(report.Sent) / elapsed.Seconds()
+	report.Ingestion = runner.ingestion.Percentiles()
+	if report.Sent > 0 {
+		report.Rate503 = float64(runner.statusCodes["503"]) / float64(report.Sent)
+		report.Rate429 = float64(runner.statusCodes["429"]) / float64(report.Sent)
+	}
+
+	if opts.journal != "" {
+		err = runner.measureDelivery(ctx, journalBase, &report)
+		if err != nil {
+			err = fmt.Errorf("failed to measure delivery: %w", err)
+			return
+		}
+	}
+
+	report.Print(os.Stdout)
+
+	if opts.baselinePath != "" {
+		var baseline Report
+		baseline, err = loadReport(opts.baselinePath)
+		if err != nil {
+			err = fmt.Errorf("failed to load baseline: %w", err)
+			return
+		}
+		fmt.Println()
+		report.PrintComparison(os.Stdout, baseline)
+	}
+
+	if opts.jsonPath != "" {
+		err = report.WriteJSON(opts.jsonPath)
+		if err != nil {
+			err = fmt.Errorf("failed to write report: %w", err)
+			return
+		}
+	}
+	return
+}
+
+func (r *loadRunner) runClosedLoop(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < r.opts.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				r.send(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *loadRunner) runRate(ctx context.Context) {
+	interval := time.Duration(float64(time.Second) / r.opts.rate)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slots := make(chan struct{}, r.opts.concurrency)
+	var wg sync.WaitGroup
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			r.skipped.Add(1)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			r.send(ctx)
+		}()
+	}
+}
+
+func (r *loadRunner) send(ctx context.Context) {
+	event := r.mix.Next(r.sequence.Add(1))
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		r.transport.Add(1)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.WithoutCancel(ctx), http.MethodPost, r.opts.target+"/integrations/events", bytes.NewReader(body))
+	if err != nil {
+		r.transport.Add(1)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	latency := time.Since(start)
+	r.sent.Add(1)
+	if err != nil {
+		r.transport.Add(1)
+		return
+	}
+	defer resp.Body.Close()
+
+	r.ingestion.Record(latency)
+
+	r.mu.Lock()
+	r.statusCodes[strconv.Itoa(resp.StatusCode)]++
+	r.mu.Unlock()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return
+	}
+
+	var accepted acceptedResponse
+	err = json.NewDecoder(resp.Body).Decode(&accepted)
+	if err != nil || accepted.EventID == "" {
+		return
+	}
+
+	r.accepted.Add(1)
+	r.mu.Lock()
+	r.sentAt[accepted.EventID] = start
+	r.mu.Unlock()
+}
+
+func (r *loadRunner) journalTotal(ctx context.Context) (total uint64, err error) {
+	var response journalResponse
+	err = r.getJSON(ctx, r.opts.journal+"/journal?limit=1", &response)
+	total = response.Stats.Total
+	return
+}
+
+func (r *loadRunner) measureDelivery(ctx context.Context, base uint64, report *Report) (err error) {
+	query := url.Values{}
+	query.Set("after", strconv.FormatUint(base, 10))
+
+	if report.Accepted > 0 {
+		waitQuery := url.Values{}
+		waitQuery.Set("after", strconv.FormatUint(base, 10))
+		waitQuery.Set("count", strconv.FormatInt(report.Accepted, 10))
+		waitQuery.Set("timeout", r.opts.drainTimeout.String())
+		waitQuery.Set("limit", "1")
+
+		waitCtx, cancel := context.WithTimeout(ctx, r.opts.drainTimeout+5*time.Second)
+		defer cancel()
+		_ = r.getJSON(waitCtx, r.opts.journal+"/journal/wait?"+waitQuery.Encode(), &journalResponse{})
+	}
+
+	var response journalResponse
+	err = r.getJSON(ctx, r.opts.journal+"/journal?"+query.Encode(), &response)
+	if err != nil {
+		return
+	}
+
+	delivery := NewHistogram()
+	for _, entry := range response.Entries {
+		sentAt, ok := r.sentAt[entry.EventID]
+		if !ok {
+			continue
+		}
+		delete(r.sentAt, entry.EventID)
+		delivery.Record(entry.ReceivedAt.Sub(sentAt))
+		report.Delivered++
+	}
+
+	report.Undelivered = int64(len(r.sentAt))
+	percentiles := delivery.Percentiles()
+	report.Delivery = &percentiles
+	return
+}
+
+func (r *loadRunner) getJSON(ctx context.Context, rawURL string, target interface{}) (err error) {
+	var req *http.Request
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return
+	}
+
+	client := &http.Client{}
+	var resp *http.Response
+	resp, err = client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		err = fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, rawURL)
+		return
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(target)
+	return
+}
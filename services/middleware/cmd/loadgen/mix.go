@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+type weightedChoice struct {
+	values  []string
+	weights []int
+	total   int
+}
+
+type EventMix struct {
+	Sources      weightedChoice
+	EventTypes   weightedChoice
+	Severities   weightedChoice
+	MetadataMin  int
+	MetadataMax  int
+	PartitionKey bool
+}
+
+type incomingEvent struct {
+	Source       string                 `json:"source"`
+	EventType    string                 `json:"event_type"`
+	Severity     string                 `json:"severity"`
+	Message      string                 `json:"message"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	PartitionKey string                 `json:"partition_key,omitempty"`
+}
+
+func parseWeighted(spec string) (choice weightedChoice, err error) {
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, rawWeight, hasWeight := strings.Cut(part, ":")
+		weight := 1
+		if hasWeight {
+			weight, err = strconv.Atoi(rawWeight)
+			if err != nil || weight <= 0 {
+				err = fmt.Errorf("invalid weight in %q", part)
+				return
+			}
+		}
+
+		choice.values = append(choice.values, name)
+		choice.weights = append(choice.weights, weight)
+		choice.total += weight
+	}
+
+	if len(choice.values) == 0 {
+		err = fmt.Errorf("no values in %q", spec)
+	}
+	return
+}
+
+func (c weightedChoice) pick() (value string) {
+	n := rand.IntN(c.total)
+	for i, weight := range c.weights {
+		if n < weight {
+			value = c.values[i]
+			return
+		}
+		n -= weight
+	}
+	value = c.values[len(c.values)-1]
+	return
+}
+
+func (m EventMix) Next(sequence int64) (event incomingEvent) {
+	event = incomingEvent{
+		Source:    m.Sources.pick(),
+		EventType: m.EventTypes.pick(),
+		Severity:  m.Severities.pick(),
+		Message:   fmt.Sprintf("loadgen event %d", sequence),
+	}
+
+	size := m.MetadataMin
+	if m.MetadataMax > m.MetadataMin {
+		size += rand.IntN(m.MetadataMax - m.MetadataMin + 1)
+	}
+	if size > 0 {
+		event.Metadata = map[string]interface{}{
+			"loadgen_sequence": sequence,
+			"padding":          strings.Repeat("x", size),
+		}
+	}
+
+	if m.PartitionKey {
+		event.PartitionKey = event.Source
+	}
+	return
+}
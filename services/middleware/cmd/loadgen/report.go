@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type Report struct {
+	StartedAt      time.Time        `json:"started_at"`
+	Target         string           `json:"target"`
+	Mode           string           `json:"mode"`
+	Rate           float64          `json:"rate,omitempty"`
+	Concurrency    int              `json:"concurrency"`
+	DurationS      float64          `json:"duration_s"`
+	Sent           int64            `json:"sent"`
+	Accepted       int64            `json:"accepted"`
+	Skipped        int64            `json:"skipped"`
+	TransportError int64            `json:"transport_errors"`
+	StatusCodes    map[string]int64 `json:"status_codes"`
+	Rate503        float64          `json:"rate_503"`
+	Rate429        float64          `json:"rate_429"`
+	ThroughputRPS  float64          `json:"throughput_rps"`
+	Ingestion      Percentiles      `json:"ingestion_latency"`
+	Delivered      int64            `json:"delivered"`
+	Undelivered    int64            `json:"undelivered"`
+	Delivery       *Percentiles     `json:"delivery_latency,omitempty"`
+}
+
+type comparisonRow struct {
+	label    string
+	baseline float64
+	current  float64
+}
+
+func (r Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "target:       %s (%s)\n", r.Target, r.Mode)
+	fmt.Fprintf(w, "duration:     %.2fs\n", r.DurationS)
+	fmt.Fprintf(w, "sent:         %d (accepted %d, skipped %d, transport errors %d)\n", r.Sent, r.Accepted, r.Skipped, r.TransportError)
+	fmt.Fprintf(w, "throughput:   %.1f req/s\n", r.ThroughputRPS)
+	fmt.Fprintf(w, "503 rate:     %.2f%%\n", r.Rate503*100)
+	fmt.Fprintf(w, "429 rate:     %.2f%%\n", r.Rate429*100)
+
+	codes := make([]string, 0, len(r.StatusCodes))
+	for code := range r.StatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		fmt.Fprintf(w, "  status %s:  %d\n", code, r.StatusCodes[code])
+	}
+
+	fmt.Fprintln(w)
+	printPercentiles(w, "ingestion latency", r.Ingestion)
+
+	if r.Delivery != nil {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "delivered:    %d (undelivered %d)\n", r.Delivered, r.Undelivered)
+		printPercentiles(w, "end-to-end delivery latency", *r.Delivery)
+	}
+}
+
+func printPercentiles(w io.Writer, title string, p Percentiles) {
+	fmt.Fprintf(w, "%s (%d samples)\n", title, p.Count)
+	fmt.Fprintf(w, "  %-8s %10s\n", "value", "ms")
+	rows := []struct {
+		label string
+		value float64
+	}{
+		{"min", p.MinMs},
+		{"mean", p.MeanMs},
+		{"p50", p.P50Ms},
+		{"p75", p.P75Ms},
+		{"p90", p.P90Ms},
+		{"p95", p.P95Ms},
+		{"p99", p.P99Ms},
+		{"p99.9", p.P999Ms},
+		{"max", p.MaxMs},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "  %-8s %10.3f\n", row.label, row.value)
+	}
+}
+
+func (r Report) WriteJSON(path string) (err error) {
+	var data []byte
+	data, err = json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return
+	}
+
+	err = os.WriteFile(path, data, 0o644)
+	return
+}
+
+func loadReport(path string) (report Report, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &report)
+	return
+}
+
+func (r Report) PrintComparison(w io.Writer, baseline Report) {
+	fmt.Fprintf(w, "comparison with baseline from %s\n", baseline.StartedAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "  %-22s %12s %12s %10s\n", "metric", "baseline", "current", "change")
+
+	rows := []comparisonRow{
+		{"throughput_rps", baseline.ThroughputRPS, r.ThroughputRPS},
+		{"rate_503", baseline.Rate503, r.Rate503},
+		{"rate_429", baseline.Rate429, r.Rate429},
+		{"ingestion_p50_ms", baseline.Ingestion.P50Ms, r.Ingestion.P50Ms},
+		{"ingestion_p99_ms", baseline.Ingestion.P99Ms, r.Ingestion.P99Ms},
+		{"ingestion_max_ms", baseline.Ingestion.MaxMs, r.Ingestion.MaxMs},
+	}
+	if baseline.Delivery != nil && r.Delivery != nil {
+		rows = append(rows,
+			comparisonRow{"delivery_p50_ms", baseline.Delivery.P50Ms, r.Delivery.P50Ms},
+			comparisonRow{"delivery_p99_ms", baseline.Delivery.P99Ms, r.Delivery.P99Ms},
+		)
+	}
+
+	for _, row := range rows {
+		change := "n/a"
+		if row.baseline != 0 {
+			change = strconv.FormatFloat((row.current-row.baseline)/row.baseline*100, 'f', 1, 64) + "%"
+		}
+		fmt.Fprintf(w, "  %-22s %12.3f %12.3f %10s\n", row.label, row.baseline, row.current, change)
+	}
+}
@@ -2,22 +2,37 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/smartcom/integration-platform/pkg/config"
+	"github.com/smartcom/integration-platform/pkg/health"
 	"github.com/smartcom/integration-platform/pkg/httpclient"
 	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/pkg/redact"
+	"github.com/smartcom/integration-platform/pkg/tlsconfig"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/encryption"
+	"github.com/smartcom/integration-platform/services/middleware/internal/enrichment"
 	"github.com/smartcom/integration-platform/services/middleware/internal/handler"
 	"github.com/smartcom/integration-platform/services/middleware/internal/infrastructure"
 	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/source"
 	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
 	"github.com/smartcom/integration-platform/services/middleware/internal/worker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -32,10 +47,29 @@ func run() (err error) {
 	log := logger.NewDefault()
 	log.Info("starting middleware integration service")
 
+	redactionConfig := usecase.DefaultRedactionConfig()
+	if redactionFile := config.GetEnv("REDACTION_FILE", ""); redactionFile != "" {
+		redactionConfig = usecase.RedactionConfig{}
+		err = config.LoadJSONFile(redactionFile, &redactionConfig)
+		if err != nil {
+			return
+		}
+	}
+
+	var redactor *redact.Redactor
+	redactor, err = redact.New(redactionConfig.Config)
+	if err != nil {
+		return
+	}
+	log.SetRedactor(redactor)
+
 	port := config.GetEnv("PORT", "8080")
 	externalURL := config.GetEnv("EXTERNAL_ENDPOINT_URL", "http://localhost:8081/external/alerts")
 	queueSize := config.GetEnvInt("QUEUE_SIZE", 1000)
+	enqueueTimeout := config.GetEnvDuration("ENQUEUE_TIMEOUT", repository.DefaultEnqueueTimeout)
 	workerCount := config.GetEnvInt("WORKER_COUNT", 10)
+	workerMin := config.GetEnvInt("WORKER_MIN", workerCount)
+	workerMax := config.GetEnvInt("WORKER_MAX", workerCount)
 	httpTimeout := config.GetEnvDuration("HTTP_TIMEOUT", 3*time.Second)
 	maxRetries := config.GetEnvInt("MAX_RETRIES", 3)
 	baseDelay := config.GetEnvDuration("BASE_DELAY", 500*time.Millisecond)
@@ -45,39 +79,391 @@ func run() (err error) {
 		MaxRetries: maxRetries,
 		BaseDelay:  baseDelay,
 	}
-	httpClient := httpclient.New(httpClientConfig)
+
+	var httpClient *httpclient.Client
+	httpClient, err = httpclient.New(httpClientConfig)
+	if err != nil {
+		return
+	}
+
+	destinations := []domain.Destination{{Name: domain.DefaultDestination, URL: externalURL}}
+	for name, url := range config.GetEnvMap("DESTINATIONS") {
+		if name != domain.DefaultDestination {
+			destinations = append(destinations, domain.Destination{Name: name, URL: url})
+		}
+	}
+	destinationRegistry := repository.NewDestinationRegistry(destinations)
+
+	var destinationRedactors map[string]*redact.Redactor
+	destinationRedactors, err = usecase.NewDestinationRedactors(redactionConfig, redactor, destinationRegistry)
+	if err != nil {
+		return
+	}
+
+	destinationTLS := make(map[string]tlsconfig.ClientConfig)
+	if destinationTLSFile := config.GetEnv("DESTINATION_TLS_FILE", ""); destinationTLSFile != "" {
+		err = config.LoadJSONFile(destinationTLSFile, &destinationTLS)
+		if err != nil {
+			return
+		}
+	}
+
+	var destinationClients map[string]*httpclient.Client
+	destinationClients, err = usecase.NewDestinationClients(httpClientConfig, destinationTLS, destinationRegistry)
+	if err != nil {
+		return
+	}
 
 	idGenerator := infrastructure.NewUUIDGenerator()
-	eventMapper := usecase.NewEventMapper(idGenerator)
-	eventProcessor := usecase.NewEventProcessor(httpClient, externalURL, log)
-	eventQueue := repository.NewEventQueue(queueSize)
+	eventMapper := usecase.NewEventMapper(idGenerator, infrastructure.NewSystemClock())
+	dataDir := config.GetEnv("DATA_DIR", "")
+
+	var keyring *encryption.Keyring
+	keyring, err = encryption.KeyringFromEnv()
+	if err != nil {
+		return
+	}
+	if keyring != nil {
+		log.Info("storage encryption enabled", "active_master_key", keyring.Active(), "master_keys", keyring.IDs(), "allow_plaintext", keyring.AllowsPlaintext())
+	}
+
+	var eventStore domain.EventStore
+	eventStore, err = openEventStore(config.GetEnv("EVENT_STORE", ""), dataDir, config.GetEnvInt("EVENT_STORE_MAX_RECORDS", repository.DefaultMemoryStoreSize), keyring)
+	if err != nil {
+		return
+	}
+	if eventStore != nil {
+		defer eventStore.Close()
+	}
 
-	workerPool := worker.NewPool(workerCount, eventQueue, eventProcessor, log)
+	var eventProcessor domain.EventProcessor
+	eventProcessor = usecase.NewEventProcessor(httpClient, destinationClients, destinationRegistry, destinationRedactors, log)
+	if eventStore != nil {
+		eventProcessor = usecase.NewRecordingProcessor(eventProcessor, eventStore, infrastructure.NewSystemClock(), log)
+	}
+	eventQueue := repository.NewEventQueue(queueSize, enqueueTimeout)
+
+	poolConfig := worker.Config{
+		InitialWorkers: workerCount,
+		MinWorkers:     workerMin,
+		MaxWorkers:     workerMax,
+		Scaling: worker.ScalingConfig{
+			Interval:         config.GetEnvDuration("SCALE_INTERVAL", 5*time.Second),
+			UpCooldown:       config.GetEnvDuration("SCALE_UP_COOLDOWN", 15*time.Second),
+			DownCooldown:     config.GetEnvDuration("SCALE_DOWN_COOLDOWN", 60*time.Second),
+			Step:             config.GetEnvInt("SCALE_STEP", 2),
+			UpQueueRatio:     config.GetEnvFloat("SCALE_UP_QUEUE_RATIO", 0.25),
+			DownQueueRatio:   config.GetEnvFloat("SCALE_DOWN_QUEUE_RATIO", 0.05),
+			LatencyTarget:    config.GetEnvDuration("SCALE_LATENCY_TARGET", time.Second),
+			IdleWait:         config.GetEnvDuration("SCALE_IDLE_WAIT", 500*time.Millisecond),
+			DownStableRounds: config.GetEnvInt("SCALE_DOWN_STABLE_ROUNDS", 3),
+		},
+		Bulkhead: worker.BulkheadConfig{
+			DefaultLimit: config.GetEnvInt("BULKHEAD_LIMIT", 0),
+			Limits:       config.GetEnvIntMap("BULKHEAD_LIMITS"),
+			ParkSize:     config.GetEnvInt("BULKHEAD_PARK_SIZE", worker.DefaultParkSize),
+		},
+		Partition: worker.PartitionConfig{
+			Key:        config.GetEnv("PARTITION_KEY", ""),
+			Lanes:      config.GetEnvInt("PARTITION_LANES", workerCount),
+			LaneBuffer: config.GetEnvInt("PARTITION_LANE_BUFFER", worker.DefaultLaneBuffer),
+		},
+	}
+	var workerPool *worker.Pool
+	workerPool, err = worker.NewPool(poolConfig, eventQueue, eventProcessor, log)
+	if err != nil {
+		return
+	}
+	destinationRegistry.Subscribe(workerPool.SetDestinationEnabled)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	workerPool.Start(ctx)
 
-	eventHandler := handler.NewEventHandler(eventQueue, eventMapper, log)
+	healthRegistry := health.NewRegistry("middleware", config.GetEnvDuration("HEALTH_CHECK_TIMEOUT", health.DefaultCheckTimeout))
+	healthRegistry.AddLiveness("worker_pool", workerPool.LivenessCheck())
+	healthRegistry.AddReadiness("worker_pool", workerPool.ReadinessCheck())
+	healthRegistry.AddReadiness("queue", eventQueue.HealthCheck(config.GetEnvFloat("QUEUE_SATURATION_THRESHOLD", 0.9)))
+
+	probeInterval := config.GetEnvDuration("DESTINATION_PROBE_INTERVAL", 15*time.Second)
+	for _, destination := range destinationRegistry.List() {
+		probe := health.NewPeriodicCheck(health.TCPReachable(destination.URL), probeInterval, httpTimeout)
+		probe.Start(ctx)
+		healthRegistry.AddReadiness("destination:"+destination.Name, probe.Check)
+	}
+
+	if dataDir != "" {
+		healthRegistry.AddReadiness("storage", health.DirWritable(dataDir))
+	}
+
+	if eventStore != nil {
+		repository.StartRetention(ctx, eventStore, repository.RetentionConfig{
+			Period:          config.GetEnvDuration("EVENT_RETENTION", 7*24*time.Hour),
+			Interval:        config.GetEnvDuration("EVENT_RETENTION_INTERVAL", time.Hour),
+			CompactInterval: config.GetEnvDuration("EVENT_COMPACT_INTERVAL", 0),
+		}, log)
+	}
+
+	var silenceStore domain.SilenceStore
+	silenceStore, err = openSilenceStore(dataDir, keyring)
+	if err != nil {
+		return
+	}
+	defer silenceStore.Close()
+
+	var silencer *usecase.Silencer
+	silencer, err = usecase.NewSilencer(ctx, silenceStore, idGenerator, infrastructure.NewSystemClock())
+	if err != nil {
+		return
+	}
+
+	alertTracker := usecase.NewAlertTracker(infrastructure.NewSystemClock(), config.GetEnvDuration("ALERT_AUTO_RESOLVE_AFTER", usecase.DefaultAutoResolveAfter))
+	if eventStore != nil {
+		var restored int
+		restored, err = alertTracker.Rebuild(ctx, eventStore)
+		if err != nil {
+			return
+		}
+		log.Info("open alerts rebuilt from event store", "alerts", restored)
+	}
+
+	var inhibitRules []domain.InhibitRule
+	if inhibitRulesFile := config.GetEnv("INHIBIT_RULES_FILE", ""); inhibitRulesFile != "" {
+		err = config.LoadJSONFile(inhibitRulesFile, &inhibitRules)
+		if err != nil {
+			return
+		}
+	}
+
+	var inhibitor *usecase.Inhibitor
+	inhibitor, err = usecase.NewInhibitor(inhibitRules, alertTracker)
+	if err != nil {
+		return
+	}
+	log.Info("inhibit rules loaded", "count", len(inhibitRules))
+
+	var routingConfig domain.RoutingConfig
+	if routesFile := config.GetEnv("ROUTES_FILE", ""); routesFile != "" {
+		err = config.LoadJSONFile(routesFile, &routingConfig)
+		if err != nil {
+			return
+		}
+	}
+
+	var eventRouter *usecase.Router
+	eventRouter, err = usecase.NewRouter(routingConfig, destinationRegistry)
+	if err != nil {
+		return
+	}
+	log.Info("routes loaded", "routes", len(routingConfig.Routes), "escalation_policies", len(routingConfig.EscalationPolicies))
+
+	var transformer *usecase.Transformer
+	transformer, err = usecase.NewTransformer(routingConfig, log)
+	if err != nil {
+		return
+	}
+
+	var escalationStore domain.EscalationStore
+	escalationStore, err = openEscalationStore(dataDir, keyring)
+	if err != nil {
+		return
+	}
+	defer escalationStore.Close()
+
+	if keyring != nil {
+		reencrypters := make(map[string]encryption.Reencrypter)
+		for name, store := range map[string]any{"events": eventStore, "silences": silenceStore, "escalations": escalationStore} {
+			if reencrypter, ok := store.(encryption.Reencrypter); ok {
+				reencrypters[name] = reencrypter
+			}
+		}
+		encryption.StartRotation(ctx, config.GetEnvDuration("STORAGE_REENCRYPT_INTERVAL", encryption.DefaultReencryptInterval), reencrypters, log)
+	}
+
+	var escalator *usecase.Escalator
+	escalator, err = usecase.NewEscalator(ctx, eventRouter, escalationStore, eventStore, eventQueue, idGenerator, infrastructure.NewSystemClock(), log)
+	if err != nil {
+		return
+	}
+	escalator.Restore(alertTracker)
+	escalator.Start(ctx, config.GetEnvDuration("ESCALATION_TICK_INTERVAL", usecase.DefaultEscalationTick))
+
+	var digester *usecase.Digester
+	digester, err = usecase.NewDigester(eventRouter, eventStore, eventQueue, idGenerator, infrastructure.NewSystemClock(), log)
+	if err != nil {
+		return
+	}
+	digester.Start(ctx, config.GetEnvDuration("DIGEST_TICK_INTERVAL", usecase.DefaultDigestTick))
+
+	var enrichers []enrichment.Config
+	if enrichersFile := config.GetEnv("ENRICHERS_FILE", ""); enrichersFile != "" {
+		err = config.LoadJSONFile(enrichersFile, &enrichers)
+		if err != nil {
+			return
+		}
+	}
+
+	var enricher *enrichment.Chain
+	enricher, err = enrichment.NewChain(enrichers, log)
+	if err != nil {
+		return
+	}
+	enricher.Start(ctx)
+	log.Info("enrichers loaded", "enrichers", enricher.Names())
+
+	ingestor := usecase.NewIngestor(eventMapper, eventQueue, eventStore, usecase.IngestPipeline{
+		Enricher:    enricher,
+		Suppressors: []usecase.Suppressor{silencer, inhibitor},
+		Alerts:      alertTracker,
+		Router:      eventRouter,
+		Transformer: transformer,
+		Escalator:   escalator,
+		Digester:    digester,
+	}, log)
+	ingestor.StartAutoResolve(ctx, config.GetEnvDuration("ALERT_AUTO_RESOLVE_INTERVAL", time.Minute))
+
+	var sources []source.Source
+	kafkaBrokers := config.GetEnv("KAFKA_BROKERS", "")
+	if kafkaBrokers != "" {
+		var kafkaSource *source.KafkaSource
+		kafkaSource, err = source.NewKafkaSource(source.KafkaConfig{
+			Brokers:         strings.Split(kafkaBrokers, ","),
+			Topic:           config.GetEnv("KAFKA_TOPIC", "events"),
+			Group:           config.GetEnv("KAFKA_GROUP", source.DefaultKafkaGroup),
+			ClientID:        config.GetEnv("KAFKA_CLIENT_ID", "middleware"),
+			RetryBackoff:    config.GetEnvDuration("KAFKA_RETRY_BACKOFF", source.DefaultKafkaRetryBackoff),
+			DeadLetterTopic: config.GetEnv("KAFKA_DEAD_LETTER_TOPIC", ""),
+		}, ingestor, log)
+		if err != nil {
+			return
+		}
+		sources = append(sources, kafkaSource)
+	}
+
+	var syslogSources []source.Source
+	syslogSources, err = buildSyslogSources(ingestor, log)
+	if err != nil {
+		return
+	}
+	sources = append(sources, syslogSources...)
+
+	for _, src := range sources {
+		healthRegistry.AddReadiness("source:"+src.Name(), src.Check)
+	}
+
+	eventHandler := handler.NewEventHandler(ingestor, log)
+	healthHandler := handler.NewHealthHandler(healthRegistry)
 
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
 
 	eventHandler.RegisterRoutes(router)
+	handler.NewWebhookHandler(ingestor, log).RegisterRoutes(router)
+	healthHandler.RegisterRoutes(router)
 
 	server := &http.Server{
 		Addr:    ":" + port,
 		Handler: router,
 	}
 
-	serverErrors := make(chan error, 1)
+	var serverTLS *tls.Config
+	if serverTLSConfig := tlsconfig.ServerConfigFromEnv(""); serverTLSConfig.Enabled() {
+		serverTLS, err = tlsconfig.NewServerConfig(serverTLSConfig, log)
+		if err != nil {
+			err = fmt.Errorf("failed to configure server TLS: %w", err)
+			return
+		}
+		server.TLSConfig = serverTLS
+	}
+
+	serverErrors := make(chan error, 3+len(sources))
+
+	sourceCtx, sourceCancel := context.WithCancel(ctx)
+	defer sourceCancel()
+
+	sourceGroup := source.NewGroup(log, sources...)
+	sourceGroup.Start(sourceCtx, serverErrors)
 	go func() {
-		log.Info("http server listening", "port", port)
-		serverErrors <- server.ListenAndServe()
+		log.Info("http server listening", "port", port, "tls", serverTLS != nil)
+		serverErrors <- listenAndServe(server)
 	}()
 
+	grpcPort := config.GetEnv("GRPC_PORT", "")
+
+	var grpcServer *grpc.Server
+	if grpcPort != "" {
+		var grpcListener net.Listener
+		grpcListener, err = net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			err = fmt.Errorf("failed to listen for grpc: %w", err)
+			return
+		}
+
+		var grpcOptions []grpc.ServerOption
+		if serverTLS != nil {
+			grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(serverTLS)))
+		}
+
+		grpcServer = grpc.NewServer(grpcOptions...)
+		handler.NewIngestGRPCHandler(ingestor, log).Register(grpcServer)
+		reflection.Register(grpcServer)
+
+		go func() {
+			log.Info("grpc server listening", "port", grpcPort, "tls", serverTLS != nil)
+			serverErrors <- grpcServer.Serve(grpcListener)
+		}()
+	}
+
+	adminAddr := config.GetEnv("ADMIN_ADDR", "127.0.0.1:9090")
+	adminUsername := config.GetEnv("ADMIN_USERNAME", "admin")
+	adminPassword := config.GetEnv("ADMIN_PASSWORD", "")
+
+	var adminServer *http.Server
+	if adminPassword == "" {
+		log.Warn("admin listener disabled, ADMIN_PASSWORD is not set")
+	} else {
+		poolHandler := handler.NewPoolHandler(workerPool, log)
+		adminHandler := handler.NewAdminHandler(eventQueue, workerPool, destinationRegistry, log)
+
+		adminRouter := gin.New()
+		adminRouter.Use(gin.Recovery())
+		adminRouter.Use(gin.BasicAuth(gin.Accounts{adminUsername: adminPassword}))
+
+		poolHandler.RegisterRoutes(adminRouter)
+		adminHandler.RegisterRoutes(adminRouter)
+		handler.NewAlertHandler(alertTracker, inhibitor).RegisterRoutes(adminRouter)
+		handler.NewAcknowledgeHandler(ingestor, alertTracker, log).RegisterRoutes(adminRouter)
+		handler.NewSilenceHandler(silencer, log).RegisterRoutes(adminRouter)
+		handler.NewRoutingHandler(eventRouter, escalator, digester).RegisterRoutes(adminRouter)
+		handler.NewTransformHandler(transformer, eventMapper).RegisterRoutes(adminRouter)
+		if eventStore != nil {
+			replayer := usecase.NewReplayer(eventStore, eventQueue, destinationRegistry, idGenerator, infrastructure.NewSystemClock(), log)
+
+			handler.NewEventStoreHandler(eventStore).RegisterRoutes(adminRouter)
+			handler.NewReplayHandler(replayer, log).RegisterRoutes(adminRouter)
+		}
+
+		adminServer = &http.Server{
+			Addr:    adminAddr,
+			Handler: adminRouter,
+		}
+
+		if adminTLSConfig := tlsconfig.ServerConfigFromEnv("ADMIN_"); adminTLSConfig.Enabled() {
+			adminServer.TLSConfig, err = tlsconfig.NewServerConfig(adminTLSConfig, log)
+			if err != nil {
+				err = fmt.Errorf("failed to configure admin TLS: %w", err)
+				return
+			}
+		}
+
+		go func() {
+			log.Info("admin server listening", "addr", adminAddr, "tls", adminServer.TLSConfig != nil)
+			serverErrors <- listenAndServe(adminServer)
+		}()
+	}
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 
@@ -88,6 +474,9 @@ func run() (err error) {
 	case sig := <-shutdown:
 		log.Info("received shutdown signal", "signal", sig.String())
 
+		healthRegistry.SetShuttingDown()
+		time.Sleep(config.GetEnvDuration("SHUTDOWN_READINESS_DELAY", 0))
+
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer shutdownCancel()
 
@@ -98,15 +487,127 @@ func run() (err error) {
 			return
 		}
 
-		cancel()
+		if grpcServer != nil {
+			grpcServer.GracefulStop()
+		}
+
+		sourceCancel()
+		sourceGroup.Wait()
+
+		digester.Flush(shutdownCtx)
 
 		workerShutdownCtx, workerShutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer workerShutdownCancel()
 
 		workerPool.Shutdown(workerShutdownCtx)
 
+		cancel()
+
+		if adminServer != nil {
+			err = adminServer.Shutdown(shutdownCtx)
+			if err != nil {
+				err = fmt.Errorf("failed to gracefully shutdown admin server: %w", err)
+				return
+			}
+		}
+
 		log.Info("middleware service shutdown complete")
 	}
 
 	return
 }
+
+func listenAndServe(server *http.Server) (err error) {
+	if server.TLSConfig != nil {
+		err = server.ListenAndServeTLS("", "")
+		return
+	}
+	err = server.ListenAndServe()
+	return
+}
+
+func openEventStore(kind, dataDir string, maxRecords int, keyring *encryption.Keyring) (store domain.EventStore, err error) {
+	if kind == "" {
+		kind = "memory"
+		if dataDir != "" {
+			kind = "bolt"
+		}
+	}
+
+	switch kind {
+	case "bolt":
+		if dataDir == "" {
+			err = errors.New("EVENT_STORE=bolt requires DATA_DIR")
+			return
+		}
+		store, err = repository.NewBoltEventStore(filepath.Join(dataDir, "events.db"), encryption.NewSealer(keyring))
+	case "memory":
+		store = repository.NewMemoryEventStore(maxRecords)
+	case "none":
+	default:
+		err = fmt.Errorf("unknown EVENT_STORE: %s", kind)
+	}
+	return
+}
+
+func openSilenceStore(dataDir string, keyring *encryption.Keyring) (store domain.SilenceStore, err error) {
+	if dataDir == "" {
+		store = repository.NewMemorySilenceStore()
+		return
+	}
+
+	store, err = repository.NewBoltSilenceStore(filepath.Join(dataDir, "silences.db"), encryption.NewSealer(keyring))
+	return
+}
+
+func openEscalationStore(dataDir string, keyring *encryption.Keyring) (store domain.EscalationStore, err error) {
+	if dataDir == "" {
+		store = repository.NewMemoryEscalationStore()
+		return
+	}
+
+	store, err = repository.NewBoltEscalationStore(filepath.Join(dataDir, "escalations.db"), encryption.NewSealer(keyring))
+	return
+}
+
+func buildSyslogSources(ingestor source.Ingestor, log *logger.Logger) (sources []source.Source, err error) {
+	rateLimit := config.GetEnvFloat("SYSLOG_RATE_LIMIT", 0)
+	burst := config.GetEnvInt("SYSLOG_BURST", 0)
+
+	configs := []source.SyslogConfig{
+		{Network: "udp", Address: config.GetEnv("SYSLOG_UDP_ADDR", "")},
+		{Network: "tcp", Address: config.GetEnv("SYSLOG_TCP_ADDR", "")},
+	}
+
+	tlsAddr := config.GetEnv("SYSLOG_TLS_ADDR", "")
+	if tlsAddr != "" {
+		var syslogTLS *tls.Config
+		syslogTLS, err = tlsconfig.NewServerConfig(tlsconfig.ServerConfigFromEnv("SYSLOG_"), log)
+		if err != nil {
+			err = fmt.Errorf("failed to configure syslog TLS: %w", err)
+			return
+		}
+
+		configs = append(configs, source.SyslogConfig{
+			Network: "tcp",
+			Address: tlsAddr,
+			TLS:     syslogTLS,
+		})
+	}
+
+	for _, cfg := range configs {
+		if cfg.Address == "" {
+			continue
+		}
+		cfg.RateLimit = rateLimit
+		cfg.Burst = burst
+
+		var syslogSource *source.SyslogSource
+		syslogSource, err = source.NewSyslogSource(cfg, ingestor, log)
+		if err != nil {
+			return
+		}
+		sources = append(sources, syslogSource)
+	}
+	return
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+type replayFilter struct {
+	Source        string            `json:"source,omitempty"`
+	EventType     string            `json:"event_type,omitempty"`
+	Priority      string            `json:"priority,omitempty"`
+	Status        string            `json:"status,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	Destination   string            `json:"destination,omitempty"`
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to,omitzero"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+type replayRequest struct {
+	Filter      replayFilter `json:"filter"`
+	Destination string       `json:"destination,omitempty"`
+	Rate        float64      `json:"rate,omitempty"`
+	MaxEvents   int          `json:"max_events,omitempty"`
+}
+
+type replayJob struct {
+	ID        string `json:"id"`
+	State     string `json:"state"`
+	Selected  int    `json:"selected"`
+	Enqueued  int    `json:"enqueued"`
+	Delivered int    `json:"delivered"`
+	Failed    int    `json:"failed"`
+	Pending   int    `json:"pending"`
+	Error     string `json:"error"`
+}
+
+type adminClient struct {
+	baseURL  string
+	username string
+	password string
+	client   *http.Client
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() (err error) {
+	var request replayRequest
+	var admin adminClient
+	var from, to, since, metadata string
+	var wait bool
+	var pollInterval time.Duration
+
+	flag.StringVar(&admin.baseURL, "admin", "http://127.0.0.1:9090", "middleware admin base URL")
+	flag.StringVar(&admin.username, "user", "admin", "admin username")
+	flag.StringVar(&from, "from", "", "start of the replay window (RFC3339)")
+	flag.StringVar(&to, "to", "", "end of the replay window (RFC3339, defaults to now)")
+	flag.StringVar(&since, "since", "", "replay the last duration instead of -from, e.g. 2h")
+	flag.StringVar(&request.Filter.Source, "source", "", "only replay events from this source")
+	flag.StringVar(&request.Filter.EventType, "event-type", "", "only replay events of this type")
+	flag.StringVar(&request.Filter.Priority, "priority", "", "only replay events with this priority")
+	flag.StringVar(&request.Filter.Status, "status", "", "only replay events with this delivery status, e.g. failed (default: delivered, failed and rejected)")
+	flag.StringVar(&request.Filter.CorrelationID, "correlation-id", "", "only replay events with this correlation ID")
+	flag.StringVar(&request.Filter.Destination, "origin", "", "only replay events originally sent to this destination")
+	flag.StringVar(&metadata, "metadata", "", "metadata filters, e.g. region=eu,host=db1")
+	flag.StringVar(&request.Destination, "destination", "", "destination to replay to (defaults to each event's original destination)")
+	flag.Float64Var(&request.Rate, "rate", 0, "replayed events per second (server default when 0)")
+	flag.IntVar(&request.MaxEvents, "max-events", 0, "maximum events to replay (server default when 0)")
+	flag.BoolVar(&wait, "wait", true, "wait for the replay to finish and report results")
+	flag.DurationVar(&pollInterval, "poll", time.Second, "progress polling interval")
+	flag.Parse()
+
+	admin.password = os.Getenv("ADMIN_PASSWORD")
+	admin.client = &http.Client{Timeout: 30 * time.Second}
+
+	request.Filter.From, request.Filter.To, err = parseWindow(from, to, since)
+	if err != nil {
+		return
+	}
+	request.Filter.Metadata, err = parseMetadata(metadata)
+	if err != nil {
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var job replayJob
+	err = admin.do(ctx, http.MethodPost, "/admin/replays", request, &job)
+	if err != nil {
+		err = fmt.Errorf("failed to start replay: %w", err)
+		return
+	}
+	fmt.Printf("replay %s started: %d events selected\n", job.ID, job.Selected)
+
+	if !wait {
+		return
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Printf("cancelling replay %s\n", job.ID)
+			err = admin.do(context.Background(), http.MethodDelete, "/admin/replays/"+job.ID, nil, nil)
+			return
+		case <-ticker.C:
+		}
+
+		err = admin.do(ctx, http.MethodGet, "/admin/replays/"+job.ID, nil, &job)
+		if err != nil {
+			err = fmt.Errorf("failed to read replay progress: %w", err)
+			return
+		}
+
+		fmt.Printf("%-9s enqueued %d/%d, delivered %d, failed %d, pending %d\n",
+			job.State, job.Enqueued, job.Selected, job.Delivered, job.Failed, job.Pending)
+
+		if job.State != "running" && job.Pending == 0 {
+			break
+		}
+	}
+
+	if job.Error != "" {
+		err = errors.New(job.Error)
+		return
+	}
+	if job.Failed > 0 {
+		err = fmt.Errorf("%d replayed events failed", job.Failed)
+	}
+	return
+}
+
+func parseWindow(from, to, since string) (start, end time.Time, err error) {
+	if to != "" {
+		end, err = time.Parse(time.RFC3339, to)
+		if err != nil {
+			err = fmt.Errorf("invalid -to: %w", err)
+			return
+		}
+	}
+
+	switch {
+	case since != "":
+		var window time.Duration
+		window, err = time.ParseDuration(since)
+		if err != nil {
+			err = fmt.Errorf("invalid -since: %w", err)
+			return
+		}
+		reference := end
+		if reference.IsZero() {
+			reference = time.Now().UTC()
+		}
+		start = reference.Add(-window)
+	case from != "":
+		start, err = time.Parse(time.RFC3339, from)
+		if err != nil {
+			err = fmt.Errorf("invalid -from: %w", err)
+			return
+		}
+	default:
+		err = errors.New("either -from or -since is required")
+	}
+	return
+}
+
+func parseMetadata(raw string) (metadata map[string]string, err error) {
+	if raw == "" {
+		return
+	}
+
+	metadata = make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		if key == "" {
+			err = fmt.Errorf("invalid metadata filter: %q", pair)
+			return
+		}
+		metadata[key] = value
+	}
+	return
+}
+
+func (a adminClient) do(ctx context.Context, method, path string, body interface{}, target interface{}) (err error) {
+	var reader io.Reader
+	if body != nil {
+		var payload []byte
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	var req *http.Request
+	req, err = http.NewRequestWithContext(ctx, method, a.baseURL+path, reader)
+	if err != nil {
+		return
+	}
+	req.SetBasicAuth(a.username, a.password)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	var resp *http.Response
+	resp, err = a.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		err = fmt.Errorf("status %d: %s", resp.StatusCode, apiErr.Error)
+		return
+	}
+
+	if target != nil {
+		err = json.NewDecoder(resp.Body).Decode(target)
+	}
+	return
+}
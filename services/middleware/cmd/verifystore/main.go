@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/encryption"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+type verifiableStore interface {
+	encryption.Reencrypter
+	Verify(ctx context.Context) (report encryption.VerifyReport, err error)
+	Close() (err error)
+}
+
+type storeFile struct {
+	name string
+	open func(path string, sealer *encryption.Sealer) (store verifiableStore, err error)
+}
+
+var storeFiles = []storeFile{
+	{"events.db", func(path string, sealer *encryption.Sealer) (store verifiableStore, err error) {
+		store, err = repository.NewBoltEventStore(path, sealer)
+		return
+	}},
+	{"silences.db", func(path string, sealer *encryption.Sealer) (store verifiableStore, err error) {
+		store, err = repository.NewBoltSilenceStore(path, sealer)
+		return
+	}},
+	{"escalations.db", func(path string, sealer *encryption.Sealer) (store verifiableStore, err error) {
+		store, err = repository.NewBoltEscalationStore(path, sealer)
+		return
+	}},
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verify error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() (err error) {
+	var dataDir string
+	var reencrypt, requireEncrypted bool
+
+	flag.StringVar(&dataDir, "data-dir", os.Getenv("DATA_DIR"), "middleware data directory (the middleware must be stopped)")
+	flag.BoolVar(&reencrypt, "reencrypt", false, "re-encrypt values under retired master keys and encrypt plaintext values before verifying")
+	flag.BoolVar(&requireEncrypted, "require-encrypted", false, "fail if any value is stored in plaintext")
+	flag.Parse()
+
+	if dataDir == "" {
+		err = errors.New("-data-dir or DATA_DIR is required")
+		return
+	}
+
+	var keyring *encryption.Keyring
+	keyring, err = encryption.KeyringFromEnv()
+	if err != nil {
+		return
+	}
+	if keyring == nil && (reencrypt || requireEncrypted) {
+		err = errors.New("no master keys configured, set STORAGE_KEYRING_FILE or STORAGE_MASTER_KEYS")
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	failed, plaintext := 0, 0
+	for _, file := range storeFiles {
+		path := filepath.Join(dataDir, file.name)
+		if _, statErr := os.Stat(path); statErr != nil {
+			continue
+		}
+
+		var report encryption.VerifyReport
+		report, err = verifyFile(ctx, file, path, encryption.NewSealer(keyring), reencrypt)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", file.name, err)
+			return
+		}
+
+		fmt.Printf("%-15s values %d, verified %d, plaintext %d, data keys %d, failures %d\n",
+			file.name, report.Values, report.Verified, report.Plaintext, report.DataKeys, len(report.Failures))
+		for masterKey, count := range report.MasterKeys {
+			fmt.Printf("%-15s   master key %s: %d values\n", "", masterKey, count)
+		}
+		for _, failure := range report.Failures {
+			fmt.Printf("%-15s   FAILED %s/%s: %s\n", "", failure.Bucket, failure.Key, failure.Error)
+		}
+
+		failed += len(report.Failures)
+		plaintext += report.Plaintext
+	}
+
+	switch {
+	case failed > 0:
+		err = fmt.Errorf("%d values failed verification", failed)
+	case requireEncrypted && plaintext > 0:
+		err = fmt.Errorf("%d values are stored in plaintext", plaintext)
+	}
+	return
+}
+
+func verifyFile(ctx context.Context, file storeFile, path string, sealer *encryption.Sealer, reencrypt bool) (report encryption.VerifyReport, err error) {
+	var store verifiableStore
+	store, err = file.open(path, sealer)
+	if err != nil {
+		return
+	}
+	defer store.Close()
+
+	if reencrypt {
+		var stats encryption.ReencryptStats
+		stats, err = store.Reencrypt(ctx)
+		if err != nil {
+			return
+		}
+		fmt.Printf("%-15s re-encrypted %d, encrypted %d, retired data keys %d, skipped %d\n", file.name, stats.Reencrypted, stats.Encrypted, stats.RetiredKeys, stats.Skipped)
+	}
+
+	report, err = store.Verify(ctx)
+	return
+}
@@ -0,0 +1,137 @@
+package harness
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type CertificateAuthority struct {
+	CertFile string
+
+	dir    string
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	mu     sync.Mutex
+	serial int64
+}
+
+func NewCertificateAuthority(dir, name string) (ca *CertificateAuthority, err error) {
+	var key *ecdsa.PrivateKey
+	key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	var der []byte
+	der, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return
+	}
+
+	var cert *x509.Certificate
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		return
+	}
+
+	ca = &CertificateAuthority{
+		CertFile: filepath.Join(dir, name+".crt"),
+		dir:      dir,
+		cert:     cert,
+		key:      key,
+		serial:   1,
+	}
+
+	err = writePEM(ca.CertFile, "CERTIFICATE", der)
+	if err != nil {
+		ca = nil
+	}
+	return
+}
+
+func (ca *CertificateAuthority) Issue(name string, hosts ...string) (certFile, keyFile string, err error) {
+	var key *ecdsa.PrivateKey
+	key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return
+	}
+
+	ca.mu.Lock()
+	ca.serial++
+	serial := ca.serial
+	ca.mu.Unlock()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	var der []byte
+	der, err = x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return
+	}
+
+	var keyDER []byte
+	keyDER, err = x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return
+	}
+
+	certFile = filepath.Join(ca.dir, name+".crt")
+	keyFile = filepath.Join(ca.dir, name+".key")
+
+	err = writePEM(keyFile, "PRIVATE KEY", keyDER)
+	if err != nil {
+		return
+	}
+	err = writePEM(certFile, "CERTIFICATE", der)
+	return
+}
+
+func writePEM(path, blockType string, der []byte) (err error) {
+	temp := path + ".tmp"
+	err = os.WriteFile(temp, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	if err != nil {
+		err = fmt.Errorf("failed to write %s: %w", path, err)
+		return
+	}
+
+	err = os.Rename(temp, path)
+	if err != nil {
+		err = fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return
+}
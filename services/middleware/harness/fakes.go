@@ -0,0 +1,55 @@
+package harness
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type FakeClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+func NewFakeClock(start time.Time) (clock *FakeClock) {
+	clock = &FakeClock{now: start}
+	return
+}
+
+func (c *FakeClock) Now() (now time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now = c.now
+	return
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+type SequentialIDGenerator struct {
+	prefix string
+	next   atomic.Uint64
+}
+
+func NewSequentialIDGenerator(prefix string) (gen *SequentialIDGenerator) {
+	gen = &SequentialIDGenerator{prefix: prefix}
+	return
+}
+
+func (g *SequentialIDGenerator) Generate() (id string, err error) {
+	id = fmt.Sprintf("%s%06d", g.prefix, g.next.Add(1))
+	return
+}
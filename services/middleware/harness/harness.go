@@ -0,0 +1,471 @@
+package harness
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/httpclient"
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/pkg/redact"
+	"github.com/smartcom/integration-platform/pkg/tlsconfig"
+	"github.com/smartcom/integration-platform/services/external-endpoint/server"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/enrichment"
+	"github.com/smartcom/integration-platform/services/middleware/internal/handler"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+	"github.com/smartcom/integration-platform/services/middleware/internal/worker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type Options struct {
+	QueueSize      int
+	EnqueueTimeout time.Duration
+	Workers        worker.Config
+	HTTPClient     httpclient.Config
+	Destinations   map[string]string
+	Faults         *server.FaultConfig
+	Clock          usecase.Clock
+	IDGenerator    usecase.IDGenerator
+	Store          domain.EventStore
+	Kafka          *KafkaOptions
+	GRPC           bool
+	AutoResolve    time.Duration
+	InhibitRules   []domain.InhibitRule
+	Routing        domain.RoutingConfig
+	Enrichers      []enrichment.Config
+	Redaction      *usecase.RedactionConfig
+	TLS            *TLSOptions
+	WrapProcessor  func(processor domain.EventProcessor) (wrapped domain.EventProcessor)
+	LogOutput      io.Writer
+	LogLevel       slog.Level
+}
+
+type TLSOptions struct {
+	Middleware   tlsconfig.ServerConfig
+	External     tlsconfig.ServerConfig
+	Client       tlsconfig.ClientConfig
+	Destinations map[string]tlsconfig.ClientConfig
+}
+
+type Harness struct {
+	MiddlewareURL string
+	ExternalURL   string
+	GRPCAddr      string
+
+	Clock        usecase.Clock
+	IDGenerator  usecase.IDGenerator
+	Queue        *repository.EventQueue
+	Pool         *worker.Pool
+	Destinations *repository.DestinationRegistry
+	Store        domain.EventStore
+	Silences     *usecase.Silencer
+	Alerts       *usecase.AlertTracker
+	Escalator    *usecase.Escalator
+	Digester     *usecase.Digester
+	Ingestor     *usecase.Ingestor
+	Kafka        *Kafka
+	External     *server.Server
+
+	client     *http.Client
+	cancel     context.CancelFunc
+	servers    []*http.Server
+	external   *http.Server
+	grpcServer *grpc.Server
+}
+
+type EventResponse struct {
+	Status        string `json:"status"`
+	EventID       string `json:"event_id"`
+	CorrelationID string `json:"correlation_id"`
+	Error         string `json:"error"`
+}
+
+var ErrUnexpectedStatus = errors.New("unexpected status code")
+
+func New(opts Options) (h *Harness, err error) {
+	opts = withDefaults(opts)
+	log := logger.New(opts.LogOutput, opts.LogLevel)
+
+	gin.SetMode(gin.TestMode)
+
+	h = &Harness{
+		Clock:       opts.Clock,
+		IDGenerator: opts.IDGenerator,
+		Store:       opts.Store,
+		client:      &http.Client{Timeout: 30 * time.Second},
+	}
+
+	var middlewareTLS, externalTLS *tls.Config
+	var destinationTLS map[string]tlsconfig.ClientConfig
+	if opts.TLS != nil {
+		middlewareTLS, externalTLS, err = h.configureTLS(*opts.TLS, log)
+		if err != nil {
+			return
+		}
+		destinationTLS = opts.TLS.Destinations
+	}
+
+	h.External, err = server.New(server.Config{
+		Logger: log,
+		Faults: opts.Faults,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to build external endpoint: %w", err)
+		return
+	}
+
+	h.external, h.ExternalURL, err = h.serve(h.External.Router, externalTLS)
+	if err != nil {
+		return
+	}
+
+	destinations := []domain.Destination{{Name: domain.DefaultDestination, URL: h.ExternalURL + "/external/alerts"}}
+	for name, url := range opts.Destinations {
+		if name != domain.DefaultDestination {
+			destinations = append(destinations, domain.Destination{Name: name, URL: url})
+		}
+	}
+	h.Destinations = repository.NewDestinationRegistry(destinations)
+
+	var destinationRedactors map[string]*redact.Redactor
+	if opts.Redaction != nil {
+		var redactor *redact.Redactor
+		redactor, err = redact.New(opts.Redaction.Config)
+		if err != nil {
+			h.Close()
+			return
+		}
+		log.SetRedactor(redactor)
+
+		destinationRedactors, err = usecase.NewDestinationRedactors(*opts.Redaction, redactor, h.Destinations)
+		if err != nil {
+			h.Close()
+			return
+		}
+	}
+
+	var httpClient *httpclient.Client
+	httpClient, err = httpclient.New(opts.HTTPClient)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	var destinationClients map[string]*httpclient.Client
+	destinationClients, err = usecase.NewDestinationClients(opts.HTTPClient, destinationTLS, h.Destinations)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	eventMapper := usecase.NewEventMapper(opts.IDGenerator, opts.Clock)
+	var eventProcessor domain.EventProcessor
+	eventProcessor = usecase.NewEventProcessor(httpClient, destinationClients, h.Destinations, destinationRedactors, log)
+	eventProcessor = usecase.NewRecordingProcessor(eventProcessor, h.Store, opts.Clock, log)
+	if opts.WrapProcessor != nil {
+		eventProcessor = opts.WrapProcessor(eventProcessor)
+	}
+
+	h.Queue = repository.NewEventQueue(opts.QueueSize, opts.EnqueueTimeout)
+	h.Pool, err = worker.NewPool(opts.Workers, h.Queue, eventProcessor, log)
+	if err != nil {
+		h.Close()
+		return
+	}
+	h.Destinations.Subscribe(h.Pool.SetDestinationEnabled)
+
+	var ctx context.Context
+	ctx, h.cancel = context.WithCancel(context.Background())
+	h.Pool.Start(ctx)
+
+	h.Silences, err = usecase.NewSilencer(ctx, repository.NewMemorySilenceStore(), opts.IDGenerator, opts.Clock)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	h.Alerts = usecase.NewAlertTracker(opts.Clock, opts.AutoResolve)
+	_, err = h.Alerts.Rebuild(ctx, h.Store)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	var inhibitor *usecase.Inhibitor
+	inhibitor, err = usecase.NewInhibitor(opts.InhibitRules, h.Alerts)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	var eventRouter *usecase.Router
+	eventRouter, err = usecase.NewRouter(opts.Routing, h.Destinations)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	var transformer *usecase.Transformer
+	transformer, err = usecase.NewTransformer(opts.Routing, log)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	h.Escalator, err = usecase.NewEscalator(ctx, eventRouter, repository.NewMemoryEscalationStore(), h.Store, h.Queue, opts.IDGenerator, opts.Clock, log)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	h.Digester, err = usecase.NewDigester(eventRouter, h.Store, h.Queue, opts.IDGenerator, opts.Clock, log)
+	if err != nil {
+		h.Close()
+		return
+	}
+
+	var enricher *enrichment.Chain
+	enricher, err = enrichment.NewChain(opts.Enrichers, log)
+	if err != nil {
+		h.Close()
+		return
+	}
+	enricher.Start(ctx)
+
+	h.Ingestor = usecase.NewIngestor(eventMapper, h.Queue, h.Store, usecase.IngestPipeline{
+		Enricher:    enricher,
+		Suppressors: []usecase.Suppressor{h.Silences, inhibitor},
+		Alerts:      h.Alerts,
+		Router:      eventRouter,
+		Transformer: transformer,
+		Escalator:   h.Escalator,
+		Digester:    h.Digester,
+	}, log)
+	ingestor := h.Ingestor
+
+	if opts.Kafka != nil {
+		h.Kafka, err = startKafka(*opts.Kafka, ingestor, log)
+		if err != nil {
+			h.Close()
+			return
+		}
+	}
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	handler.NewEventHandler(ingestor, log).RegisterRoutes(router)
+	handler.NewWebhookHandler(ingestor, log).RegisterRoutes(router)
+	handler.NewAcknowledgeHandler(ingestor, h.Alerts, log).RegisterRoutes(router)
+
+	var middlewareServer *http.Server
+	middlewareServer, h.MiddlewareURL, err = h.serve(router, middlewareTLS)
+	if err != nil {
+		h.Close()
+		return
+	}
+	h.servers = append(h.servers, middlewareServer)
+
+	if opts.GRPC {
+		var listener net.Listener
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			h.Close()
+			err = fmt.Errorf("failed to listen on ephemeral port: %w", err)
+			return
+		}
+
+		var grpcOptions []grpc.ServerOption
+		if middlewareTLS != nil {
+			grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(middlewareTLS)))
+		}
+
+		h.grpcServer = grpc.NewServer(grpcOptions...)
+		handler.NewIngestGRPCHandler(ingestor, log).Register(h.grpcServer)
+		go h.grpcServer.Serve(listener)
+
+		h.GRPCAddr = listener.Addr().String()
+	}
+	return
+}
+
+func Start(tb testing.TB, opts Options) (h *Harness) {
+	tb.Helper()
+
+	h, err := New(opts)
+	if err != nil {
+		tb.Fatalf("failed to start harness: %v", err)
+	}
+	tb.Cleanup(h.Close)
+	return
+}
+
+func (h *Harness) configureTLS(opts TLSOptions, log tlsconfig.Logger) (middlewareTLS, externalTLS *tls.Config, err error) {
+	if opts.Middleware.Enabled() {
+		middlewareTLS, err = tlsconfig.NewServerConfig(opts.Middleware, log)
+		if err != nil {
+			return
+		}
+
+		var clientTLS *tls.Config
+		clientTLS, err = tlsconfig.NewClientConfig(opts.Client)
+		if err != nil {
+			return
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = clientTLS
+		h.client.Transport = transport
+	}
+
+	if opts.External.Enabled() {
+		externalTLS, err = tlsconfig.NewServerConfig(opts.External, log)
+	}
+	return
+}
+
+func (h *Harness) serve(router http.Handler, tlsConfig *tls.Config) (srv *http.Server, baseURL string, err error) {
+	var listener net.Listener
+	listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		err = fmt.Errorf("failed to listen on ephemeral port: %w", err)
+		return
+	}
+
+	srv = &http.Server{Handler: router, TLSConfig: tlsConfig}
+
+	if tlsConfig != nil {
+		go srv.ServeTLS(listener, "", "")
+		baseURL = "https://" + listener.Addr().String()
+		return
+	}
+
+	go srv.Serve(listener)
+	baseURL = "http://" + listener.Addr().String()
+	return
+}
+
+func (h *Harness) SendEvent(ctx context.Context, incoming domain.IncomingEvent) (response EventResponse, err error) {
+	var body []byte
+	body, err = json.Marshal(incoming)
+	if err != nil {
+		return
+	}
+
+	var req *http.Request
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, h.MiddlewareURL+"/integrations/events", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp *http.Response
+	resp, err = h.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		err = fmt.Errorf("failed to decode response: %w", err)
+		return
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%w: %d: %s", ErrUnexpectedStatus, resp.StatusCode, response.Error)
+	}
+	return
+}
+
+func (h *Harness) AwaitDelivery(ctx context.Context, eventID string) (entry server.JournalEntry, err error) {
+	var entries []server.JournalEntry
+	entries, err = h.External.Journal.Wait(ctx, server.JournalFilter{EventID: eventID}, 1)
+	if err != nil {
+		err = fmt.Errorf("event %s was not delivered: %w", eventID, err)
+		return
+	}
+
+	entry = entries[0]
+	return
+}
+
+func (h *Harness) AwaitDeliveries(ctx context.Context, filter server.JournalFilter, count int) (entries []server.JournalEntry, err error) {
+	entries, err = h.External.Journal.Wait(ctx, filter, count)
+	if err != nil {
+		err = fmt.Errorf("expected %d deliveries, got %d: %w", count, h.External.Journal.Count(filter), err)
+	}
+	return
+}
+
+func (h *Harness) Close() {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, srv := range h.servers {
+		srv.Shutdown(shutdownCtx)
+	}
+	if h.grpcServer != nil {
+		h.grpcServer.Stop()
+	}
+	if h.Kafka != nil {
+		h.Kafka.stop()
+	}
+
+	if h.Digester != nil {
+		h.Digester.Flush(shutdownCtx)
+	}
+	if h.Pool != nil {
+		h.Pool.Shutdown(shutdownCtx)
+	}
+	if h.cancel != nil {
+		h.cancel()
+	}
+	if h.external != nil {
+		h.external.Shutdown(shutdownCtx)
+	}
+	if h.Kafka != nil {
+		h.Kafka.close()
+	}
+}
+
+func withDefaults(opts Options) (result Options) {
+	result = opts
+	if result.QueueSize <= 0 {
+		result.QueueSize = 100
+	}
+	if result.Workers.InitialWorkers <= 0 {
+		result.Workers.InitialWorkers = 2
+	}
+	if result.HTTPClient.Timeout <= 0 {
+		result.HTTPClient.Timeout = 2 * time.Second
+	}
+	if result.HTTPClient.BaseDelay <= 0 {
+		result.HTTPClient.BaseDelay = 10 * time.Millisecond
+	}
+	if result.Clock == nil {
+		result.Clock = NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	if result.IDGenerator == nil {
+		result.IDGenerator = NewSequentialIDGenerator("evt-")
+	}
+	if result.LogOutput == nil {
+		result.LogOutput = io.Discard
+	}
+	if result.Store == nil {
+		result.Store = repository.NewMemoryEventStore(0)
+	}
+	return
+}
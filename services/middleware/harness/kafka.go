@@ -0,0 +1,169 @@
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/source"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type KafkaOptions struct {
+	Topic           string
+	Partitions      int
+	Group           string
+	DeadLetterTopic string
+}
+
+type Kafka struct {
+	Brokers         []string
+	Topic           string
+	Group           string
+	DeadLetterTopic string
+
+	cluster  *kfake.Cluster
+	producer *kgo.Client
+	sources  *source.Group
+	cancel   context.CancelFunc
+}
+
+func startKafka(opts KafkaOptions, ingestor source.Ingestor, log source.SourceLogger) (k *Kafka, err error) {
+	if opts.Topic == "" {
+		opts.Topic = "events"
+	}
+	if opts.Partitions <= 0 {
+		opts.Partitions = 1
+	}
+	if opts.Group == "" {
+		opts.Group = source.DefaultKafkaGroup
+	}
+
+	k = &Kafka{
+		Topic:           opts.Topic,
+		Group:           opts.Group,
+		DeadLetterTopic: opts.DeadLetterTopic,
+	}
+
+	topics := []string{opts.Topic}
+	if opts.DeadLetterTopic != "" {
+		topics = append(topics, opts.DeadLetterTopic)
+	}
+
+	k.cluster, err = kfake.NewCluster(
+		kfake.NumBrokers(1),
+		kfake.SeedTopics(int32(opts.Partitions), topics...),
+	)
+	if err != nil {
+		err = fmt.Errorf("failed to start in-process kafka cluster: %w", err)
+		return
+	}
+	k.Brokers = k.cluster.ListenAddrs()
+
+	k.producer, err = kgo.NewClient(
+		kgo.SeedBrokers(k.Brokers...),
+		kgo.DefaultProduceTopic(opts.Topic),
+	)
+	if err != nil {
+		k.cluster.Close()
+		err = fmt.Errorf("failed to create kafka producer: %w", err)
+		return
+	}
+
+	var kafkaSource *source.KafkaSource
+	kafkaSource, err = source.NewKafkaSource(source.KafkaConfig{
+		Brokers:         k.Brokers,
+		Topic:           opts.Topic,
+		Group:           opts.Group,
+		RetryBackoff:    10 * time.Millisecond,
+		DeadLetterTopic: opts.DeadLetterTopic,
+	}, ingestor, log)
+	if err != nil {
+		k.producer.Close()
+		k.cluster.Close()
+		return
+	}
+
+	var ctx context.Context
+	ctx, k.cancel = context.WithCancel(context.Background())
+	k.sources = source.NewGroup(log, kafkaSource)
+	k.sources.Start(ctx, make(chan error, 1))
+	return
+}
+
+func (k *Kafka) Produce(ctx context.Context, key string, incoming domain.IncomingEvent, headers map[string]string) (err error) {
+	var value []byte
+	value, err = json.Marshal(incoming)
+	if err != nil {
+		return
+	}
+
+	err = k.ProduceRaw(ctx, key, value, headers)
+	return
+}
+
+func (k *Kafka) ProduceRaw(ctx context.Context, key string, value []byte, headers map[string]string) (err error) {
+	record := &kgo.Record{Value: value}
+	if key != "" {
+		record.Key = []byte(key)
+	}
+	for name, headerValue := range headers {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: name, Value: []byte(headerValue)})
+	}
+
+	err = k.producer.ProduceSync(ctx, record).FirstErr()
+	return
+}
+
+func (k *Kafka) CommittedOffsets(ctx context.Context) (offsets map[int32]int64, err error) {
+	var fetched kadm.OffsetResponses
+	fetched, err = kadm.NewClient(k.producer).FetchOffsets(ctx, k.Group)
+	if err != nil {
+		return
+	}
+
+	offsets = make(map[int32]int64)
+	fetched.Each(func(response kadm.OffsetResponse) {
+		if response.Topic == k.Topic && response.Err == nil {
+			offsets[response.Partition] = response.At
+		}
+	})
+	return
+}
+
+func (k *Kafka) DeadLetters(ctx context.Context, count int) (records []*kgo.Record, err error) {
+	var consumer *kgo.Client
+	consumer, err = kgo.NewClient(
+		kgo.SeedBrokers(k.Brokers...),
+		kgo.ConsumeTopics(k.DeadLetterTopic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		return
+	}
+	defer consumer.Close()
+
+	for len(records) < count {
+		fetches := consumer.PollFetches(ctx)
+		if ctx.Err() != nil {
+			err = fmt.Errorf("got %d of %d dead-letter records: %w", len(records), count, ctx.Err())
+			return
+		}
+		records = append(records, fetches.Records()...)
+	}
+	return
+}
+
+func (k *Kafka) stop() {
+	k.cancel()
+	k.sources.Wait()
+}
+
+func (k *Kafka) close() {
+	k.producer.Close()
+	k.cluster.Close()
+}
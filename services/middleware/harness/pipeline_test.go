@@ -0,0 +1,594 @@
+package harness_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/httpclient"
+	"github.com/smartcom/integration-platform/services/external-endpoint/server"
+	ingestv1 "github.com/smartcom/integration-platform/services/middleware/api/ingest/v1"
+	"github.com/smartcom/integration-platform/services/middleware/harness"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/enrichment"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+	"github.com/smartcom/integration-platform/services/middleware/internal/worker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+func testEvent() (incoming domain.IncomingEvent) {
+	incoming = domain.IncomingEvent{
+		Source:    "db",
+		EventType: "replication_lag",
+		Severity:  "high",
+		Message:   "replica is 42s behind",
+		Metadata:  map[string]interface{}{"region": "eu"},
+	}
+	return
+}
+
+func testContext(t *testing.T) (ctx context.Context) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return
+}
+
+func awaitStatus(t *testing.T, h *harness.Harness, eventID string, status domain.DeliveryStatus) (record domain.EventRecord) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var err error
+		record, err = h.Store.Get(context.Background(), eventID)
+		if err == nil && record.Status == status {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("event %s status = %q (err %v), want %q", eventID, record.Status, err, status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPipelineDeliversEvent(t *testing.T) {
+	h := harness.Start(t, harness.Options{})
+	ctx := testContext(t)
+
+	resp, err := h.SendEvent(ctx, testEvent())
+	if err != nil {
+		t.Fatalf("SendEvent returned error: %v", err)
+	}
+	if resp.Status != "accepted" || resp.EventID != "evt-000001" {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	entry, err := h.AwaitDelivery(ctx, resp.EventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.CorrelationID != resp.CorrelationID || entry.Headers["X-Correlation-Id"] != resp.CorrelationID {
+		t.Errorf("correlation ID not propagated: entry %q headers %v, want %q", entry.CorrelationID, entry.Headers, resp.CorrelationID)
+	}
+	if entry.Payload["source"] != "db" || entry.Payload["priority"] != "high" || entry.Payload["message"] != "replica is 42s behind" {
+		t.Errorf("payload = %v", entry.Payload)
+	}
+
+	record := awaitStatus(t, h, resp.EventID, domain.StatusDelivered)
+	if record.Attempts != 1 {
+		t.Errorf("attempts = %d, want 1", record.Attempts)
+	}
+}
+
+func TestPipelineRetriesTransientFailures(t *testing.T) {
+	h := harness.Start(t, harness.Options{
+		HTTPClient: httpclient.Config{MaxRetries: 3},
+		Faults: &server.FaultConfig{
+			Scenarios: map[string]server.FaultScenario{"flaky": {FailFirst: 2, StatusCode: 503}},
+			Global:    "flaky",
+		},
+	})
+	ctx := testContext(t)
+
+	resp, err := h.SendEvent(ctx, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = h.AwaitDelivery(ctx, resp.EventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitStatus(t, h, resp.EventID, domain.StatusDelivered)
+}
+
+func TestPipelineRecordsFailedDelivery(t *testing.T) {
+	h := harness.Start(t, harness.Options{
+		HTTPClient: httpclient.Config{MaxRetries: 1},
+		Faults: &server.FaultConfig{
+			Scenarios: map[string]server.FaultScenario{"down": {ErrorRate: 1, StatusCode: 500}},
+			Global:    "down",
+		},
+	})
+	ctx := testContext(t)
+
+	resp, err := h.SendEvent(ctx, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := awaitStatus(t, h, resp.EventID, domain.StatusFailed)
+	if record.Error == "" {
+		t.Error("failed record has no error")
+	}
+	if count := h.External.Journal.Count(server.JournalFilter{EventID: resp.EventID}); count != 0 {
+		t.Errorf("journal recorded %d failed deliveries", count)
+	}
+}
+
+func TestPipelineRejectsInvalidEvent(t *testing.T) {
+	h := harness.Start(t, harness.Options{})
+
+	incoming := testEvent()
+	incoming.Message = ""
+
+	_, err := h.SendEvent(testContext(t), incoming)
+	if !errors.Is(err, harness.ErrUnexpectedStatus) {
+		t.Fatalf("error = %v, want ErrUnexpectedStatus", err)
+	}
+}
+
+func TestPipelineSilenceSuppressesEvent(t *testing.T) {
+	h := harness.Start(t, harness.Options{})
+	ctx := testContext(t)
+
+	now := h.Clock.Now()
+	_, err := h.Silences.Create(ctx, domain.Silence{
+		Matchers:  []domain.Matcher{{Field: "source", Value: "db"}},
+		StartsAt:  now,
+		EndsAt:    now.Add(time.Hour),
+		CreatedBy: "test",
+		Comment:   "maintenance",
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	resp, err := h.SendEvent(ctx, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "suppressed" {
+		t.Fatalf("status = %q, want suppressed", resp.Status)
+	}
+
+	record := awaitStatus(t, h, resp.EventID, domain.StatusSuppressed)
+	if record.SilenceID == "" {
+		t.Error("suppressed record has no silence ID")
+	}
+
+	other := testEvent()
+	other.Source = "web"
+	resp, err = h.SendEvent(ctx, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = h.AwaitDelivery(ctx, resp.EventID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPipelineTransformDropsEvent(t *testing.T) {
+	h := harness.Start(t, harness.Options{
+		Routing: domain.RoutingConfig{Routes: []domain.Route{{
+			Name:     "db",
+			Matchers: []domain.Matcher{{Field: "source", Value: "db"}},
+			Transforms: []domain.Transform{
+				{Name: "drop-debug", When: "metadata.region == 'test'", Drop: true},
+				{Name: "tag", Set: map[string]string{"message": "'[' + metadata.region + '] ' + message"}},
+			},
+		}}},
+	})
+	ctx := testContext(t)
+
+	dropped := testEvent()
+	dropped.Metadata = map[string]interface{}{"region": "test"}
+	resp, err := h.SendEvent(ctx, dropped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitStatus(t, h, resp.EventID, domain.StatusDropped)
+
+	resp, err = h.SendEvent(ctx, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := h.AwaitDelivery(ctx, resp.EventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Payload["message"] != "[eu] replica is 42s behind" {
+		t.Errorf("message = %v", entry.Payload["message"])
+	}
+}
+
+type gatedProcessor struct {
+	next    domain.EventProcessor
+	source  string
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func (p *gatedProcessor) ProcessEvent(event domain.Event) (err error) {
+	if event.Source == p.source {
+		close(p.started)
+		<-p.gate
+	}
+	err = p.next.ProcessEvent(event)
+	return
+}
+
+func TestPipelineCloseDeliversPendingDigests(t *testing.T) {
+	gated := &gatedProcessor{source: "web", started: make(chan struct{}), gate: make(chan struct{})}
+	h := harness.Start(t, harness.Options{
+		Workers: worker.Config{InitialWorkers: 1},
+		Routing: domain.RoutingConfig{Routes: []domain.Route{{
+			Name:     "db",
+			Matchers: []domain.Matcher{{Field: "source", Value: "db"}},
+			Digest:   &domain.Digest{Schedule: "hourly"},
+		}}},
+		WrapProcessor: func(processor domain.EventProcessor) (wrapped domain.EventProcessor) {
+			gated.next = processor
+			wrapped = gated
+			return
+		},
+	})
+	ctx := testContext(t)
+
+	resp, err := h.SendEvent(ctx, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitStatus(t, h, resp.EventID, domain.StatusDigested)
+
+	busy := testEvent()
+	busy.Source = "web"
+	_, err = h.SendEvent(ctx, busy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-gated.started
+
+	go func() {
+		for h.Queue.Len() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(gated.gate)
+	}()
+	h.Close()
+
+	entries := h.External.Journal.List(server.JournalFilter{})
+	if len(entries) != 2 {
+		t.Fatalf("journal entries = %d, want 2", len(entries))
+	}
+	digests := 0
+	for _, entry := range entries {
+		if entry.Payload["event_type"] == usecase.DigestEventType {
+			digests++
+			awaitStatus(t, h, entry.EventID, domain.StatusDelivered)
+		}
+	}
+	if digests != 1 {
+		t.Errorf("digests delivered = %d, want 1", digests)
+	}
+}
+
+func TestPipelineResolvesAlertsOpenedBeforeRestart(t *testing.T) {
+	store := repository.NewMemoryEventStore(0)
+	ctx := testContext(t)
+
+	trigger := testEvent()
+	trigger.Action = string(domain.ActionTrigger)
+	trigger.DedupKey = "db-lag"
+
+	before := harness.Start(t, harness.Options{Store: store, IDGenerator: harness.NewSequentialIDGenerator("before-")})
+	resp, err := before.SendEvent(ctx, trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitStatus(t, before, resp.EventID, domain.StatusDelivered)
+	before.Close()
+
+	after := harness.Start(t, harness.Options{Store: store, IDGenerator: harness.NewSequentialIDGenerator("after-")})
+	if _, err = after.Alerts.Get("db-lag"); err != nil {
+		t.Fatalf("alert was not rebuilt: %v", err)
+	}
+
+	resolve := trigger
+	resolve.Action = string(domain.ActionResolve)
+	resp, err = after.SendEvent(ctx, resolve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitStatus(t, after, resp.EventID, domain.StatusDelivered)
+}
+
+func TestPipelineGRPCIngestion(t *testing.T) {
+	h := harness.Start(t, harness.Options{GRPC: true})
+	ctx := testContext(t)
+
+	conn, err := grpc.NewClient(h.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	resp, err := ingestv1.NewIngestServiceClient(conn).IngestEvent(ctx, &ingestv1.IngestEventRequest{
+		Event: &ingestv1.IncomingEvent{Source: "db", EventType: "lag", Severity: "critical", Message: "from grpc"},
+	})
+	if err != nil {
+		t.Fatalf("IngestEvent returned error: %v", err)
+	}
+
+	entry, err := h.AwaitDelivery(ctx, resp.GetEventId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Payload["message"] != "from grpc" || entry.Payload["priority"] != "critical" {
+		t.Errorf("payload = %v", entry.Payload)
+	}
+}
+
+func TestPipelineGRPCStreamCorrelationIDs(t *testing.T) {
+	h := harness.Start(t, harness.Options{GRPC: true})
+
+	conn, err := grpc.NewClient(h.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"generated", testContext(t)},
+		{"from metadata", metadata.AppendToOutgoingContext(testContext(t), "x-correlation-id", "batch")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := ingestv1.NewIngestServiceClient(conn).IngestEvents(tt.ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 3; i++ {
+				err = stream.Send(&ingestv1.IngestEventsRequest{
+					Event: &ingestv1.IncomingEvent{Source: "db", EventType: "lag", Severity: "low", Message: "streamed"},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			summary, err := stream.CloseAndRecv()
+			if err != nil {
+				t.Fatalf("CloseAndRecv returned error: %v", err)
+			}
+
+			seen := make(map[string]bool)
+			for i, event := range summary.GetEvents() {
+				id := event.GetCorrelationId()
+				if id == "" || seen[id] {
+					t.Errorf("event %d correlation ID %q is not unique", i, id)
+				}
+				seen[id] = true
+
+				entry, err := h.AwaitDelivery(tt.ctx, event.GetEventId())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if entry.CorrelationID != id {
+					t.Errorf("delivered correlation ID = %q, want %q", entry.CorrelationID, id)
+				}
+			}
+			if tt.name == "from metadata" && !seen["batch-1"] {
+				t.Errorf("correlation IDs = %v, want batch-1 among them", seen)
+			}
+		})
+	}
+}
+
+func TestPipelineGRPCStreamCountsSuppressedSeparately(t *testing.T) {
+	h := harness.Start(t, harness.Options{GRPC: true})
+	ctx := testContext(t)
+
+	conn, err := grpc.NewClient(h.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	stream, err := ingestv1.NewIngestServiceClient(conn).IngestEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []*ingestv1.IncomingEvent{
+		{Source: "db", EventType: "lag", Severity: "high", Message: "lagging", DedupKey: "db-lag"},
+		{Source: "db", EventType: "lag", Severity: "high", Message: "recovered", DedupKey: "db-disk", Action: "resolve"},
+		{Source: "db", EventType: "lag", Severity: "high", Message: "recovered", DedupKey: "db-lag", Action: "resolve"},
+	}
+	for _, event := range events {
+		err = stream.Send(&ingestv1.IngestEventsRequest{Event: event})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summary, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("CloseAndRecv returned error: %v", err)
+	}
+	if summary.GetAccepted() != 2 || summary.GetSuppressed() != 1 {
+		t.Errorf("summary accepted = %d, suppressed = %d, want 2 and 1", summary.GetAccepted(), summary.GetSuppressed())
+	}
+
+	wantStatuses := []string{"accepted", "suppressed", "accepted"}
+	for i, event := range summary.GetEvents() {
+		if event.GetStatus() != wantStatuses[i] {
+			t.Errorf("event %d status = %q, want %q", i, event.GetStatus(), wantStatuses[i])
+		}
+	}
+}
+
+func TestPipelineKafkaIngestion(t *testing.T) {
+	h := harness.Start(t, harness.Options{Kafka: &harness.KafkaOptions{}})
+	ctx := testContext(t)
+
+	for i := 0; i < 3; i++ {
+		err := h.Kafka.Produce(ctx, "db", testEvent(), nil)
+		if err != nil {
+			t.Fatalf("Produce returned error: %v", err)
+		}
+	}
+
+	entries, err := h.AwaitDeliveries(ctx, server.JournalFilter{}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Payload["source"] != "db" {
+			t.Errorf("payload = %v", entry.Payload)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		offsets, err := h.Kafka.CommittedOffsets(ctx)
+		if err == nil && offsets[0] == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("committed offsets = %v (err %v), want partition 0 at 3", offsets, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestPipelineHoldsEventsForDisabledDestination(t *testing.T) {
+	h := harness.Start(t, harness.Options{})
+	ctx := testContext(t)
+
+	err := h.Destinations.SetEnabled(domain.DefaultDestination, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var eventIDs []string
+	for i := 0; i < 3; i++ {
+		resp, err := h.SendEvent(ctx, testEvent())
+		if err != nil {
+			t.Fatal(err)
+		}
+		eventIDs = append(eventIDs, resp.EventID)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for h.Pool.Stats().Bulkheads[domain.DefaultDestination].Held != 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("held = %d, want 3", h.Pool.Stats().Bulkheads[domain.DefaultDestination].Held)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if count := h.External.Journal.Count(server.JournalFilter{}); count != 0 {
+		t.Fatalf("journal has %d entries while the destination is disabled", count)
+	}
+	for _, eventID := range eventIDs {
+		record, err := h.Store.Get(ctx, eventID)
+		if err != nil || record.Status != domain.StatusAccepted {
+			t.Errorf("held event %s status = %q (err %v), want accepted", eventID, record.Status, err)
+		}
+	}
+
+	err = h.Destinations.SetEnabled(domain.DefaultDestination, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, eventID := range eventIDs {
+		if _, err = h.AwaitDelivery(ctx, eventID); err != nil {
+			t.Fatal(err)
+		}
+		awaitStatus(t, h, eventID, domain.StatusDelivered)
+	}
+}
+
+func TestPipelineKafkaDeadLettersPermanentFailures(t *testing.T) {
+	h := harness.Start(t, harness.Options{
+		Kafka: &harness.KafkaOptions{DeadLetterTopic: "events-dlq"},
+		Enrichers: []enrichment.Config{{
+			Name:    "inventory",
+			Type:    "http",
+			Key:     "metadata.host",
+			URL:     "http://127.0.0.1:1/hosts/{key}",
+			OnError: "closed",
+		}},
+	})
+	ctx := testContext(t)
+
+	err := h.Kafka.ProduceRaw(ctx, "db", []byte(`{"source": "db"`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unenrichable := testEvent()
+	unenrichable.Metadata = map[string]interface{}{"host": "db-01"}
+	err = h.Kafka.Produce(ctx, "db", unenrichable, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = h.Kafka.Produce(ctx, "db", testEvent(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = h.AwaitDeliveries(ctx, server.JournalFilter{}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := h.Kafka.DeadLetters(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		var reason string
+		for _, header := range record.Headers {
+			if header.Key == "X-Dead-Letter-Error" {
+				reason = string(header.Value)
+			}
+		}
+		if reason == "" {
+			t.Errorf("dead-letter record %q has no error header", record.Value)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		offsets, err := h.Kafka.CommittedOffsets(ctx)
+		if err == nil && offsets[0] == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("committed offsets = %v (err %v), want partition 0 at 3", offsets, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
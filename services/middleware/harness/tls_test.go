@@ -0,0 +1,171 @@
+package harness_test
+
+import (
+	"crypto/tls"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/httpclient"
+	"github.com/smartcom/integration-platform/pkg/tlsconfig"
+	"github.com/smartcom/integration-platform/services/external-endpoint/server"
+	"github.com/smartcom/integration-platform/services/middleware/harness"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type testPKI struct {
+	ca         *harness.CertificateAuthority
+	other      *harness.CertificateAuthority
+	serverCert string
+	serverKey  string
+	clientCert string
+	clientKey  string
+}
+
+func newTestPKI(t *testing.T) (pki testPKI) {
+	t.Helper()
+
+	dir := t.TempDir()
+	var err error
+	pki.ca, err = harness.NewCertificateAuthority(dir, "ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pki.other, err = harness.NewCertificateAuthority(dir, "other-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pki.serverCert, pki.serverKey, err = pki.ca.Issue("external", "external.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pki.clientCert, pki.clientKey, err = pki.ca.Issue("middleware")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func (p testPKI) external(reload time.Duration) (cfg tlsconfig.ServerConfig) {
+	cfg = tlsconfig.ServerConfig{
+		CertFile:       p.serverCert,
+		KeyFile:        p.serverKey,
+		ClientCAFile:   p.ca.CertFile,
+		ClientAuth:     tlsconfig.ClientAuthRequire,
+		ReloadInterval: reload,
+	}
+	return
+}
+
+func TestPipelineDestinationTLS(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := []struct {
+		name   string
+		client tlsconfig.ClientConfig
+		want   domain.DeliveryStatus
+	}{
+		{
+			name:   "mutual TLS with destination CA and server name",
+			client: tlsconfig.ClientConfig{CAFile: pki.ca.CertFile, CertFile: pki.clientCert, KeyFile: pki.clientKey, ServerName: "external.test"},
+			want:   domain.StatusDelivered,
+		},
+		{
+			name:   "missing client certificate",
+			client: tlsconfig.ClientConfig{CAFile: pki.ca.CertFile, ServerName: "external.test"},
+			want:   domain.StatusFailed,
+		},
+		{
+			name:   "server name does not match certificate",
+			client: tlsconfig.ClientConfig{CAFile: pki.ca.CertFile, CertFile: pki.clientCert, KeyFile: pki.clientKey},
+			want:   domain.StatusFailed,
+		},
+		{
+			name:   "untrusted server CA",
+			client: tlsconfig.ClientConfig{CAFile: pki.other.CertFile, CertFile: pki.clientCert, KeyFile: pki.clientKey, ServerName: "external.test"},
+			want:   domain.StatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := harness.Start(t, harness.Options{
+				HTTPClient: httpclient.Config{MaxRetries: 1},
+				TLS: &harness.TLSOptions{
+					External:     pki.external(0),
+					Destinations: map[string]tlsconfig.ClientConfig{domain.DefaultDestination: tt.client},
+				},
+			})
+			ctx := testContext(t)
+
+			resp, err := h.SendEvent(ctx, testEvent())
+			if err != nil {
+				t.Fatalf("SendEvent returned error: %v", err)
+			}
+
+			record := awaitStatus(t, h, resp.EventID, tt.want)
+			if tt.want == domain.StatusDelivered {
+				return
+			}
+			if record.Error == "" {
+				t.Errorf("record error is empty, want the TLS failure")
+			}
+			if count := h.External.Journal.Count(server.JournalFilter{}); count != 0 {
+				t.Errorf("journal entries = %d, want 0", count)
+			}
+		})
+	}
+}
+
+func TestExternalTLSReloadsCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	h := harness.Start(t, harness.Options{
+		TLS: &harness.TLSOptions{External: pki.external(10 * time.Millisecond)},
+	})
+
+	clientCert, err := tls.LoadX509KeyPair(pki.clientCert, pki.clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := tlsconfig.LoadCertPool(pki.ca.CertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint, err := url.Parse(h.ExternalURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial := func() (serial string) {
+		t.Helper()
+
+		conn, err := tls.Dial("tcp", endpoint.Host, &tls.Config{
+			RootCAs:      pool,
+			ServerName:   "external.test",
+			Certificates: []tls.Certificate{clientCert},
+		})
+		if err != nil {
+			t.Fatalf("handshake failed: %v", err)
+		}
+		defer conn.Close()
+
+		serial = conn.ConnectionState().PeerCertificates[0].SerialNumber.String()
+		return
+	}
+
+	before := serial()
+
+	_, _, err = pki.ca.Issue("external", "external.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for serial() == before {
+		if time.Now().After(deadline) {
+			t.Fatalf("server certificate serial is still %s, want the reissued certificate", before)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type AlertAction string
+
+const (
+	ActionTrigger     AlertAction = "trigger"
+	ActionAcknowledge AlertAction = "acknowledge"
+	ActionResolve     AlertAction = "resolve"
+)
+
+type AlertState string
+
+const (
+	AlertTriggered    AlertState = "triggered"
+	AlertAcknowledged AlertState = "acknowledged"
+)
+
+type Alert struct {
+	DedupKey        string                 `json:"dedup_key"`
+	State           AlertState             `json:"state"`
+	Source          string                 `json:"source"`
+	EventType       string                 `json:"event_type"`
+	Priority        Priority               `json:"priority"`
+	Message         string                 `json:"message"`
+	Destination     string                 `json:"destination"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	FirstEventID    string                 `json:"first_event_id"`
+	LastEventID     string                 `json:"last_event_id"`
+	TriggerCount    int                    `json:"trigger_count"`
+	TriggeredAt     time.Time              `json:"triggered_at"`
+	LastTriggeredAt time.Time              `json:"last_triggered_at"`
+	AcknowledgedAt  *time.Time             `json:"acknowledged_at,omitempty"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+func (a Alert) Event() (event Event) {
+	event = Event{
+		ID:          a.LastEventID,
+		Source:      a.Source,
+		EventType:   a.EventType,
+		Priority:    a.Priority,
+		Message:     a.Message,
+		Destination: a.Destination,
+		Action:      ActionTrigger,
+		DedupKey:    a.DedupKey,
+		Metadata:    a.Metadata,
+	}
+	return
+}
+
+func ParseAlertAction(name string) (action AlertAction, err error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "trigger":
+		action = ActionTrigger
+	case "acknowledge", "ack":
+		action = ActionAcknowledge
+	case "resolve":
+		action = ActionResolve
+	default:
+		err = fmt.Errorf("unknown action: %s", name)
+	}
+	return
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() (data []byte, err error) {
+	data, err = json.Marshal(time.Duration(d).String())
+	return
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) (err error) {
+	var raw string
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		err = fmt.Errorf("duration must be a string such as \"15m\": %w", err)
+		return
+	}
+
+	var parsed time.Duration
+	parsed, err = time.ParseDuration(raw)
+	if err != nil {
+		return
+	}
+	*d = Duration(parsed)
+	return
+}
@@ -1,9 +1,14 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+var ErrInvalidEvent = errors.New("invalid event")
+
 type Priority int
 
 const (
@@ -21,15 +26,64 @@ type Event struct {
 	Message       string
 	Timestamp     time.Time
 	CorrelationID string
+	Destination   string
+	PartitionKey  string
+	ReplayOf      string
+	ReplayJobID   string
+	Action        AlertAction
+	DedupKey      string
 	Metadata      map[string]interface{}
 }
 
+const DefaultDestination = "default"
+
+type Destination struct {
+	Name string
+	URL  string
+}
+
 type IncomingEvent struct {
-	Source    string                 `json:"source" binding:"required"`
-	EventType string                 `json:"event_type" binding:"required"`
-	Severity  string                 `json:"severity" binding:"required"`
-	Message   string                 `json:"message" binding:"required"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	Source       string                 `json:"source" binding:"required"`
+	EventType    string                 `json:"event_type" binding:"required"`
+	Severity     string                 `json:"severity" binding:"required"`
+	Message      string                 `json:"message" binding:"required"`
+	Metadata     map[string]interface{} `json:"metadata"`
+	Destination  string                 `json:"destination"`
+	PartitionKey string                 `json:"partition_key"`
+	Action       string                 `json:"action"`
+	DedupKey     string                 `json:"dedup_key"`
+}
+
+func (e IncomingEvent) Validate() (err error) {
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"source", e.Source},
+		{"event_type", e.EventType},
+		{"severity", e.Severity},
+		{"message", e.Message},
+	}
+
+	for _, field := range required {
+		if strings.TrimSpace(field.value) == "" {
+			err = fmt.Errorf("%w: %s is required", ErrInvalidEvent, field.name)
+			return
+		}
+	}
+
+	var action AlertAction
+	action, err = ParseAlertAction(e.Action)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+		return
+	}
+
+	if action != ActionTrigger && strings.TrimSpace(e.DedupKey) == "" {
+		err = fmt.Errorf("%w: dedup_key is required for %s", ErrInvalidEvent, action)
+		return
+	}
+	return
 }
 
 type EventProcessor interface {
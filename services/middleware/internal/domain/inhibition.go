@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidInhibitRule = errors.New("invalid inhibit rule")
+
+type InhibitRule struct {
+	Name           string    `json:"name"`
+	SourceMatchers []Matcher `json:"source_matchers"`
+	TargetMatchers []Matcher `json:"target_matchers"`
+	Equal          []string  `json:"equal"`
+}
+
+func (r *InhibitRule) Validate() (err error) {
+	if r.Name == "" {
+		err = fmt.Errorf("%w: name is required", ErrInvalidInhibitRule)
+		return
+	}
+	if len(r.SourceMatchers) == 0 || len(r.TargetMatchers) == 0 {
+		err = fmt.Errorf("%w: %s: source_matchers and target_matchers are required", ErrInvalidInhibitRule, r.Name)
+		return
+	}
+
+	err = CompileMatchers(r.SourceMatchers)
+	if err == nil {
+		err = CompileMatchers(r.TargetMatchers)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: %s: %w", ErrInvalidInhibitRule, r.Name, err)
+		return
+	}
+
+	for _, field := range r.Equal {
+		err = ValidateField(field)
+		if err != nil {
+			err = fmt.Errorf("%w: %s: equal: %w", ErrInvalidInhibitRule, r.Name, err)
+			return
+		}
+	}
+	return
+}
+
+func (r InhibitRule) Inhibits(source, target Event) (inhibits bool) {
+	if source.Priority <= target.Priority {
+		return
+	}
+	if !MatchAll(r.SourceMatchers, source) || !MatchAll(r.TargetMatchers, target) {
+		return
+	}
+
+	for _, field := range r.Equal {
+		sourceValue, _ := FieldValue(source, field)
+		targetValue, _ := FieldValue(target, field)
+		if sourceValue != targetValue {
+			return
+		}
+	}
+
+	inhibits = true
+	return
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidMatcher = errors.New("invalid matcher")
+
+type MatchOperator string
+
+const (
+	MatchEqual       MatchOperator = "="
+	MatchNotEqual    MatchOperator = "!="
+	MatchRegex       MatchOperator = "=~"
+	MatchNotRegex    MatchOperator = "!~"
+	metadataFieldTag               = "metadata."
+)
+
+type Matcher struct {
+	Field    string        `json:"field"`
+	Operator MatchOperator `json:"operator,omitempty"`
+	Value    string        `json:"value"`
+
+	pattern *regexp.Regexp
+}
+
+func (m *Matcher) Compile() (err error) {
+	if m.Operator == "" {
+		m.Operator = MatchEqual
+	}
+
+	err = ValidateField(m.Field)
+	if err != nil {
+		return
+	}
+
+	switch m.Operator {
+	case MatchEqual, MatchNotEqual:
+	case MatchRegex, MatchNotRegex:
+		m.pattern, err = regexp.Compile("^(?:" + m.Value + ")$")
+		if err != nil {
+			err = fmt.Errorf("%w: %s: %w", ErrInvalidMatcher, m.Field, err)
+			return
+		}
+	default:
+		err = fmt.Errorf("%w: unknown operator %q", ErrInvalidMatcher, m.Operator)
+	}
+	return
+}
+
+func (m Matcher) Matches(event Event) (matches bool) {
+	value, present := FieldValue(event, m.Field)
+
+	switch m.Operator {
+	case MatchNotEqual:
+		matches = !present || value != m.Value
+	case MatchRegex:
+		matches = present && m.pattern != nil && m.pattern.MatchString(value)
+	case MatchNotRegex:
+		matches = !present || m.pattern == nil || !m.pattern.MatchString(value)
+	default:
+		matches = present && value == m.Value
+	}
+	return
+}
+
+func CompileMatchers(matchers []Matcher) (err error) {
+	for i := range matchers {
+		err = matchers[i].Compile()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func MatchAll(matchers []Matcher, event Event) (matches bool) {
+	for _, matcher := range matchers {
+		if !matcher.Matches(event) {
+			return
+		}
+	}
+	matches = true
+	return
+}
+
+func ValidateField(field string) (err error) {
+	switch {
+	case field == "source", field == "event_type", field == "priority":
+	case strings.HasPrefix(field, metadataFieldTag) && len(field) > len(metadataFieldTag):
+	default:
+		err = fmt.Errorf("%w: unknown field %q", ErrInvalidMatcher, field)
+	}
+	return
+}
+
+func FieldValue(event Event, field string) (value string, present bool) {
+	switch field {
+	case "source":
+		value, present = event.Source, true
+	case "event_type":
+		value, present = event.EventType, true
+	case "priority":
+		value, present = event.Priority.String(), true
+	default:
+		var raw interface{}
+		raw, present = event.Metadata[strings.TrimPrefix(field, metadataFieldTag)]
+		if present {
+			value = fmt.Sprint(raw)
+		}
+	}
+	return
+}
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type DeliveryStatus string
+
+const (
+	StatusAccepted   DeliveryStatus = "accepted"
+	StatusRejected   DeliveryStatus = "rejected"
+	StatusDelivered  DeliveryStatus = "delivered"
+	StatusFailed     DeliveryStatus = "failed"
+	StatusSuppressed DeliveryStatus = "suppressed"
+	StatusDigested   DeliveryStatus = "digested"
+	StatusDropped    DeliveryStatus = "dropped"
+)
+
+type EventRecord struct {
+	ID            string                 `json:"id"`
+	Source        string                 `json:"source"`
+	EventType     string                 `json:"event_type"`
+	Priority      Priority               `json:"priority"`
+	Message       string                 `json:"message"`
+	Timestamp     time.Time              `json:"timestamp"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Destination   string                 `json:"destination"`
+	PartitionKey  string                 `json:"partition_key,omitempty"`
+	ReplayOf      string                 `json:"replay_of,omitempty"`
+	ReplayJobID   string                 `json:"replay_job_id,omitempty"`
+	Action        AlertAction            `json:"action,omitempty"`
+	DedupKey      string                 `json:"dedup_key,omitempty"`
+	SilenceID     string                 `json:"silence_id,omitempty"`
+	InhibitedBy   string                 `json:"inhibited_by,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	Status        DeliveryStatus         `json:"status"`
+	Attempts      int                    `json:"attempts"`
+	Error         string                 `json:"error,omitempty"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+	DeliveredAt   *time.Time             `json:"delivered_at,omitempty"`
+}
+
+type StatusUpdate struct {
+	Status    DeliveryStatus
+	Error     string
+	UpdatedAt time.Time
+}
+
+type EventQuery struct {
+	Source        string
+	EventType     string
+	Priority      *Priority
+	Status        DeliveryStatus
+	CorrelationID string
+	Destination   string
+	DedupKey      string
+	SilenceID     string
+	InhibitedBy   string
+	From          time.Time
+	To            time.Time
+	Metadata      map[string]string
+	Cursor        string
+	Limit         int
+}
+
+type EventPage struct {
+	Records    []EventRecord `json:"records"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type EventStore interface {
+	Record(ctx context.Context, record EventRecord) (err error)
+	UpdateStatus(ctx context.Context, id string, update StatusUpdate) (err error)
+	Get(ctx context.Context, id string) (record EventRecord, err error)
+	Query(ctx context.Context, query EventQuery) (page EventPage, err error)
+	Purge(ctx context.Context, before time.Time) (removed int, err error)
+	Close() (err error)
+}
+
+func NewEventRecord(event Event, status DeliveryStatus) (record EventRecord) {
+	record = EventRecord{
+		ID:            event.ID,
+		Source:        event.Source,
+		EventType:     event.EventType,
+		Priority:      event.Priority,
+		Message:       event.Message,
+		Timestamp:     event.Timestamp,
+		CorrelationID: event.CorrelationID,
+		Destination:   event.Destination,
+		PartitionKey:  event.PartitionKey,
+		ReplayOf:      event.ReplayOf,
+		ReplayJobID:   event.ReplayJobID,
+		Action:        event.Action,
+		DedupKey:      event.DedupKey,
+		Metadata:      event.Metadata,
+		Status:        status,
+		UpdatedAt:     event.Timestamp,
+	}
+	return
+}
+
+func (r EventRecord) Event() (event Event) {
+	event = Event{
+		ID:            r.ID,
+		Source:        r.Source,
+		EventType:     r.EventType,
+		Priority:      r.Priority,
+		Message:       r.Message,
+		Timestamp:     r.Timestamp,
+		CorrelationID: r.CorrelationID,
+		Destination:   r.Destination,
+		PartitionKey:  r.PartitionKey,
+		ReplayOf:      r.ReplayOf,
+		ReplayJobID:   r.ReplayJobID,
+		Action:        r.Action,
+		DedupKey:      r.DedupKey,
+		Metadata:      r.Metadata,
+	}
+	return
+}
+
+func (r *EventRecord) Apply(update StatusUpdate) {
+	r.Status = update.Status
+	r.Error = update.Error
+	r.UpdatedAt = update.UpdatedAt
+
+	switch update.Status {
+	case StatusDelivered:
+		r.Attempts++
+		deliveredAt := update.UpdatedAt
+		r.DeliveredAt = &deliveredAt
+	case StatusFailed:
+		r.Attempts++
+	}
+}
+
+func (q EventQuery) Matches(record EventRecord) (matches bool) {
+	switch {
+	case q.Source != "" && record.Source != q.Source:
+		return
+	case q.EventType != "" && record.EventType != q.EventType:
+		return
+	case q.Priority != nil && record.Priority != *q.Priority:
+		return
+	case q.Status != "" && record.Status != q.Status:
+		return
+	case q.CorrelationID != "" && record.CorrelationID != q.CorrelationID:
+		return
+	case q.Destination != "" && record.Destination != q.Destination:
+		return
+	case q.DedupKey != "" && record.DedupKey != q.DedupKey:
+		return
+	case q.SilenceID != "" && record.SilenceID != q.SilenceID:
+		return
+	case q.InhibitedBy != "" && record.InhibitedBy != q.InhibitedBy:
+		return
+	case !q.From.IsZero() && record.Timestamp.Before(q.From):
+		return
+	case !q.To.IsZero() && !record.Timestamp.Before(q.To):
+		return
+	}
+
+	for key, want := range q.Metadata {
+		value, ok := record.Metadata[key]
+		if !ok {
+			return
+		}
+		if want != "" && fmt.Sprint(value) != want {
+			return
+		}
+	}
+
+	matches = true
+	return
+}
+
+func (p Priority) String() (name string) {
+	switch p {
+	case PriorityCritical:
+		name = "critical"
+	case PriorityHigh:
+		name = "high"
+	case PriorityMedium:
+		name = "medium"
+	case PriorityLow:
+		name = "low"
+	default:
+		name = "unknown"
+	}
+	return
+}
+
+func ParsePriority(name string) (priority Priority, err error) {
+	switch strings.ToLower(name) {
+	case "critical":
+		priority = PriorityCritical
+	case "high":
+		priority = PriorityHigh
+	case "medium":
+		priority = PriorityMedium
+	case "low":
+		priority = PriorityLow
+	default:
+		err = fmt.Errorf("unknown priority: %s", name)
+	}
+	return
+}
+
+func (p Priority) MarshalText() (text []byte, err error) {
+	text = []byte(p.String())
+	return
+}
+
+func (p *Priority) UnmarshalText(text []byte) (err error) {
+	*p, err = ParsePriority(string(text))
+	return
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidRoute = errors.New("invalid routing config")
+
+type Route struct {
+	Name        string      `json:"name"`
+	Matchers    []Matcher   `json:"matchers,omitempty"`
+	Destination string      `json:"destination,omitempty"`
+	Escalation  string      `json:"escalation,omitempty"`
+	Digest      *Digest     `json:"digest,omitempty"`
+	Transforms  []Transform `json:"transforms,omitempty"`
+}
+
+type Digest struct {
+	Schedule    string `json:"schedule"`
+	Timezone    string `json:"timezone,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Template    string `json:"template,omitempty"`
+	MaxSamples  int    `json:"max_samples,omitempty"`
+}
+
+type EscalationTier struct {
+	Destinations  []string `json:"destinations"`
+	EscalateAfter Duration `json:"escalate_after,omitempty"`
+}
+
+type EscalationPolicy struct {
+	Name           string           `json:"name"`
+	Tiers          []EscalationTier `json:"tiers"`
+	RepeatInterval Duration         `json:"repeat_interval,omitempty"`
+	MaxRepeats     int              `json:"max_repeats,omitempty"`
+}
+
+type RoutingConfig struct {
+	Routes             []Route            `json:"routes"`
+	EscalationPolicies []EscalationPolicy `json:"escalation_policies,omitempty"`
+}
+
+type Escalation struct {
+	DedupKey       string      `json:"dedup_key"`
+	Policy         string      `json:"policy"`
+	Route          string      `json:"route"`
+	Tier           int         `json:"tier"`
+	Repeats        int         `json:"repeats"`
+	Notified       []string    `json:"notified"`
+	Event          EventRecord `json:"event"`
+	StartedAt      time.Time   `json:"started_at"`
+	NotifiedAt     time.Time   `json:"notified_at"`
+	NextAt         *time.Time  `json:"next_at,omitempty"`
+	AcknowledgedAt *time.Time  `json:"acknowledged_at,omitempty"`
+}
+
+type EscalationStore interface {
+	Save(ctx context.Context, escalation Escalation) (err error)
+	Delete(ctx context.Context, dedupKey string) (err error)
+	List(ctx context.Context) (escalations []Escalation, err error)
+	Close() (err error)
+}
+
+func (r *Route) Validate() (err error) {
+	if r.Name == "" {
+		err = fmt.Errorf("%w: route name is required", ErrInvalidRoute)
+		return
+	}
+
+	err = CompileMatchers(r.Matchers)
+	if err != nil {
+		err = fmt.Errorf("%w: route %s: %w", ErrInvalidRoute, r.Name, err)
+		return
+	}
+
+	if r.Digest != nil && r.Escalation != "" {
+		err = fmt.Errorf("%w: route %s cannot use both digest and escalation", ErrInvalidRoute, r.Name)
+		return
+	}
+
+	names := make(map[string]bool, len(r.Transforms))
+	for i := range r.Transforms {
+		err = r.Transforms[i].Validate()
+		if err != nil {
+			err = fmt.Errorf("%w: route %s: %w", ErrInvalidRoute, r.Name, err)
+			return
+		}
+		if names[r.Transforms[i].Name] {
+			err = fmt.Errorf("%w: route %s: duplicate transform %s", ErrInvalidRoute, r.Name, r.Transforms[i].Name)
+			return
+		}
+		names[r.Transforms[i].Name] = true
+	}
+	return
+}
+
+func (r Route) Matches(event Event) (matches bool) {
+	matches = MatchAll(r.Matchers, event)
+	return
+}
+
+func (p EscalationPolicy) Validate() (err error) {
+	switch {
+	case p.Name == "":
+		err = fmt.Errorf("%w: escalation policy name is required", ErrInvalidRoute)
+	case len(p.Tiers) == 0:
+		err = fmt.Errorf("%w: escalation policy %s has no tiers", ErrInvalidRoute, p.Name)
+	case p.RepeatInterval < 0 || p.MaxRepeats < 0:
+		err = fmt.Errorf("%w: escalation policy %s: repeat_interval and max_repeats must not be negative", ErrInvalidRoute, p.Name)
+	}
+	if err != nil {
+		return
+	}
+
+	for i, tier := range p.Tiers {
+		if len(tier.Destinations) == 0 {
+			err = fmt.Errorf("%w: escalation policy %s tier %d has no destinations", ErrInvalidRoute, p.Name, i+1)
+			return
+		}
+		if i < len(p.Tiers)-1 && tier.EscalateAfter <= 0 {
+			err = fmt.Errorf("%w: escalation policy %s tier %d needs escalate_after", ErrInvalidRoute, p.Name, i+1)
+			return
+		}
+	}
+	return
+}
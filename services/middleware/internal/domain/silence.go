@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSilence = errors.New("invalid silence")
+
+type SilenceState string
+
+const (
+	SilencePending SilenceState = "pending"
+	SilenceActive  SilenceState = "active"
+	SilenceExpired SilenceState = "expired"
+)
+
+type Silence struct {
+	ID        string    `json:"id"`
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedBy string    `json:"created_by"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SilenceStore interface {
+	Save(ctx context.Context, silence Silence) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	List(ctx context.Context) (silences []Silence, err error)
+	Close() (err error)
+}
+
+func (s *Silence) Validate() (err error) {
+	if len(s.Matchers) == 0 {
+		err = fmt.Errorf("%w: at least one matcher is required", ErrInvalidSilence)
+		return
+	}
+	if s.EndsAt.IsZero() || !s.EndsAt.After(s.StartsAt) {
+		err = fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidSilence)
+		return
+	}
+	if strings.TrimSpace(s.CreatedBy) == "" {
+		err = fmt.Errorf("%w: created_by is required", ErrInvalidSilence)
+		return
+	}
+	if strings.TrimSpace(s.Comment) == "" {
+		err = fmt.Errorf("%w: comment is required", ErrInvalidSilence)
+		return
+	}
+
+	err = CompileMatchers(s.Matchers)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidSilence, err)
+	}
+	return
+}
+
+func (s Silence) State(now time.Time) (state SilenceState) {
+	switch {
+	case now.Before(s.StartsAt):
+		state = SilencePending
+	case now.Before(s.EndsAt):
+		state = SilenceActive
+	default:
+		state = SilenceExpired
+	}
+	return
+}
+
+func (s Silence) Matches(event Event) (matches bool) {
+	matches = MatchAll(s.Matchers, event)
+	return
+}
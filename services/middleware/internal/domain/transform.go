@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Transform struct {
+	Name string            `json:"name"`
+	When string            `json:"when,omitempty"`
+	Drop bool              `json:"drop,omitempty"`
+	Keep bool              `json:"keep,omitempty"`
+	Set  map[string]string `json:"set,omitempty"`
+}
+
+func (t *Transform) Validate() (err error) {
+	if t.Name == "" {
+		err = fmt.Errorf("name is required")
+		return
+	}
+	if t.Drop && t.Keep {
+		err = fmt.Errorf("transform %s cannot use both drop and keep", t.Name)
+		return
+	}
+	if t.Keep && t.When == "" {
+		err = fmt.Errorf("transform %s: keep requires a when expression", t.Name)
+		return
+	}
+	if t.Drop && len(t.Set) > 0 {
+		err = fmt.Errorf("transform %s cannot use both drop and set", t.Name)
+		return
+	}
+	if !t.Drop && !t.Keep && len(t.Set) == 0 {
+		err = fmt.Errorf("transform %s needs drop, keep or set", t.Name)
+		return
+	}
+
+	for field, expression := range t.Set {
+		err = ValidateTransformField(field)
+		if err != nil {
+			err = fmt.Errorf("transform %s: %w", t.Name, err)
+			return
+		}
+		if strings.TrimSpace(expression) == "" {
+			err = fmt.Errorf("transform %s: expression for %s is empty", t.Name, field)
+			return
+		}
+	}
+	return
+}
+
+func ValidateTransformField(field string) (err error) {
+	switch {
+	case field == "source", field == "event_type", field == "priority", field == "message", field == "destination":
+	case strings.HasPrefix(field, metadataFieldTag) && len(field) > len(metadataFieldTag):
+	default:
+		err = fmt.Errorf("field %q cannot be set", field)
+	}
+	return
+}
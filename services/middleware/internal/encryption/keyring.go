@@ -0,0 +1,118 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/config"
+)
+
+var ErrInvalidKeyring = errors.New("invalid keyring")
+
+const (
+	MasterKeySize          = 32
+	DefaultDataKeyRotation = 24 * time.Hour
+)
+
+type KeyringFile struct {
+	Active         string            `json:"active"`
+	Keys           map[string]string `json:"keys"`
+	AllowPlaintext bool              `json:"allow_plaintext,omitempty"`
+}
+
+type Keyring struct {
+	active          string
+	keys            map[string][]byte
+	dataKeyRotation time.Duration
+	allowPlaintext  bool
+}
+
+func NewKeyring(file KeyringFile, dataKeyRotation time.Duration) (keyring *Keyring, err error) {
+	if len(file.Keys) == 0 {
+		err = fmt.Errorf("%w: no master keys", ErrInvalidKeyring)
+		return
+	}
+
+	active := file.Active
+	if active == "" && len(file.Keys) == 1 {
+		for id := range file.Keys {
+			active = id
+		}
+	}
+	if _, ok := file.Keys[active]; !ok {
+		err = fmt.Errorf("%w: active master key %q is not in the keyring", ErrInvalidKeyring, active)
+		return
+	}
+
+	if dataKeyRotation <= 0 {
+		dataKeyRotation = DefaultDataKeyRotation
+	}
+
+	keyring = &Keyring{
+		active:          active,
+		keys:            make(map[string][]byte, len(file.Keys)),
+		dataKeyRotation: dataKeyRotation,
+		allowPlaintext:  file.AllowPlaintext,
+	}
+
+	for id, encoded := range file.Keys {
+		var key []byte
+		key, err = base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != MasterKeySize {
+			keyring = nil
+			err = fmt.Errorf("%w: master key %s must be %d bytes, base64 encoded", ErrInvalidKeyring, id, MasterKeySize)
+			return
+		}
+		keyring.keys[id] = key
+	}
+	return
+}
+
+func KeyringFromEnv() (keyring *Keyring, err error) {
+	var file KeyringFile
+	if path := config.GetEnv("STORAGE_KEYRING_FILE", ""); path != "" {
+		err = config.LoadJSONFile(path, &file)
+		if err != nil {
+			return
+		}
+	} else {
+		file.Keys = config.GetEnvMap("STORAGE_MASTER_KEYS")
+		file.Active = config.GetEnv("STORAGE_ACTIVE_MASTER_KEY", "")
+	}
+
+	if len(file.Keys) == 0 {
+		return
+	}
+	if config.GetEnvBool("STORAGE_ALLOW_PLAINTEXT", false) {
+		file.AllowPlaintext = true
+	}
+
+	keyring, err = NewKeyring(file, config.GetEnvDuration("STORAGE_DATA_KEY_ROTATION", DefaultDataKeyRotation))
+	return
+}
+
+func (k *Keyring) Active() (id string) {
+	id = k.active
+	return
+}
+
+func (k *Keyring) AllowsPlaintext() (allowed bool) {
+	allowed = k.allowPlaintext
+	return
+}
+
+func (k *Keyring) IDs() (ids []string) {
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return
+}
+
+func (k *Keyring) masterKey(id string) (key []byte, ok bool) {
+	key, ok = k.keys[id]
+	return
+}
@@ -0,0 +1,327 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	DefaultReencryptInterval = time.Hour
+	reencryptBatchSize       = 500
+)
+
+type ReencryptStats struct {
+	Reencrypted int       `json:"reencrypted"`
+	Encrypted   int       `json:"encrypted"`
+	RetiredKeys int       `json:"retired_keys"`
+	Skipped     int       `json:"skipped"`
+	Failures    []Failure `json:"failures,omitempty"`
+}
+
+type Failure struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	Error  string `json:"error"`
+}
+
+type VerifyReport struct {
+	Values     int            `json:"values"`
+	Plaintext  int            `json:"plaintext"`
+	Verified   int            `json:"verified"`
+	DataKeys   int            `json:"data_keys"`
+	MasterKeys map[string]int `json:"master_keys"`
+	Failures   []Failure      `json:"failures,omitempty"`
+}
+
+type Reencrypter interface {
+	Reencrypt(ctx context.Context) (stats ReencryptStats, err error)
+}
+
+type RotationLogger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+func (s *Sealer) Reencrypt(ctx context.Context, db *bolt.DB, buckets ...[]byte) (stats ReencryptStats, err error) {
+	if s == nil {
+		return
+	}
+
+	started := time.Now()
+	referenced := make(map[string]bool)
+	for _, bucket := range buckets {
+		err = s.reencryptBucket(ctx, db, bucket, referenced, &stats)
+		if err != nil {
+			return
+		}
+	}
+
+	err = db.Update(func(tx *bolt.Tx) (err error) {
+		keys := tx.Bucket(dataKeysBucket)
+		if keys == nil {
+			return
+		}
+
+		var retired [][]byte
+		err = keys.ForEach(func(id, value []byte) (err error) {
+			var key DataKey
+			err = json.Unmarshal(value, &key)
+			if err != nil {
+				return
+			}
+			if !referenced[key.ID] && s.retirable(key, started) {
+				retired = append(retired, bytes.Clone(id))
+			}
+			return
+		})
+		if err != nil {
+			return
+		}
+
+		for _, id := range retired {
+			err = keys.Delete(id)
+			if err != nil {
+				return
+			}
+		}
+		stats.RetiredKeys = len(retired)
+		return
+	})
+	return
+}
+
+func (s *Sealer) reencryptBucket(ctx context.Context, db *bolt.DB, bucket []byte, referenced map[string]bool, stats *ReencryptStats) (err error) {
+	var after []byte
+	for done := false; !done; {
+		err = ctx.Err()
+		if err != nil {
+			return
+		}
+
+		err = db.Update(func(tx *bolt.Tx) (err error) {
+			b := tx.Bucket(bucket)
+			if b == nil {
+				done = true
+				return
+			}
+
+			cursor := b.Cursor()
+			var key, value []byte
+			if after == nil {
+				key, value = cursor.First()
+			} else {
+				key, value = cursor.Seek(after)
+				if bytes.Equal(key, after) {
+					key, value = cursor.Next()
+				}
+			}
+
+			type rewrite struct {
+				key   []byte
+				value []byte
+			}
+			var rewrites []rewrite
+
+			for n := 0; key != nil && n < reencryptBatchSize; key, value = cursor.Next() {
+				n++
+				after = bytes.Clone(key)
+				if value == nil {
+					continue
+				}
+
+				plaintext := value
+				sealed := IsSealed(value)
+				if sealed {
+					id, stale, openErr := s.stale(tx, value)
+					if openErr != nil {
+						s.skip(bucket, key, value, openErr, referenced, stats)
+						continue
+					}
+					if !stale {
+						referenced[id] = true
+						continue
+					}
+
+					plaintext, openErr = s.Open(tx, bucket, key, value)
+					if openErr != nil {
+						s.skip(bucket, key, value, openErr, referenced, stats)
+						continue
+					}
+				}
+
+				var resealed []byte
+				resealed, err = s.Seal(tx, bucket, key, plaintext)
+				if err != nil {
+					return
+				}
+
+				rewrites = append(rewrites, rewrite{key: bytes.Clone(key), value: resealed})
+				if sealed {
+					stats.Reencrypted++
+				} else {
+					stats.Encrypted++
+				}
+			}
+			done = key == nil
+
+			for _, r := range rewrites {
+				err = b.Put(r.key, r.value)
+				if err != nil {
+					return
+				}
+
+				var id string
+				id, err = sealedKeyID(r.value)
+				if err != nil {
+					return
+				}
+				referenced[id] = true
+			}
+			return
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to re-encrypt bucket %s: %w", bucket, err)
+			return
+		}
+	}
+	return
+}
+
+func (s *Sealer) skip(bucket, key, value []byte, err error, referenced map[string]bool, stats *ReencryptStats) {
+	if id, idErr := sealedKeyID(value); idErr == nil {
+		referenced[id] = true
+	}
+
+	stats.Skipped++
+	stats.Failures = append(stats.Failures, Failure{Bucket: string(bucket), Key: fmt.Sprintf("%x", key), Error: err.Error()})
+}
+
+func (s *Sealer) retirable(key DataKey, started time.Time) (retirable bool) {
+	retirable = key.MasterKeyID != s.keyring.Active() || !key.CreatedAt.Add(s.keyring.dataKeyRotation).After(started)
+	return
+}
+
+func (s *Sealer) stale(tx *bolt.Tx, value []byte) (id string, stale bool, err error) {
+	id, err = sealedKeyID(value)
+	if err != nil {
+		return
+	}
+
+	var unwrapped unwrappedKey
+	unwrapped, err = s.dataKey(tx, id)
+	if err != nil {
+		return
+	}
+
+	stale = unwrapped.masterKeyID != s.keyring.Active()
+	return
+}
+
+func (s *Sealer) Verify(ctx context.Context, db *bolt.DB, buckets ...[]byte) (report VerifyReport, err error) {
+	report.MasterKeys = make(map[string]int)
+
+	err = db.View(func(tx *bolt.Tx) (err error) {
+		if keys := tx.Bucket(dataKeysBucket); keys != nil {
+			report.DataKeys = keys.Stats().KeyN
+		}
+
+		for _, bucket := range buckets {
+			b := tx.Bucket(bucket)
+			if b == nil {
+				continue
+			}
+
+			err = b.ForEach(func(key, value []byte) (err error) {
+				err = ctx.Err()
+				if err != nil || value == nil {
+					return
+				}
+				report.Values++
+
+				if !IsSealed(value) {
+					report.Plaintext++
+					return
+				}
+
+				var plaintext []byte
+				plaintext, err = s.Open(tx, bucket, key, value)
+				if err == nil && !json.Valid(plaintext) {
+					err = errors.New("decrypted value is not valid JSON")
+				}
+				if err != nil {
+					report.Failures = append(report.Failures, Failure{Bucket: string(bucket), Key: fmt.Sprintf("%x", key), Error: err.Error()})
+					err = nil
+					return
+				}
+
+				report.Verified++
+
+				id, _ := sealedKeyID(value)
+				unwrapped, _ := s.dataKey(tx, id)
+				report.MasterKeys[unwrapped.masterKeyID]++
+				return
+			})
+			if err != nil {
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+func StartRotation(ctx context.Context, interval time.Duration, targets map[string]Reencrypter, logger RotationLogger) {
+	if interval <= 0 || len(targets) == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			RunRotation(ctx, targets, logger)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func RunRotation(ctx context.Context, targets map[string]Reencrypter, logger RotationLogger) {
+	for name, target := range targets {
+		stats, err := target.Reencrypt(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.ErrorContext(ctx, "storage re-encryption failed", "store", name, "error", err.Error())
+			}
+			continue
+		}
+
+		if stats.Skipped > 0 {
+			logger.ErrorContext(ctx, "storage values could not be decrypted and were skipped",
+				"store", name,
+				"skipped", stats.Skipped,
+				"first_key", stats.Failures[0].Bucket+"/"+stats.Failures[0].Key,
+				"error", stats.Failures[0].Error,
+			)
+		}
+		if stats.Reencrypted > 0 || stats.Encrypted > 0 || stats.RetiredKeys > 0 {
+			logger.InfoContext(ctx, "storage re-encrypted",
+				"store", name,
+				"reencrypted", stats.Reencrypted,
+				"encrypted", stats.Encrypted,
+				"retired_keys", stats.RetiredKeys,
+			)
+		}
+	}
+}
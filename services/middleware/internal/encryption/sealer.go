@@ -0,0 +1,377 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	ErrDecryptFailed  = errors.New("failed to decrypt stored value")
+	ErrUnknownDataKey = errors.New("unknown data key")
+	ErrPlaintext      = errors.New("stored value is not encrypted")
+)
+
+var (
+	dataKeysBucket = []byte("_data_keys")
+	sealedMagic    = []byte{0x00, 'E', 'N', 'C'}
+)
+
+const (
+	sealedVersion = 1
+	dataKeySize   = 32
+	dataKeyIDSize = 8
+)
+
+type DataKey struct {
+	ID          string    `json:"id"`
+	MasterKeyID string    `json:"master_key_id"`
+	Wrapped     []byte    `json:"wrapped"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type unwrappedKey struct {
+	masterKeyID string
+	aead        cipher.AEAD
+}
+
+type Sealer struct {
+	keyring *Keyring
+
+	mu      sync.Mutex
+	keys    map[string]unwrappedKey
+	current DataKey
+}
+
+func NewSealer(keyring *Keyring) (sealer *Sealer) {
+	if keyring == nil {
+		return
+	}
+
+	sealer = &Sealer{
+		keyring: keyring,
+		keys:    make(map[string]unwrappedKey),
+	}
+	return
+}
+
+func IsSealed(value []byte) (sealed bool) {
+	sealed = bytes.HasPrefix(value, sealedMagic)
+	return
+}
+
+func (s *Sealer) Seal(tx *bolt.Tx, bucket, key, plaintext []byte) (value []byte, err error) {
+	if s == nil {
+		value = plaintext
+		return
+	}
+
+	var id string
+	var aead cipher.AEAD
+	id, aead, err = s.currentKey(tx)
+	if err != nil {
+		return
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return
+	}
+
+	value = make([]byte, 0, len(sealedMagic)+2+len(id)+len(nonce)+len(plaintext)+aead.Overhead())
+	value = append(value, sealedMagic...)
+	value = append(value, sealedVersion, byte(len(id)))
+	value = append(value, id...)
+	value = append(value, nonce...)
+	value = aead.Seal(value, nonce, plaintext, associatedData(bucket, key))
+	return
+}
+
+func (s *Sealer) Open(tx *bolt.Tx, bucket, key, value []byte) (plaintext []byte, err error) {
+	if !IsSealed(value) {
+		if s != nil && !s.keyring.allowPlaintext {
+			err = fmt.Errorf("%w: allow plaintext reads until existing values are re-encrypted", ErrPlaintext)
+			return
+		}
+		plaintext = value
+		return
+	}
+	if s == nil {
+		err = fmt.Errorf("%w: value is encrypted but no master key is configured", ErrDecryptFailed)
+		return
+	}
+
+	var id string
+	var body []byte
+	id, body, err = parseSealed(value)
+	if err != nil {
+		return
+	}
+
+	var unwrapped unwrappedKey
+	unwrapped, err = s.dataKey(tx, id)
+	if err != nil {
+		return
+	}
+
+	nonceSize := unwrapped.aead.NonceSize()
+	if len(body) < nonceSize {
+		err = fmt.Errorf("%w: truncated value", ErrDecryptFailed)
+		return
+	}
+
+	plaintext, err = unwrapped.aead.Open(nil, body[:nonceSize], body[nonceSize:], associatedData(bucket, key))
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrDecryptFailed, err)
+	}
+	return
+}
+
+func (s *Sealer) currentKey(tx *bolt.Tx) (id string, aead cipher.AEAD, err error) {
+	s.mu.Lock()
+	current := s.current
+	s.mu.Unlock()
+
+	if !s.usable(current) {
+		current, err = s.findOrCreateKey(tx)
+		if err != nil {
+			return
+		}
+	}
+
+	var unwrapped unwrappedKey
+	unwrapped, err = s.dataKey(tx, current.ID)
+	if err != nil {
+		return
+	}
+
+	id, aead = current.ID, unwrapped.aead
+	return
+}
+
+func (s *Sealer) usable(key DataKey) (ok bool) {
+	ok = key.ID != "" && key.MasterKeyID == s.keyring.Active() && time.Since(key.CreatedAt) < s.keyring.dataKeyRotation
+	return
+}
+
+func (s *Sealer) findOrCreateKey(tx *bolt.Tx) (key DataKey, err error) {
+	var bucket *bolt.Bucket
+	bucket, err = tx.CreateBucketIfNotExists(dataKeysBucket)
+	if err != nil {
+		return
+	}
+
+	err = bucket.ForEach(func(_, value []byte) (err error) {
+		var candidate DataKey
+		err = json.Unmarshal(value, &candidate)
+		if err != nil {
+			return
+		}
+		if s.usable(candidate) && candidate.CreatedAt.After(key.CreatedAt) {
+			key = candidate
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	if key.ID == "" {
+		key, err = s.createKey(bucket)
+		if err != nil {
+			return
+		}
+	}
+
+	found := key
+	tx.OnCommit(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if found.CreatedAt.After(s.current.CreatedAt) || !s.usable(s.current) {
+			s.current = found
+		}
+	})
+	return
+}
+
+func (s *Sealer) createKey(bucket *bolt.Bucket) (key DataKey, err error) {
+	idBytes := make([]byte, dataKeyIDSize)
+	_, err = rand.Read(idBytes)
+	if err != nil {
+		return
+	}
+
+	plain := make([]byte, dataKeySize)
+	_, err = rand.Read(plain)
+	if err != nil {
+		return
+	}
+
+	key = DataKey{
+		ID:          hex.EncodeToString(idBytes),
+		MasterKeyID: s.keyring.Active(),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	master, _ := s.keyring.masterKey(key.MasterKeyID)
+	key.Wrapped, err = wrap(master, plain, wrapAssociatedData(key))
+	if err != nil {
+		return
+	}
+
+	var value []byte
+	value, err = json.Marshal(key)
+	if err != nil {
+		return
+	}
+	err = bucket.Put([]byte(key.ID), value)
+	return
+}
+
+func (s *Sealer) dataKey(tx *bolt.Tx, id string) (unwrapped unwrappedKey, err error) {
+	s.mu.Lock()
+	cached, ok := s.keys[id]
+	s.mu.Unlock()
+	if ok {
+		unwrapped = cached
+		return
+	}
+
+	var key DataKey
+	key, err = readDataKey(tx, id)
+	if err != nil {
+		return
+	}
+
+	master, ok := s.keyring.masterKey(key.MasterKeyID)
+	if !ok {
+		err = fmt.Errorf("%w: data key %s is wrapped by master key %s, which is not in the keyring", ErrUnknownDataKey, id, key.MasterKeyID)
+		return
+	}
+
+	var plain []byte
+	plain, err = unwrap(master, key.Wrapped, wrapAssociatedData(key))
+	if err != nil {
+		err = fmt.Errorf("%w: data key %s: %w", ErrDecryptFailed, id, err)
+		return
+	}
+
+	unwrapped.masterKeyID = key.MasterKeyID
+	unwrapped.aead, err = newAEAD(plain)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.keys[id] = unwrapped
+	s.mu.Unlock()
+	return
+}
+
+func readDataKey(tx *bolt.Tx, id string) (key DataKey, err error) {
+	bucket := tx.Bucket(dataKeysBucket)
+	if bucket == nil {
+		err = fmt.Errorf("%w: %s", ErrUnknownDataKey, id)
+		return
+	}
+
+	value := bucket.Get([]byte(id))
+	if value == nil {
+		err = fmt.Errorf("%w: %s", ErrUnknownDataKey, id)
+		return
+	}
+
+	err = json.Unmarshal(value, &key)
+	return
+}
+
+func parseSealed(value []byte) (id string, body []byte, err error) {
+	header := len(sealedMagic) + 2
+	if len(value) < header || value[len(sealedMagic)] != sealedVersion {
+		err = fmt.Errorf("%w: unsupported format", ErrDecryptFailed)
+		return
+	}
+
+	idLength := int(value[len(sealedMagic)+1])
+	if len(value) < header+idLength {
+		err = fmt.Errorf("%w: truncated value", ErrDecryptFailed)
+		return
+	}
+
+	id = string(value[header : header+idLength])
+	body = value[header+idLength:]
+	return
+}
+
+func sealedKeyID(value []byte) (id string, err error) {
+	id, _, err = parseSealed(value)
+	return
+}
+
+func associatedData(bucket, key []byte) (data []byte) {
+	data = make([]byte, 0, len(bucket)+1+len(key))
+	data = append(data, bucket...)
+	data = append(data, 0)
+	data = append(data, key...)
+	return
+}
+
+func wrapAssociatedData(key DataKey) (data []byte) {
+	data = []byte("data-key:" + key.ID + ":" + key.MasterKeyID)
+	return
+}
+
+func newAEAD(key []byte) (aead cipher.AEAD, err error) {
+	var block cipher.Block
+	block, err = aes.NewCipher(key)
+	if err != nil {
+		return
+	}
+	aead, err = cipher.NewGCM(block)
+	return
+}
+
+func wrap(master, plain, associated []byte) (wrapped []byte, err error) {
+	var aead cipher.AEAD
+	aead, err = newAEAD(master)
+	if err != nil {
+		return
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return
+	}
+
+	wrapped = aead.Seal(nonce, nonce, plain, associated)
+	return
+}
+
+func unwrap(master, wrapped, associated []byte) (plain []byte, err error) {
+	var aead cipher.AEAD
+	aead, err = newAEAD(master)
+	if err != nil {
+		return
+	}
+
+	if len(wrapped) < aead.NonceSize() {
+		err = errors.New("wrapped key is truncated")
+		return
+	}
+
+	nonceSize := aead.NonceSize()
+	plain, err = aead.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], associated)
+	return
+}
@@ -0,0 +1,365 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var testBucket = []byte("records")
+
+func newMasterKey(t *testing.T) (encoded string) {
+	t.Helper()
+
+	key := make([]byte, MasterKeySize)
+	_, err := rand.Read(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded = base64.StdEncoding.EncodeToString(key)
+	return
+}
+
+func newTestKeyring(t *testing.T, active string, keys map[string]string) (keyring *Keyring) {
+	t.Helper()
+
+	keyring, err := NewKeyring(KeyringFile{Active: active, Keys: keys}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeyring returned error: %v", err)
+	}
+	return
+}
+
+func openTestDB(t *testing.T) (db *bolt.DB) {
+	t.Helper()
+
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	err = db.Update(func(tx *bolt.Tx) (err error) {
+		_, err = tx.CreateBucketIfNotExists(testBucket)
+		return
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func put(t *testing.T, db *bolt.DB, sealer *Sealer, key, value string) {
+	t.Helper()
+
+	err := db.Update(func(tx *bolt.Tx) (err error) {
+		var sealed []byte
+		sealed, err = sealer.Seal(tx, testBucket, []byte(key), []byte(value))
+		if err != nil {
+			return
+		}
+		err = tx.Bucket(testBucket).Put([]byte(key), sealed)
+		return
+	})
+	if err != nil {
+		t.Fatalf("put %s: %v", key, err)
+	}
+}
+
+func get(db *bolt.DB, sealer *Sealer, key string) (value string, err error) {
+	err = db.View(func(tx *bolt.Tx) (err error) {
+		var plaintext []byte
+		plaintext, err = sealer.Open(tx, testBucket, []byte(key), tx.Bucket(testBucket).Get([]byte(key)))
+		value = string(plaintext)
+		return
+	})
+	return
+}
+
+func raw(t *testing.T, db *bolt.DB, key string) (value []byte) {
+	t.Helper()
+
+	db.View(func(tx *bolt.Tx) (err error) {
+		value = bytes.Clone(tx.Bucket(testBucket).Get([]byte(key)))
+		return
+	})
+	return
+}
+
+func TestNewKeyringValidation(t *testing.T) {
+	key := newMasterKey(t)
+
+	cases := map[string]KeyringFile{
+		"no keys":        {},
+		"unknown active": {Active: "b", Keys: map[string]string{"a": key}},
+		"ambiguous":      {Keys: map[string]string{"a": key, "b": key}},
+		"short key":      {Active: "a", Keys: map[string]string{"a": base64.StdEncoding.EncodeToString([]byte("short"))}},
+		"not base64":     {Active: "a", Keys: map[string]string{"a": "%%%"}},
+	}
+
+	for name, file := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewKeyring(file, 0)
+			if !errors.Is(err, ErrInvalidKeyring) {
+				t.Errorf("error = %v, want ErrInvalidKeyring", err)
+			}
+		})
+	}
+
+	keyring, err := NewKeyring(KeyringFile{Keys: map[string]string{"only": key}}, 0)
+	if err != nil || keyring.Active() != "only" {
+		t.Errorf("single key keyring: active = %v, err = %v", keyring, err)
+	}
+}
+
+func TestSealerRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	sealer := NewSealer(newTestKeyring(t, "k1", map[string]string{"k1": newMasterKey(t)}))
+
+	put(t, db, sealer, "a", `{"message":"secret payload"}`)
+
+	stored := raw(t, db, "a")
+	if !IsSealed(stored) || bytes.Contains(stored, []byte("secret payload")) {
+		t.Fatalf("value is stored in plaintext: %q", stored)
+	}
+
+	value, err := get(db, sealer, "a")
+	if err != nil || value != `{"message":"secret payload"}` {
+		t.Errorf("get = %q, %v", value, err)
+	}
+
+	value, err = get(db, NewSealer(nil), "a")
+	if !errors.Is(err, ErrDecryptFailed) {
+		t.Errorf("opening without a keyring: value %q, error %v", value, err)
+	}
+}
+
+func TestSealerPlaintext(t *testing.T) {
+	db := openTestDB(t)
+	key := newMasterKey(t)
+
+	put(t, db, nil, "legacy", `{"v":1}`)
+
+	value, err := get(db, nil, "legacy")
+	if err != nil || value != `{"v":1}` {
+		t.Errorf("get without a keyring = %q, %v", value, err)
+	}
+
+	strict := NewSealer(newTestKeyring(t, "k1", map[string]string{"k1": key}))
+	if value, err = get(db, strict, "legacy"); !errors.Is(err, ErrPlaintext) {
+		t.Errorf("get with a keyring = %q, %v, want %v", value, err, ErrPlaintext)
+	}
+
+	migrating, err := NewKeyring(KeyringFile{Active: "k1", Keys: map[string]string{"k1": key}, AllowPlaintext: true}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err = get(db, NewSealer(migrating), "legacy")
+	if err != nil || value != `{"v":1}` {
+		t.Errorf("get while migrating = %q, %v", value, err)
+	}
+
+	stats, err := strict.Reencrypt(context.Background(), db, testBucket)
+	if err != nil || stats.Encrypted != 1 {
+		t.Fatalf("Reencrypt = %+v, %v, want one value encrypted", stats, err)
+	}
+	value, err = get(db, strict, "legacy")
+	if err != nil || value != `{"v":1}` {
+		t.Errorf("get after re-encryption = %q, %v", value, err)
+	}
+}
+
+func TestSealerDetectsTampering(t *testing.T) {
+	db := openTestDB(t)
+	sealer := NewSealer(newTestKeyring(t, "k1", map[string]string{"k1": newMasterKey(t)}))
+
+	put(t, db, sealer, "a", `{"v":1}`)
+	put(t, db, sealer, "b", `{"v":2}`)
+
+	// Swapping values between keys must fail because each value is bound to its key.
+	swapped := raw(t, db, "b")
+	db.Update(func(tx *bolt.Tx) (err error) {
+		err = tx.Bucket(testBucket).Put([]byte("a"), swapped)
+		return
+	})
+	_, err := get(db, sealer, "a")
+	if !errors.Is(err, ErrDecryptFailed) {
+		t.Errorf("swapped value: error = %v, want ErrDecryptFailed", err)
+	}
+
+	flipped := raw(t, db, "b")
+	flipped[len(flipped)-1] ^= 0xff
+	db.Update(func(tx *bolt.Tx) (err error) {
+		err = tx.Bucket(testBucket).Put([]byte("b"), flipped)
+		return
+	})
+	_, err = get(db, sealer, "b")
+	if !errors.Is(err, ErrDecryptFailed) {
+		t.Errorf("flipped byte: error = %v, want ErrDecryptFailed", err)
+	}
+}
+
+func TestReencryptRetiresOldMasterKey(t *testing.T) {
+	db := openTestDB(t)
+	oldKey, newKey := newMasterKey(t), newMasterKey(t)
+
+	put(t, db, nil, "plain", `{"v":0}`)
+
+	oldSealer := NewSealer(newTestKeyring(t, "old", map[string]string{"old": oldKey}))
+	put(t, db, oldSealer, "a", `{"v":1}`)
+	put(t, db, oldSealer, "b", `{"v":2}`)
+
+	rotated := NewSealer(newTestKeyring(t, "new", map[string]string{"old": oldKey, "new": newKey}))
+	stats, err := rotated.Reencrypt(context.Background(), db, testBucket)
+	if err != nil {
+		t.Fatalf("Reencrypt returned error: %v", err)
+	}
+	if stats.Reencrypted != 2 || stats.Encrypted != 1 || stats.RetiredKeys != 1 {
+		t.Errorf("stats = %+v, want 2 re-encrypted, 1 encrypted, 1 retired key", stats)
+	}
+
+	report, err := rotated.Verify(context.Background(), db, testBucket)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if report.Verified != 3 || report.Plaintext != 0 || report.MasterKeys["new"] != 3 || report.DataKeys != 1 || len(report.Failures) != 0 {
+		t.Errorf("report = %+v", report)
+	}
+
+	onlyNew := NewSealer(newTestKeyring(t, "new", map[string]string{"new": newKey}))
+	for _, key := range []string{"plain", "a", "b"} {
+		_, err = get(db, onlyNew, key)
+		if err != nil {
+			t.Errorf("get %s after retiring the old key: %v", key, err)
+		}
+	}
+
+	stats, err = rotated.Reencrypt(context.Background(), db, testBucket)
+	if err != nil || stats.Reencrypted != 0 || stats.Encrypted != 0 || stats.RetiredKeys != 0 || stats.Skipped != 0 {
+		t.Errorf("second pass: stats = %+v, err = %v", stats, err)
+	}
+}
+
+func TestVerifyReportsFailures(t *testing.T) {
+	db := openTestDB(t)
+	sealer := NewSealer(newTestKeyring(t, "k1", map[string]string{"k1": newMasterKey(t)}))
+
+	put(t, db, sealer, "a", `{"v":1}`)
+	put(t, db, nil, "plain", `{"v":2}`)
+
+	other := NewSealer(newTestKeyring(t, "k2", map[string]string{"k2": newMasterKey(t)}))
+	report, err := other.Verify(context.Background(), db, testBucket)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if report.Values != 2 || report.Plaintext != 1 || report.Verified != 0 || len(report.Failures) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestReencryptSkipsUndecryptableValues(t *testing.T) {
+	db := openTestDB(t)
+	lostKey, oldKey, newKey := newMasterKey(t), newMasterKey(t), newMasterKey(t)
+
+	put(t, db, NewSealer(newTestKeyring(t, "lost", map[string]string{"lost": lostKey})), "a", `{"v":1}`)
+	put(t, db, NewSealer(newTestKeyring(t, "old", map[string]string{"old": oldKey})), "b", `{"v":2}`)
+
+	rotated := NewSealer(newTestKeyring(t, "new", map[string]string{"old": oldKey, "new": newKey}))
+	stats, err := rotated.Reencrypt(context.Background(), db, testBucket)
+	if err != nil {
+		t.Fatalf("Reencrypt returned error: %v", err)
+	}
+	if stats.Reencrypted != 1 || stats.Skipped != 1 || stats.RetiredKeys != 1 {
+		t.Errorf("stats = %+v, want 1 re-encrypted, 1 skipped, 1 retired key", stats)
+	}
+	if len(stats.Failures) != 1 || stats.Failures[0].Key != fmt.Sprintf("%x", "a") {
+		t.Errorf("failures = %+v, want one failure for key a", stats.Failures)
+	}
+
+	restored := NewSealer(newTestKeyring(t, "new", map[string]string{"lost": lostKey, "new": newKey}))
+	value, err := get(db, restored, "a")
+	if err != nil || value != `{"v":1}` {
+		t.Errorf("get a with the lost key restored = %q, %v, want its data key kept", value, err)
+	}
+}
+
+func TestReencryptRetiresExpiredDataKeys(t *testing.T) {
+	db := openTestDB(t)
+	keyring := newTestKeyring(t, "k1", map[string]string{"k1": newMasterKey(t)})
+
+	put(t, db, NewSealer(keyring), "a", `{"v":1}`)
+	expireDataKeys(t, db)
+
+	sealer := NewSealer(keyring)
+	put(t, db, sealer, "b", `{"v":2}`)
+
+	stats, err := sealer.Reencrypt(context.Background(), db, testBucket)
+	if err != nil {
+		t.Fatalf("Reencrypt returned error: %v", err)
+	}
+	if stats.Reencrypted != 0 || stats.RetiredKeys != 0 {
+		t.Errorf("stats = %+v, want the referenced expired key kept", stats)
+	}
+
+	put(t, db, sealer, "a", `{"v":3}`)
+	stats, err = sealer.Reencrypt(context.Background(), db, testBucket)
+	if err != nil {
+		t.Fatalf("Reencrypt returned error: %v", err)
+	}
+	if stats.RetiredKeys != 1 {
+		t.Errorf("stats = %+v, want 1 retired key", stats)
+	}
+
+	report, err := sealer.Verify(context.Background(), db, testBucket)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if report.Verified != 2 || report.DataKeys != 1 || len(report.Failures) != 0 {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func expireDataKeys(t *testing.T, db *bolt.DB) {
+	t.Helper()
+
+	err := db.Update(func(tx *bolt.Tx) (err error) {
+		keys := tx.Bucket(dataKeysBucket)
+
+		var expired []DataKey
+		err = keys.ForEach(func(_, value []byte) (err error) {
+			var key DataKey
+			err = json.Unmarshal(value, &key)
+			key.CreatedAt = key.CreatedAt.Add(-2 * time.Hour)
+			expired = append(expired, key)
+			return
+		})
+		if err != nil {
+			return
+		}
+
+		for _, key := range expired {
+			var value []byte
+			value, err = json.Marshal(key)
+			if err != nil {
+				return
+			}
+			err = keys.Put([]byte(key.ID), value)
+			if err != nil {
+				return
+			}
+		}
+		return
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,192 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var ErrInvalidConfig = errors.New("invalid enricher config")
+
+const (
+	FailOpen   = "open"
+	FailClosed = "closed"
+)
+
+type Enricher interface {
+	Name() (name string)
+	Lookup(ctx context.Context, event domain.Event) (fields map[string]interface{}, err error)
+}
+
+type EnrichmentLogger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+type Config struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	OnError   string `json:"on_error,omitempty"`
+	Overwrite bool   `json:"overwrite,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+
+	Table map[string]map[string]interface{} `json:"table,omitempty"`
+
+	Path           string          `json:"path,omitempty"`
+	Format         string          `json:"format,omitempty"`
+	KeyColumn      string          `json:"key_column,omitempty"`
+	ReloadInterval domain.Duration `json:"reload_interval,omitempty"`
+
+	Field   string `json:"field,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+
+	URL       string          `json:"url,omitempty"`
+	Timeout   domain.Duration `json:"timeout,omitempty"`
+	CacheTTL  domain.Duration `json:"cache_ttl,omitempty"`
+	CacheSize int             `json:"cache_size,omitempty"`
+	Fields    []string        `json:"fields,omitempty"`
+}
+
+type stage struct {
+	enricher  Enricher
+	failOpen  bool
+	overwrite bool
+	prefix    string
+}
+
+type Chain struct {
+	stages   []stage
+	watchers []*FileEnricher
+	logger   EnrichmentLogger
+}
+
+func NewChain(configs []Config, logger EnrichmentLogger) (chain *Chain, err error) {
+	chain = &Chain{logger: logger}
+
+	names := make(map[string]bool, len(configs))
+	for _, cfg := range configs {
+		if cfg.Name == "" {
+			chain = nil
+			err = fmt.Errorf("%w: name is required", ErrInvalidConfig)
+			return
+		}
+		if names[cfg.Name] {
+			chain = nil
+			err = fmt.Errorf("%w: duplicate enricher %s", ErrInvalidConfig, cfg.Name)
+			return
+		}
+		names[cfg.Name] = true
+
+		var s stage
+		s, err = chain.build(cfg)
+		if err != nil {
+			chain = nil
+			err = fmt.Errorf("%w: %s: %w", ErrInvalidConfig, cfg.Name, err)
+			return
+		}
+		chain.stages = append(chain.stages, s)
+	}
+	return
+}
+
+func (c *Chain) build(cfg Config) (s stage, err error) {
+	switch cfg.OnError {
+	case "", FailOpen:
+		s.failOpen = true
+	case FailClosed:
+	default:
+		err = fmt.Errorf("on_error must be %s or %s", FailOpen, FailClosed)
+		return
+	}
+	s.overwrite = cfg.Overwrite
+	s.prefix = cfg.Prefix
+
+	if cfg.Key == "" {
+		cfg.Key = "source"
+	}
+
+	switch cfg.Type {
+	case "static":
+		s.enricher, err = NewStaticEnricher(cfg.Name, cfg.Key, cfg.Table)
+	case "file":
+		var file *FileEnricher
+		file, err = NewFileEnricher(cfg, c.logger)
+		if err == nil {
+			c.watchers = append(c.watchers, file)
+			s.enricher = file
+		}
+	case "regex":
+		s.enricher, err = NewRegexEnricher(cfg.Name, cfg.Field, cfg.Pattern)
+	case "http":
+		s.enricher, err = NewHTTPEnricher(cfg)
+	default:
+		err = fmt.Errorf("unknown type %q", cfg.Type)
+	}
+	return
+}
+
+func (c *Chain) Start(ctx context.Context) {
+	for _, watcher := range c.watchers {
+		watcher.Start(ctx)
+	}
+}
+
+func (c *Chain) Enrich(ctx context.Context, event domain.Event) (enriched domain.Event, err error) {
+	enriched = event
+	if len(c.stages) == 0 {
+		return
+	}
+	enriched.Metadata = maps.Clone(event.Metadata)
+	if enriched.Metadata == nil {
+		enriched.Metadata = make(map[string]interface{})
+	}
+
+	for _, s := range c.stages {
+		var fields map[string]interface{}
+		fields, err = s.enricher.Lookup(ctx, enriched)
+		if err != nil {
+			if !s.failOpen {
+				err = fmt.Errorf("enricher %s: %w", s.enricher.Name(), err)
+				return
+			}
+
+			c.logger.ErrorContext(ctx, "enricher failed, continuing without it",
+				"enricher", s.enricher.Name(),
+				"event_id", event.ID,
+				"error", err.Error(),
+			)
+			err = nil
+			continue
+		}
+
+		for name, value := range fields {
+			key := s.prefix + name
+			if _, exists := enriched.Metadata[key]; exists && !s.overwrite {
+				continue
+			}
+			enriched.Metadata[key] = value
+		}
+	}
+	return
+}
+
+func (c *Chain) Names() (names []string) {
+	for _, s := range c.stages {
+		names = append(names, s.enricher.Name())
+	}
+	return
+}
+
+func lookupKey(event domain.Event, field string) (key string, ok bool) {
+	if field == "message" {
+		key, ok = event.Message, event.Message != ""
+		return
+	}
+
+	key, ok = domain.FieldValue(event, field)
+	return
+}
@@ -0,0 +1,159 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type testEnricher struct {
+	name   string
+	fields map[string]interface{}
+	err    error
+}
+
+func (e testEnricher) Name() (name string) {
+	name = e.name
+	return
+}
+
+func (e testEnricher) Lookup(ctx context.Context, event domain.Event) (fields map[string]interface{}, err error) {
+	fields, err = e.fields, e.err
+	return
+}
+
+func testLogger() (log *logger.Logger) {
+	log = logger.New(io.Discard, slog.LevelError)
+	return
+}
+
+func TestChainEnrich(t *testing.T) {
+	errLookup := errors.New("lookup unavailable")
+	event := domain.Event{
+		ID:       "evt-1",
+		Source:   "db",
+		Metadata: map[string]interface{}{"owner": "caller", "region": "eu"},
+	}
+
+	tests := []struct {
+		name    string
+		stages  []stage
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "keeps existing fields without overwrite",
+			stages: []stage{{enricher: testEnricher{name: "owners", fields: map[string]interface{}{"owner": "team-db", "tier": "1"}}}},
+			want:   map[string]interface{}{"owner": "caller", "region": "eu", "tier": "1"},
+		},
+		{
+			name:   "overwrite replaces existing fields",
+			stages: []stage{{enricher: testEnricher{name: "owners", fields: map[string]interface{}{"owner": "team-db"}}, overwrite: true}},
+			want:   map[string]interface{}{"owner": "team-db", "region": "eu"},
+		},
+		{
+			name:   "prefix namespaces fields",
+			stages: []stage{{enricher: testEnricher{name: "cmdb", fields: map[string]interface{}{"owner": "team-db"}}, prefix: "cmdb_"}},
+			want:   map[string]interface{}{"owner": "caller", "region": "eu", "cmdb_owner": "team-db"},
+		},
+		{
+			name: "later stages see earlier fields",
+			stages: []stage{
+				{enricher: testEnricher{name: "first", fields: map[string]interface{}{"tier": "1"}}},
+				{enricher: testEnricher{name: "second", fields: map[string]interface{}{"tier": "2"}}},
+			},
+			want: map[string]interface{}{"owner": "caller", "region": "eu", "tier": "1"},
+		},
+		{
+			name: "fail open skips the failing stage",
+			stages: []stage{
+				{enricher: testEnricher{name: "broken", err: errLookup}, failOpen: true},
+				{enricher: testEnricher{name: "owners", fields: map[string]interface{}{"tier": "1"}}},
+			},
+			want: map[string]interface{}{"owner": "caller", "region": "eu", "tier": "1"},
+		},
+		{
+			name: "fail closed stops the chain",
+			stages: []stage{
+				{enricher: testEnricher{name: "broken", err: errLookup}},
+				{enricher: testEnricher{name: "owners", fields: map[string]interface{}{"tier": "1"}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &Chain{stages: tt.stages, logger: testLogger()}
+
+			enriched, err := chain.Enrich(context.Background(), event)
+			if tt.wantErr {
+				if !errors.Is(err, errLookup) {
+					t.Errorf("Enrich error = %v, want %v", err, errLookup)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Enrich returned error: %v", err)
+			}
+
+			if len(enriched.Metadata) != len(tt.want) {
+				t.Errorf("metadata = %v, want %v", enriched.Metadata, tt.want)
+			}
+			for key, want := range tt.want {
+				if got := enriched.Metadata[key]; got != want {
+					t.Errorf("metadata %s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+
+	if len(event.Metadata) != 2 || event.Metadata["owner"] != "caller" {
+		t.Errorf("input metadata was modified: %v", event.Metadata)
+	}
+}
+
+func TestChainEnrichWithoutStages(t *testing.T) {
+	chain, err := NewChain(nil, testLogger())
+	if err != nil {
+		t.Fatalf("NewChain returned error: %v", err)
+	}
+
+	enriched, err := chain.Enrich(context.Background(), domain.Event{ID: "evt-1"})
+	if err != nil || enriched.Metadata != nil {
+		t.Errorf("Enrich = %v, %v, want the event unchanged", enriched.Metadata, err)
+	}
+}
+
+func TestNewChainRejectsInvalidConfig(t *testing.T) {
+	table := map[string]map[string]interface{}{"db": {"owner": "team-db"}}
+
+	tests := []struct {
+		name    string
+		configs []Config
+	}{
+		{name: "missing name", configs: []Config{{Type: "static", Table: table}}},
+		{name: "duplicate name", configs: []Config{{Name: "a", Type: "static", Table: table}, {Name: "a", Type: "static", Table: table}}},
+		{name: "unknown type", configs: []Config{{Name: "a", Type: "ldap"}}},
+		{name: "unknown on_error", configs: []Config{{Name: "a", Type: "static", Table: table, OnError: "ignore"}}},
+		{name: "unknown key field", configs: []Config{{Name: "a", Type: "static", Table: table, Key: "host"}}},
+		{name: "static without table", configs: []Config{{Name: "a", Type: "static"}}},
+		{name: "file without path", configs: []Config{{Name: "a", Type: "file"}}},
+		{name: "regex without named group", configs: []Config{{Name: "a", Type: "regex", Pattern: `host=\S+`}}},
+		{name: "http without placeholder", configs: []Config{{Name: "a", Type: "http", URL: "http://cmdb/owners"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := NewChain(tt.configs, testLogger())
+			if !errors.Is(err, ErrInvalidConfig) || chain != nil {
+				t.Errorf("NewChain = %v, %v, want nil and %v", chain, err, ErrInvalidConfig)
+			}
+		})
+	}
+}
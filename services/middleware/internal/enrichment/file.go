@@ -0,0 +1,236 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+const DefaultReloadInterval = 30 * time.Second
+
+type FileEnricher struct {
+	name           string
+	key            string
+	path           string
+	format         string
+	keyColumn      string
+	reloadInterval time.Duration
+	logger         EnrichmentLogger
+
+	mu      sync.RWMutex
+	table   map[string]map[string]interface{}
+	modTime time.Time
+}
+
+func NewFileEnricher(cfg Config, logger EnrichmentLogger) (enricher *FileEnricher, err error) {
+	err = domain.ValidateField(cfg.Key)
+	if err != nil {
+		return
+	}
+	if cfg.Path == "" {
+		err = fmt.Errorf("path is required")
+		return
+	}
+
+	format := cfg.Format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(cfg.Path)), ".")
+	}
+	if format != "json" && format != "csv" {
+		err = fmt.Errorf("format must be json or csv")
+		return
+	}
+
+	reloadInterval := time.Duration(cfg.ReloadInterval)
+	if reloadInterval <= 0 {
+		reloadInterval = DefaultReloadInterval
+	}
+
+	enricher = &FileEnricher{
+		name:           cfg.Name,
+		key:            cfg.Key,
+		path:           cfg.Path,
+		format:         format,
+		keyColumn:      cfg.KeyColumn,
+		reloadInterval: reloadInterval,
+		logger:         logger,
+	}
+
+	err = enricher.Reload()
+	if err != nil {
+		enricher = nil
+	}
+	return
+}
+
+func (e *FileEnricher) Name() (name string) {
+	name = e.name
+	return
+}
+
+func (e *FileEnricher) Lookup(ctx context.Context, event domain.Event) (fields map[string]interface{}, err error) {
+	key, ok := lookupKey(event, e.key)
+	if !ok {
+		return
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	fields = e.table[key]
+	return
+}
+
+func (e *FileEnricher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(e.reloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			changed, err := e.reloadIfChanged()
+			if err != nil {
+				e.logger.ErrorContext(ctx, "failed to reload enrichment table, keeping previous version",
+					"enricher", e.name,
+					"path", e.path,
+					"error", err.Error(),
+				)
+				continue
+			}
+			if changed {
+				e.logger.InfoContext(ctx, "enrichment table reloaded",
+					"enricher", e.name,
+					"path", e.path,
+					"entries", e.Len(),
+				)
+			}
+		}
+	}()
+}
+
+func (e *FileEnricher) Len() (count int) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	count = len(e.table)
+	return
+}
+
+func (e *FileEnricher) Reload() (err error) {
+	var info os.FileInfo
+	info, err = os.Stat(e.path)
+	if err != nil {
+		return
+	}
+
+	err = e.load(info.ModTime())
+	return
+}
+
+func (e *FileEnricher) reloadIfChanged() (changed bool, err error) {
+	var info os.FileInfo
+	info, err = os.Stat(e.path)
+	if err != nil {
+		return
+	}
+
+	e.mu.RLock()
+	current := e.modTime
+	e.mu.RUnlock()
+
+	if info.ModTime().Equal(current) {
+		return
+	}
+
+	err = e.load(info.ModTime())
+	changed = err == nil
+	return
+}
+
+func (e *FileEnricher) load(modTime time.Time) (err error) {
+	var file *os.File
+	file, err = os.Open(e.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	var table map[string]map[string]interface{}
+	if e.format == "csv" {
+		table, err = readCSVTable(file, e.keyColumn)
+	} else {
+		err = json.NewDecoder(file).Decode(&table)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to parse %s: %w", e.path, err)
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.table = table
+	e.modTime = modTime
+	return
+}
+
+func readCSVTable(r io.Reader, keyColumn string) (table map[string]map[string]interface{}, err error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	header, err = reader.Read()
+	if err != nil {
+		err = fmt.Errorf("failed to read header: %w", err)
+		return
+	}
+
+	keyIndex := 0
+	if keyColumn != "" {
+		keyIndex = -1
+		for i, name := range header {
+			if name == keyColumn {
+				keyIndex = i
+			}
+		}
+		if keyIndex < 0 {
+			err = fmt.Errorf("key column %q not found in header", keyColumn)
+			return
+		}
+	}
+
+	table = make(map[string]map[string]interface{})
+	for {
+		var record []string
+		record, err = reader.Read()
+		if err == io.EOF {
+			err = nil
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		row := make(map[string]interface{}, len(header)-1)
+		for i, name := range header {
+			if i != keyIndex && record[i] != "" {
+				row[name] = record[i]
+			}
+		}
+		table[record[keyIndex]] = row
+	}
+}
@@ -0,0 +1,162 @@
+package enrichment
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func writeTable(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err == nil {
+		err = os.Chtimes(path, modTime, modTime)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func owner(t *testing.T, enricher *FileEnricher, source string) (value interface{}) {
+	t.Helper()
+
+	fields, err := enricher.Lookup(context.Background(), domain.Event{Source: source})
+	if err != nil {
+		t.Fatalf("Lookup(%s) returned error: %v", source, err)
+	}
+	value = fields["owner"]
+	return
+}
+
+func TestFileEnricherFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		cfg     Config
+	}{
+		{
+			name:    "json",
+			file:    "owners.json",
+			content: `{"db": {"owner": "team-db"}}`,
+		},
+		{
+			name:    "csv keyed by first column",
+			file:    "owners.csv",
+			content: "source,owner\ndb, team-db\n",
+		},
+		{
+			name:    "csv with key column",
+			file:    "owners.csv",
+			content: "owner,host\nteam-db,db\n",
+			cfg:     Config{KeyColumn: "host"},
+		},
+		{
+			name:    "explicit format",
+			file:    "owners.txt",
+			content: `{"db": {"owner": "team-db"}}`,
+			cfg:     Config{Format: "json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Name = "owners"
+			cfg.Key = "source"
+			cfg.Path = filepath.Join(t.TempDir(), tt.file)
+			writeTable(t, cfg.Path, tt.content, time.Now())
+
+			enricher, err := NewFileEnricher(cfg, testLogger())
+			if err != nil {
+				t.Fatalf("NewFileEnricher returned error: %v", err)
+			}
+			if got := owner(t, enricher, "db"); got != "team-db" {
+				t.Errorf("owner = %v, want team-db", got)
+			}
+			if got := owner(t, enricher, "web"); got != nil {
+				t.Errorf("owner for unknown source = %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestNewFileEnricherRejectsInvalidTables(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		cfg     Config
+	}{
+		{name: "unknown format", file: "owners.yaml", content: "db: team-db"},
+		{name: "invalid json", file: "owners.json", content: `{"db":`},
+		{name: "missing key column", file: "owners.csv", content: "source,owner\ndb,team-db\n", cfg: Config{KeyColumn: "host"}},
+		{name: "missing file", file: "missing.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Name = "owners"
+			cfg.Key = "source"
+			cfg.Path = filepath.Join(dir, tt.file)
+			if tt.content != "" {
+				writeTable(t, cfg.Path, tt.content, time.Now())
+			}
+
+			enricher, err := NewFileEnricher(cfg, testLogger())
+			if err == nil || enricher != nil {
+				t.Errorf("NewFileEnricher = %v, %v, want an error", enricher, err)
+			}
+		})
+	}
+}
+
+func TestFileEnricherReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.json")
+	modTime := time.Now().Add(-time.Hour)
+	writeTable(t, path, `{"db": {"owner": "team-db"}}`, modTime)
+
+	enricher, err := NewFileEnricher(Config{
+		Name:           "owners",
+		Key:            "source",
+		Path:           path,
+		ReloadInterval: domain.Duration(10 * time.Millisecond),
+	}, testLogger())
+	if err != nil {
+		t.Fatalf("NewFileEnricher returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	enricher.Start(ctx)
+
+	modTime = modTime.Add(time.Minute)
+	writeTable(t, path, `{"db": {"owner": "team-storage"}, "web": {"owner": "team-web"}}`, modTime)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for owner(t, enricher, "db") != "team-storage" {
+		if time.Now().After(deadline) {
+			t.Fatalf("owner is still %v, want the reloaded team-storage", owner(t, enricher, "db"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if enricher.Len() != 2 {
+		t.Errorf("Len = %d, want 2", enricher.Len())
+	}
+
+	modTime = modTime.Add(time.Minute)
+	writeTable(t, path, `{"db":`, modTime)
+	time.Sleep(50 * time.Millisecond)
+
+	if got := owner(t, enricher, "web"); got != "team-web" {
+		t.Errorf("owner after a broken reload = %v, want the previous team-web", got)
+	}
+}
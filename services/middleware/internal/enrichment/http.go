@@ -0,0 +1,223 @@
+package enrichment
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+const (
+	DefaultLookupTimeout   = 2 * time.Second
+	DefaultLookupCacheTTL  = 5 * time.Minute
+	DefaultLookupCacheSize = 1000
+	maxLookupResponseSize  = 1 << 20
+	lookupKeyPlaceholder   = "{key}"
+)
+
+type cachedLookup struct {
+	key       string
+	fields    map[string]interface{}
+	expiresAt time.Time
+}
+
+type inflightLookup struct {
+	done   chan struct{}
+	fields map[string]interface{}
+	err    error
+}
+
+type HTTPEnricher struct {
+	name      string
+	key       string
+	url       string
+	fields    []string
+	cacheTTL  time.Duration
+	cacheSize int
+	client    *http.Client
+
+	mu       sync.Mutex
+	cache    map[string]*list.Element
+	lru      *list.List
+	inflight map[string]*inflightLookup
+}
+
+func NewHTTPEnricher(cfg Config) (enricher *HTTPEnricher, err error) {
+	err = domain.ValidateField(cfg.Key)
+	if err != nil {
+		return
+	}
+	if !strings.Contains(cfg.URL, lookupKeyPlaceholder) {
+		err = fmt.Errorf("url must contain the %s placeholder", lookupKeyPlaceholder)
+		return
+	}
+	_, err = url.Parse(strings.ReplaceAll(cfg.URL, lookupKeyPlaceholder, "key"))
+	if err != nil {
+		return
+	}
+
+	timeout := time.Duration(cfg.Timeout)
+	if timeout <= 0 {
+		timeout = DefaultLookupTimeout
+	}
+	cacheTTL := time.Duration(cfg.CacheTTL)
+	if cacheTTL == 0 {
+		cacheTTL = DefaultLookupCacheTTL
+	}
+	cacheSize := cfg.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = DefaultLookupCacheSize
+	}
+
+	enricher = &HTTPEnricher{
+		name:      cfg.Name,
+		key:       cfg.Key,
+		url:       cfg.URL,
+		fields:    cfg.Fields,
+		cacheTTL:  cacheTTL,
+		cacheSize: cacheSize,
+		client:    &http.Client{Timeout: timeout},
+		cache:     make(map[string]*list.Element),
+		lru:       list.New(),
+		inflight:  make(map[string]*inflightLookup),
+	}
+	return
+}
+
+func (e *HTTPEnricher) Name() (name string) {
+	name = e.name
+	return
+}
+
+func (e *HTTPEnricher) Lookup(ctx context.Context, event domain.Event) (fields map[string]interface{}, err error) {
+	key, ok := lookupKey(event, e.key)
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	if cached, hit := e.cachedLocked(key, time.Now()); hit {
+		e.mu.Unlock()
+		fields = cached
+		return
+	}
+
+	lookup, waiting := e.inflight[key]
+	if !waiting {
+		lookup = &inflightLookup{done: make(chan struct{})}
+		e.inflight[key] = lookup
+		go e.resolve(context.WithoutCancel(ctx), key, lookup)
+	}
+	e.mu.Unlock()
+
+	select {
+	case <-lookup.done:
+		fields, err = lookup.fields, lookup.err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	return
+}
+
+func (e *HTTPEnricher) resolve(ctx context.Context, key string, lookup *inflightLookup) {
+	lookup.fields, lookup.err = e.fetch(ctx, key)
+
+	e.mu.Lock()
+	delete(e.inflight, key)
+	if lookup.err == nil && e.cacheTTL > 0 {
+		e.storeLocked(key, lookup.fields, time.Now())
+	}
+	e.mu.Unlock()
+
+	close(lookup.done)
+}
+
+func (e *HTTPEnricher) cachedLocked(key string, now time.Time) (fields map[string]interface{}, hit bool) {
+	element, found := e.cache[key]
+	if !found {
+		return
+	}
+
+	entry := element.Value.(*cachedLookup)
+	if now.After(entry.expiresAt) {
+		e.lru.Remove(element)
+		delete(e.cache, key)
+		return
+	}
+
+	e.lru.MoveToFront(element)
+	fields, hit = entry.fields, true
+	return
+}
+
+func (e *HTTPEnricher) storeLocked(key string, fields map[string]interface{}, now time.Time) {
+	entry := &cachedLookup{key: key, fields: fields, expiresAt: now.Add(e.cacheTTL)}
+
+	if element, found := e.cache[key]; found {
+		element.Value = entry
+		e.lru.MoveToFront(element)
+		return
+	}
+
+	e.cache[key] = e.lru.PushFront(entry)
+	for e.lru.Len() > e.cacheSize {
+		oldest := e.lru.Back()
+		e.lru.Remove(oldest)
+		delete(e.cache, oldest.Value.(*cachedLookup).key)
+	}
+}
+
+func (e *HTTPEnricher) fetch(ctx context.Context, key string) (fields map[string]interface{}, err error) {
+	target := strings.ReplaceAll(e.url, lookupKeyPlaceholder, url.PathEscape(key))
+
+	var req *http.Request
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var resp *http.Response
+	resp, err = e.client.Do(req)
+	if err != nil {
+		err = fmt.Errorf("lookup request failed: %w", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("lookup returned status %d", resp.StatusCode)
+		return
+	}
+
+	var body map[string]interface{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxLookupResponseSize)).Decode(&body)
+	if err != nil {
+		err = fmt.Errorf("failed to decode lookup response: %w", err)
+		return
+	}
+
+	if len(e.fields) == 0 {
+		fields = body
+		return
+	}
+
+	fields = make(map[string]interface{}, len(e.fields))
+	for _, name := range e.fields {
+		if value, ok := body[name]; ok {
+			fields[name] = value
+		}
+	}
+	return
+}
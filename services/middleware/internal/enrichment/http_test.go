@@ -0,0 +1,107 @@
+package enrichment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func newTestHTTPEnricher(t *testing.T, cfg Config, delay time.Duration) (enricher *HTTPEnricher, requests *atomic.Int64) {
+	t.Helper()
+
+	requests = &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"owner": "team-db"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg.Key = "source"
+	cfg.URL = server.URL + "/owners/{key}"
+	enricher, err := NewHTTPEnricher(cfg)
+	if err != nil {
+		t.Fatalf("NewHTTPEnricher returned error: %v", err)
+	}
+	return
+}
+
+func lookup(t *testing.T, enricher *HTTPEnricher, source string) {
+	t.Helper()
+
+	fields, err := enricher.Lookup(context.Background(), domain.Event{Source: source})
+	if err != nil {
+		t.Fatalf("Lookup(%s) returned error: %v", source, err)
+	}
+	if fields["owner"] != "team-db" {
+		t.Fatalf("Lookup(%s) = %v", source, fields)
+	}
+}
+
+func TestHTTPEnricherCoalescesConcurrentMisses(t *testing.T) {
+	enricher, requests := newTestHTTPEnricher(t, Config{}, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fields, err := enricher.Lookup(context.Background(), domain.Event{Source: "db"})
+			if err != nil || fields["owner"] != "team-db" {
+				t.Errorf("Lookup = %v, %v", fields, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestHTTPEnricherEvictsLeastRecentlyUsed(t *testing.T) {
+	enricher, requests := newTestHTTPEnricher(t, Config{CacheSize: 2}, 0)
+
+	for _, source := range []string{"a", "b", "a", "c"} {
+		lookup(t, enricher, source)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Fatalf("requests = %d, want 3", got)
+	}
+	if enricher.lru.Len() != 2 {
+		t.Errorf("cache holds %d entries, want 2", enricher.lru.Len())
+	}
+
+	lookup(t, enricher, "a")
+	if got := requests.Load(); got != 3 {
+		t.Errorf("recently used entry was evicted: requests = %d, want 3", got)
+	}
+
+	lookup(t, enricher, "b")
+	if got := requests.Load(); got != 4 {
+		t.Errorf("least recently used entry was kept: requests = %d, want 4", got)
+	}
+}
+
+func TestHTTPEnricherExpiresEntries(t *testing.T) {
+	enricher, requests := newTestHTTPEnricher(t, Config{CacheTTL: domain.Duration(10 * time.Millisecond)}, 0)
+
+	lookup(t, enricher, "db")
+	lookup(t, enricher, "db")
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("requests = %d, want 1", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	lookup(t, enricher, "db")
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d after expiry, want 2", got)
+	}
+}
@@ -0,0 +1,76 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type RegexEnricher struct {
+	name    string
+	field   string
+	pattern *regexp.Regexp
+}
+
+func NewRegexEnricher(name, field, pattern string) (enricher *RegexEnricher, err error) {
+	if field == "" {
+		field = "message"
+	}
+	if field != "message" {
+		err = domain.ValidateField(field)
+		if err != nil {
+			return
+		}
+	}
+
+	var compiled *regexp.Regexp
+	compiled, err = regexp.Compile(pattern)
+	if err != nil {
+		return
+	}
+
+	named := 0
+	for _, group := range compiled.SubexpNames() {
+		if group != "" {
+			named++
+		}
+	}
+	if named == 0 {
+		err = fmt.Errorf("pattern needs at least one named group such as (?P<host>\\S+)")
+		return
+	}
+
+	enricher = &RegexEnricher{
+		name:    name,
+		field:   field,
+		pattern: compiled,
+	}
+	return
+}
+
+func (e *RegexEnricher) Name() (name string) {
+	name = e.name
+	return
+}
+
+func (e *RegexEnricher) Lookup(ctx context.Context, event domain.Event) (fields map[string]interface{}, err error) {
+	value, ok := lookupKey(event, e.field)
+	if !ok {
+		return
+	}
+
+	match := e.pattern.FindStringSubmatch(value)
+	if match == nil {
+		return
+	}
+
+	fields = make(map[string]interface{})
+	for i, group := range e.pattern.SubexpNames() {
+		if group != "" && match[i] != "" {
+			fields[group] = match[i]
+		}
+	}
+	return
+}
@@ -0,0 +1,93 @@
+package enrichment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func TestRegexEnricherLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		pattern string
+		event   domain.Event
+		want    map[string]interface{}
+	}{
+		{
+			name:    "named groups from message",
+			pattern: `host=(?P<host>\S+)(?: port=(?P<port>\d+))?`,
+			event:   domain.Event{Message: "connection refused host=db-1 port=5432"},
+			want:    map[string]interface{}{"host": "db-1", "port": "5432"},
+		},
+		{
+			name:    "empty optional group is skipped",
+			pattern: `host=(?P<host>\S+)(?: port=(?P<port>\d+))?`,
+			event:   domain.Event{Message: "connection refused host=db-1"},
+			want:    map[string]interface{}{"host": "db-1"},
+		},
+		{
+			name:    "no match",
+			pattern: `host=(?P<host>\S+)`,
+			event:   domain.Event{Message: "connection refused"},
+			want:    map[string]interface{}{},
+		},
+		{
+			name:    "metadata field",
+			field:   "metadata.path",
+			pattern: `^/(?P<service>[a-z]+)/`,
+			event:   domain.Event{Message: "host=ignored", Metadata: map[string]interface{}{"path": "/billing/invoices"}},
+			want:    map[string]interface{}{"service": "billing"},
+		},
+		{
+			name:    "missing field",
+			field:   "metadata.path",
+			pattern: `^/(?P<service>[a-z]+)/`,
+			event:   domain.Event{Message: "/billing/"},
+			want:    map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enricher, err := NewRegexEnricher("parse", tt.field, tt.pattern)
+			if err != nil {
+				t.Fatalf("NewRegexEnricher returned error: %v", err)
+			}
+
+			fields, err := enricher.Lookup(context.Background(), tt.event)
+			if err != nil {
+				t.Fatalf("Lookup returned error: %v", err)
+			}
+			if len(fields) != len(tt.want) {
+				t.Errorf("fields = %v, want %v", fields, tt.want)
+			}
+			for key, want := range tt.want {
+				if got := fields[key]; got != want {
+					t.Errorf("%s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRegexEnricherRejectsInvalidPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		pattern string
+	}{
+		{name: "invalid regex", pattern: `(?P<host>`},
+		{name: "no named group", pattern: `host=(\S+)`},
+		{name: "unknown field", field: "host", pattern: `(?P<host>\S+)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRegexEnricher("parse", tt.field, tt.pattern); err == nil {
+				t.Errorf("NewRegexEnricher returned no error")
+			}
+		})
+	}
+}
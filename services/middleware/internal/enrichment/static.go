@@ -0,0 +1,45 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type StaticEnricher struct {
+	name  string
+	key   string
+	table map[string]map[string]interface{}
+}
+
+func NewStaticEnricher(name, key string, table map[string]map[string]interface{}) (enricher *StaticEnricher, err error) {
+	err = domain.ValidateField(key)
+	if err != nil {
+		return
+	}
+	if len(table) == 0 {
+		err = fmt.Errorf("table is required")
+		return
+	}
+
+	enricher = &StaticEnricher{
+		name:  name,
+		key:   key,
+		table: table,
+	}
+	return
+}
+
+func (e *StaticEnricher) Name() (name string) {
+	name = e.name
+	return
+}
+
+func (e *StaticEnricher) Lookup(ctx context.Context, event domain.Event) (fields map[string]interface{}, err error) {
+	key, ok := lookupKey(event, e.key)
+	if ok {
+		fields = e.table[key]
+	}
+	return
+}
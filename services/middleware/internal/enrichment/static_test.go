@@ -0,0 +1,39 @@
+package enrichment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func TestStaticEnricherLookup(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		event domain.Event
+		want  interface{}
+	}{
+		{name: "source", key: "source", event: domain.Event{Source: "db"}, want: "team-db"},
+		{name: "unknown source", key: "source", event: domain.Event{Source: "web"}, want: nil},
+		{name: "metadata key", key: "metadata.host", event: domain.Event{Metadata: map[string]interface{}{"host": "db"}}, want: "team-db"},
+		{name: "missing metadata key", key: "metadata.host", event: domain.Event{Source: "db"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enricher, err := NewStaticEnricher("owners", tt.key, map[string]map[string]interface{}{"db": {"owner": "team-db"}})
+			if err != nil {
+				t.Fatalf("NewStaticEnricher returned error: %v", err)
+			}
+
+			fields, err := enricher.Lookup(context.Background(), tt.event)
+			if err != nil {
+				t.Fatalf("Lookup returned error: %v", err)
+			}
+			if got := fields["owner"]; got != tt.want {
+				t.Errorf("owner = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/correlation"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type AcknowledgeHandler struct {
+	ingestor *usecase.Ingestor
+	alerts   *usecase.AlertTracker
+	logger   HandlerLogger
+}
+
+type AcknowledgeRequest struct {
+	By   string `json:"by"`
+	Note string `json:"note"`
+}
+
+func NewAcknowledgeHandler(ingestor *usecase.Ingestor, alerts *usecase.AlertTracker, logger HandlerLogger) (handler *AcknowledgeHandler) {
+	handler = &AcknowledgeHandler{
+		ingestor: ingestor,
+		alerts:   alerts,
+		logger:   logger,
+	}
+	return
+}
+
+func (h *AcknowledgeHandler) HandleAcknowledge(c *gin.Context) {
+	var request AcknowledgeRequest
+	if c.Request.ContentLength != 0 {
+		err := c.ShouldBindJSON(&request)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+			return
+		}
+	}
+
+	key, ok := strings.CutSuffix(strings.TrimPrefix(c.Param("path"), "/"), "/acknowledge")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+
+	alert, err := h.alerts.Get(key)
+	if errors.Is(err, usecase.ErrAlertNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "acknowledged"
+	if request.By != "" {
+		message = fmt.Sprintf("acknowledged by %s", request.By)
+	}
+
+	incoming := domain.IncomingEvent{
+		Source:      alert.Source,
+		EventType:   alert.EventType,
+		Severity:    alert.Priority.String(),
+		Message:     message,
+		Destination: alert.Destination,
+		Action:      string(domain.ActionAcknowledge),
+		DedupKey:    alert.DedupKey,
+		Metadata: map[string]interface{}{
+			"acknowledged_by": request.By,
+			"note":            request.Note,
+		},
+	}
+
+	var correlationID string
+	correlationID, err = correlation.GenerateID()
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to generate correlation ID", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	ctx := correlation.WithID(c.Request.Context(), correlationID)
+
+	var event domain.Event
+	event, err = h.ingestor.Ingest(ctx, incoming, correlationID)
+	if errors.Is(err, usecase.ErrSuppressed) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to acknowledge alert", "dedup_key", alert.DedupKey, "error", err.Error())
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "service temporarily unavailable"})
+		return
+	}
+
+	h.logger.InfoContext(ctx, "alert acknowledged", "dedup_key", alert.DedupKey, "by", request.By, "event_id", event.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":         "acknowledged",
+		"dedup_key":      alert.DedupKey,
+		"event_id":       event.ID,
+		"correlation_id": correlationID,
+	})
+}
+
+func (h *AcknowledgeHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/integrations/alerts/*path", h.HandleAcknowledge)
+}
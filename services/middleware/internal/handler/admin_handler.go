@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+type AdminHandler struct {
+	queue        *repository.EventQueue
+	pool         PoolController
+	destinations *repository.DestinationRegistry
+	logger       AdminLogger
+}
+
+type AdminLogger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+	SetLevel(level slog.Level)
+	Level() (level slog.Level)
+}
+
+type logLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+func NewAdminHandler(queue *repository.EventQueue, pool PoolController, destinations *repository.DestinationRegistry, logger AdminLogger) (handler *AdminHandler) {
+	handler = &AdminHandler{
+		queue:        queue,
+		pool:         pool,
+		destinations: destinations,
+		logger:       logger,
+	}
+	return
+}
+
+func (h *AdminHandler) HandleQueueStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.queue.Stats())
+}
+
+func (h *AdminHandler) HandleWorkers(c *gin.Context) {
+	stats := h.pool.Stats()
+	c.JSON(http.StatusOK, gin.H{
+		"paused":  stats.Paused,
+		"workers": h.pool.Workers(),
+	})
+}
+
+func (h *AdminHandler) HandlePause(c *gin.Context) {
+	h.pool.Pause()
+	h.logger.InfoContext(c.Request.Context(), "event consumption paused via admin")
+	c.JSON(http.StatusOK, gin.H{"paused": true})
+}
+
+func (h *AdminHandler) HandleResume(c *gin.Context) {
+	h.pool.Resume()
+	h.logger.InfoContext(c.Request.Context(), "event consumption resumed via admin")
+	c.JSON(http.StatusOK, gin.H{"paused": false})
+}
+
+func (h *AdminHandler) HandleDrainStatus(c *gin.Context) {
+	stats := h.queue.Stats()
+	c.JSON(http.StatusOK, gin.H{
+		"draining":  stats.Draining,
+		"remaining": stats.Depth,
+		"drained":   stats.Draining && stats.Depth == 0,
+	})
+}
+
+func (h *AdminHandler) HandleStartDrain(c *gin.Context) {
+	h.queue.SetDraining(true)
+	h.pool.Resume()
+	h.logger.InfoContext(c.Request.Context(), "queue draining started via admin")
+	h.HandleDrainStatus(c)
+}
+
+func (h *AdminHandler) HandleStopDrain(c *gin.Context) {
+	h.queue.SetDraining(false)
+	h.logger.InfoContext(c.Request.Context(), "queue draining stopped via admin")
+	h.HandleDrainStatus(c)
+}
+
+func (h *AdminHandler) HandleDestinations(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"destinations": h.destinations.Statuses()})
+}
+
+func (h *AdminHandler) HandleEnableDestination(c *gin.Context) {
+	h.setDestinationEnabled(c, true)
+}
+
+func (h *AdminHandler) HandleDisableDestination(c *gin.Context) {
+	h.setDestinationEnabled(c, false)
+}
+
+func (h *AdminHandler) setDestinationEnabled(c *gin.Context, enabled bool) {
+	name := c.Param("name")
+	err := h.destinations.SetEnabled(name, enabled)
+	if errors.Is(err, repository.ErrUnknownDestination) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "destination not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "destination state changed via admin",
+		"destination", name,
+		"enabled", enabled,
+	)
+	c.JSON(http.StatusOK, gin.H{"name": name, "enabled": enabled})
+}
+
+func (h *AdminHandler) HandleGetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"level": h.logger.Level().String()})
+}
+
+func (h *AdminHandler) HandleSetLogLevel(c *gin.Context) {
+	var request logLevelRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	var level slog.Level
+	err = level.UnmarshalText([]byte(request.Level))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid log level"})
+		return
+	}
+
+	h.logger.SetLevel(level)
+	h.logger.InfoContext(c.Request.Context(), "log level changed via admin", "level", level.String())
+	c.JSON(http.StatusOK, gin.H{"level": level.String()})
+}
+
+func (h *AdminHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/queue", h.HandleQueueStats)
+	router.GET("/admin/workers", h.HandleWorkers)
+	router.POST("/admin/consumption/pause", h.HandlePause)
+	router.POST("/admin/consumption/resume", h.HandleResume)
+	router.GET("/admin/drain", h.HandleDrainStatus)
+	router.POST("/admin/drain", h.HandleStartDrain)
+	router.DELETE("/admin/drain", h.HandleStopDrain)
+	router.GET("/admin/destinations", h.HandleDestinations)
+	router.POST("/admin/destinations/:name/enable", h.HandleEnableDestination)
+	router.POST("/admin/destinations/:name/disable", h.HandleDisableDestination)
+	router.GET("/admin/log-level", h.HandleGetLogLevel)
+	router.PUT("/admin/log-level", h.HandleSetLogLevel)
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/worker"
+)
+
+func adminRouter(t *testing.T) (router *gin.Engine, queue *repository.EventQueue, pool *worker.Pool, destinations *repository.DestinationRegistry) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	queue = repository.NewEventQueue(10, 0)
+	pool, err := worker.NewPool(worker.Config{InitialWorkers: 2}, queue, nil, testLogger())
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	destinations = repository.NewDestinationRegistry([]domain.Destination{
+		{Name: domain.DefaultDestination, URL: "http://external/alerts"},
+		{Name: "chat", URL: "http://chat/hooks"},
+	})
+
+	router = gin.New()
+	NewAdminHandler(queue, pool, destinations, testLogger()).RegisterRoutes(router)
+	return
+}
+
+func TestAdminHandlerDestinations(t *testing.T) {
+	router, _, _, destinations := adminRouter(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		want    int
+		enabled bool
+	}{
+		{name: "disable", path: "/admin/destinations/chat/disable", want: http.StatusOK, enabled: false},
+		{name: "disable again", path: "/admin/destinations/chat/disable", want: http.StatusOK, enabled: false},
+		{name: "enable", path: "/admin/destinations/chat/enable", want: http.StatusOK, enabled: true},
+		{name: "disable unknown", path: "/admin/destinations/pager/disable", want: http.StatusNotFound, enabled: true},
+		{name: "enable unknown", path: "/admin/destinations/pager/enable", want: http.StatusNotFound, enabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, http.MethodPost, tt.path, "")
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+
+			_, err := destinations.Resolve("chat")
+			if enabled := err == nil; enabled != tt.enabled {
+				t.Errorf("chat enabled = %v, want %v (resolve error %v)", enabled, tt.enabled, err)
+			}
+		})
+	}
+
+	serve(router, http.MethodPost, "/admin/destinations/chat/disable", "")
+	rec := serve(router, http.MethodGet, "/admin/destinations", "")
+	var body struct {
+		Destinations []repository.DestinationStatus `json:"destinations"`
+	}
+	err := json.Unmarshal(rec.Body.Bytes(), &body)
+	if err != nil || len(body.Destinations) != 2 {
+		t.Fatalf("GET /admin/destinations = %s, err %v", rec.Body.String(), err)
+	}
+	for _, status := range body.Destinations {
+		if status.Enabled != (status.Name != "chat") {
+			t.Errorf("destination %s enabled = %v", status.Name, status.Enabled)
+		}
+	}
+}
+
+func TestAdminHandlerConsumptionAndDrain(t *testing.T) {
+	router, queue, pool, _ := adminRouter(t)
+
+	serve(router, http.MethodPost, "/admin/consumption/pause", "")
+	if !pool.Stats().Paused {
+		t.Errorf("pool is not paused after pause")
+	}
+
+	rec := serve(router, http.MethodPost, "/admin/drain", "")
+	if rec.Code != http.StatusOK || !queue.Stats().Draining || pool.Stats().Paused {
+		t.Errorf("start drain = %d %s, want draining and the pool resumed", rec.Code, rec.Body.String())
+	}
+	var drain map[string]interface{}
+	err := json.Unmarshal(rec.Body.Bytes(), &drain)
+	if err != nil || drain["draining"] != true || drain["drained"] != true {
+		t.Errorf("drain status = %s, err %v, want draining and drained", rec.Body.String(), err)
+	}
+
+	serve(router, http.MethodDelete, "/admin/drain", "")
+	if queue.Stats().Draining {
+		t.Errorf("queue is still draining after DELETE /admin/drain")
+	}
+}
+
+func TestAdminHandlerLogLevel(t *testing.T) {
+	router, _, _, _ := adminRouter(t)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "valid level", body: `{"level": "debug"}`, want: http.StatusOK},
+		{name: "unknown level", body: `{"level": "verbose"}`, want: http.StatusBadRequest},
+		{name: "missing level", body: `{}`, want: http.StatusBadRequest},
+		{name: "not JSON", body: `debug`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, http.MethodPut, "/admin/log-level", tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	rec := serve(router, http.MethodGet, "/admin/log-level", "")
+	var body map[string]string
+	err := json.Unmarshal(rec.Body.Bytes(), &body)
+	if err != nil || body["level"] != slog.LevelDebug.String() {
+		t.Errorf("log level = %s, err %v, want %s", rec.Body.String(), err, slog.LevelDebug)
+	}
+}
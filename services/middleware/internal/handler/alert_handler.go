@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type AlertHandler struct {
+	alerts    *usecase.AlertTracker
+	inhibitor *usecase.Inhibitor
+}
+
+func NewAlertHandler(alerts *usecase.AlertTracker, inhibitor *usecase.Inhibitor) (handler *AlertHandler) {
+	handler = &AlertHandler{
+		alerts:    alerts,
+		inhibitor: inhibitor,
+	}
+	return
+}
+
+func (h *AlertHandler) HandleList(c *gin.Context) {
+	state := domain.AlertState(c.Query("state"))
+	source := c.Query("source")
+
+	alerts := make([]domain.Alert, 0)
+	for _, alert := range h.alerts.Open() {
+		if state != "" && alert.State != state {
+			continue
+		}
+		if source != "" && alert.Source != source {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":  len(alerts),
+		"alerts": alerts,
+	})
+}
+
+func (h *AlertHandler) HandleGet(c *gin.Context) {
+	alert, err := h.alerts.Get(strings.TrimPrefix(c.Param("key"), "/"))
+	if errors.Is(err, usecase.ErrAlertNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+func (h *AlertHandler) HandleInhibitRules(c *gin.Context) {
+	rules := h.inhibitor.Rules()
+	c.JSON(http.StatusOK, gin.H{
+		"count": len(rules),
+		"rules": rules,
+	})
+}
+
+func (h *AlertHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/alerts", h.HandleList)
+	router.GET("/admin/alerts/*key", h.HandleGet)
+	router.GET("/admin/inhibit-rules", h.HandleInhibitRules)
+}
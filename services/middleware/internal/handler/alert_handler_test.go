@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/infrastructure"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+func testLogger() (log *logger.Logger) {
+	log = logger.New(io.Discard, slog.LevelError)
+	return
+}
+
+func testIngestor(queue *repository.EventQueue, pipeline usecase.IngestPipeline) (ingestor *usecase.Ingestor) {
+	mapper := usecase.NewEventMapper(infrastructure.NewUUIDGenerator(), infrastructure.NewSystemClock())
+	ingestor = usecase.NewIngestor(mapper, queue, nil, pipeline, testLogger())
+	return
+}
+
+func serve(router *gin.Engine, method, path, body string) (rec *httptest.ResponseRecorder) {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return
+}
+
+func TestAlertRoutesAcceptSlashedDedupKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tracker := usecase.NewAlertTracker(infrastructure.NewSystemClock(), 0)
+	tracker.Apply(domain.Event{ID: "evt-1", Source: "web", EventType: "server_down", Priority: domain.PriorityHigh, DedupKey: "prod-web-01/server_down", Action: domain.ActionTrigger})
+
+	queue := repository.NewEventQueue(10, 0)
+	router := gin.New()
+	NewAlertHandler(tracker, nil).RegisterRoutes(router)
+	NewAcknowledgeHandler(testIngestor(queue, usecase.IngestPipeline{Alerts: tracker}), tracker, testLogger()).RegisterRoutes(router)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{name: "get with slash", method: http.MethodGet, path: "/admin/alerts/prod-web-01/server_down", want: http.StatusOK},
+		{name: "get with escaped slash", method: http.MethodGet, path: "/admin/alerts/prod-web-01%2Fserver_down", want: http.StatusOK},
+		{name: "get unknown key", method: http.MethodGet, path: "/admin/alerts/prod-web-01/disk_full", want: http.StatusNotFound},
+		{name: "acknowledge unknown key", method: http.MethodPost, path: "/integrations/alerts/prod-web-01/disk_full/acknowledge", want: http.StatusNotFound},
+		{name: "acknowledge without suffix", method: http.MethodPost, path: "/integrations/alerts/prod-web-01/server_down", want: http.StatusNotFound},
+		{name: "acknowledge with escaped slash", method: http.MethodPost, path: "/integrations/alerts/prod-web-01%2Fserver_down/acknowledge", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, tt.method, tt.path, "")
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	alert, err := tracker.Get("prod-web-01/server_down")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alert.State != domain.AlertAcknowledged {
+		t.Errorf("alert state = %q, want %q", alert.State, domain.AlertAcknowledged)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	event, ok := queue.Dequeue(ctx)
+	if !ok {
+		t.Fatalf("Dequeue returned no event")
+	}
+	if event.DedupKey != "prod-web-01/server_down" || event.Action != domain.ActionAcknowledge {
+		t.Errorf("enqueued event = %+v, want an acknowledge for prod-web-01/server_down", event)
+	}
+
+	rec := serve(router, http.MethodGet, "/admin/alerts/prod-web-01/server_down", "")
+	var body domain.Alert
+	if err = json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.DedupKey != "prod-web-01/server_down" {
+		t.Errorf("get body = %s, err %v", rec.Body.String(), err)
+	}
+}
@@ -2,18 +2,18 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/smartcom/integration-platform/pkg/correlation"
 	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
-	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
 )
 
 type EventHandler struct {
-	queue  *repository.EventQueue
-	mapper domain.EventMapper
-	logger HandlerLogger
+	ingestor *usecase.Ingestor
+	logger   HandlerLogger
 }
 
 type HandlerLogger interface {
@@ -21,11 +21,10 @@ type HandlerLogger interface {
 	ErrorContext(ctx context.Context, msg string, args ...any)
 }
 
-func NewEventHandler(queue *repository.EventQueue, mapper domain.EventMapper, logger HandlerLogger) (handler *EventHandler) {
+func NewEventHandler(ingestor *usecase.Ingestor, logger HandlerLogger) (handler *EventHandler) {
 	handler = &EventHandler{
-		queue:  queue,
-		mapper: mapper,
-		logger: logger,
+		ingestor: ingestor,
+		logger:   logger,
 	}
 	return
 }
@@ -50,14 +49,32 @@ func (h *EventHandler) HandleEvent(c *gin.Context) {
 	ctx := correlation.WithID(c.Request.Context(), correlationID)
 
 	var event domain.Event
-	event, err = h.mapper.MapIncomingEvent(incoming, correlationID)
-	if err != nil {
+	event, err = h.ingestor.Ingest(ctx, incoming, correlationID)
+	if errors.Is(err, domain.ErrInvalidEvent) {
+		h.logger.ErrorContext(ctx, "invalid request payload", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+	if errors.Is(err, usecase.ErrSuppressed) {
+		h.logger.InfoContext(ctx, "event suppressed", "event_id", event.ID, "reason", err.Error())
+		c.JSON(http.StatusOK, gin.H{
+			"status":         "suppressed",
+			"event_id":       event.ID,
+			"correlation_id": correlationID,
+			"reason":         err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, usecase.ErrMappingFailed) {
 		h.logger.ErrorContext(ctx, "failed to map event", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
-
-	err = h.queue.Enqueue(ctx, event)
+	if errors.Is(err, usecase.ErrEnrichmentFailed) {
+		h.logger.ErrorContext(ctx, "failed to enrich event", "event_id", event.ID, "error", err.Error())
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "service temporarily unavailable", "event_id": event.ID})
+		return
+	}
 	if err != nil {
 		h.logger.ErrorContext(ctx, "failed to enqueue event", "error", err.Error())
 		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "service temporarily unavailable"})
@@ -77,14 +94,6 @@ func (h *EventHandler) HandleEvent(c *gin.Context) {
 	})
 }
 
-func (h *EventHandler) HandleHealth(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "middleware",
-	})
-}
-
 func (h *EventHandler) RegisterRoutes(router *gin.Engine) {
-	router.GET("/health", h.HandleHealth)
 	router.POST("/integrations/events", h.HandleEvent)
 }
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+type EventStoreHandler struct {
+	store domain.EventStore
+}
+
+const metadataQueryPrefix = "metadata."
+
+func NewEventStoreHandler(store domain.EventStore) (handler *EventStoreHandler) {
+	handler = &EventStoreHandler{store: store}
+	return
+}
+
+func (h *EventStoreHandler) HandleQuery(c *gin.Context) {
+	query, err := parseEventQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.store.Query(c.Request.Context(), query)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":       len(page.Records),
+		"records":     page.Records,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+func (h *EventStoreHandler) HandleGet(c *gin.Context) {
+	record, err := h.store.Get(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, repository.ErrEventNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+func (h *EventStoreHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/events", h.HandleQuery)
+	router.GET("/admin/events/:id", h.HandleGet)
+}
+
+func parseEventQuery(c *gin.Context) (query domain.EventQuery, err error) {
+	query.Source = c.Query("source")
+	query.EventType = c.Query("event_type")
+	query.Status = domain.DeliveryStatus(c.Query("status"))
+	query.CorrelationID = c.Query("correlation_id")
+	query.Destination = c.Query("destination")
+	query.DedupKey = c.Query("dedup_key")
+	query.SilenceID = c.Query("silence_id")
+	query.InhibitedBy = c.Query("inhibited_by")
+	query.Cursor = c.Query("cursor")
+
+	if raw := c.Query("priority"); raw != "" {
+		var priority domain.Priority
+		priority, err = domain.ParsePriority(raw)
+		if err != nil {
+			return
+		}
+		query.Priority = &priority
+	}
+
+	query.From, err = parseQueryTime(c.Query("from"))
+	if err != nil {
+		err = errors.New("from must be an RFC3339 timestamp")
+		return
+	}
+	query.To, err = parseQueryTime(c.Query("to"))
+	if err != nil {
+		err = errors.New("to must be an RFC3339 timestamp")
+		return
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		query.Limit, err = strconv.Atoi(raw)
+		if err != nil || query.Limit <= 0 {
+			err = errors.New("limit must be a positive integer")
+			return
+		}
+	}
+
+	for key, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(key, metadataQueryPrefix) {
+			continue
+		}
+		if query.Metadata == nil {
+			query.Metadata = make(map[string]string)
+		}
+		query.Metadata[strings.TrimPrefix(key, metadataQueryPrefix)] = values[0]
+	}
+	return
+}
+
+func parseQueryTime(raw string) (t time.Time, err error) {
+	if raw == "" {
+		return
+	}
+	t, err = time.Parse(time.RFC3339, raw)
+	return
+}
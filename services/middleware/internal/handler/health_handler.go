@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/health"
+)
+
+type HealthHandler struct {
+	registry *health.Registry
+}
+
+func NewHealthHandler(registry *health.Registry) (handler *HealthHandler) {
+	handler = &HealthHandler{
+		registry: registry,
+	}
+	return
+}
+
+func (h *HealthHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/livez", gin.WrapH(h.registry.LivenessHandler()))
+	router.GET("/readyz", gin.WrapH(h.registry.ReadinessHandler()))
+	router.GET("/health", gin.WrapH(h.registry.ReadinessHandler()))
+}
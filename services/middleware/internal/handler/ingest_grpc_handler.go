@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/smartcom/integration-platform/pkg/correlation"
+	ingestv1 "github.com/smartcom/integration-platform/services/middleware/api/ingest/v1"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const grpcCorrelationHeader = "x-correlation-id"
+
+type IngestGRPCHandler struct {
+	ingestv1.UnimplementedIngestServiceServer
+
+	ingestor *usecase.Ingestor
+	logger   HandlerLogger
+}
+
+func NewIngestGRPCHandler(ingestor *usecase.Ingestor, logger HandlerLogger) (handler *IngestGRPCHandler) {
+	handler = &IngestGRPCHandler{
+		ingestor: ingestor,
+		logger:   logger,
+	}
+	return
+}
+
+func (h *IngestGRPCHandler) Register(server *grpc.Server) {
+	ingestv1.RegisterIngestServiceServer(server, h)
+}
+
+func (h *IngestGRPCHandler) IngestEvent(ctx context.Context, req *ingestv1.IngestEventRequest) (resp *ingestv1.IngestEventResponse, err error) {
+	var correlationID string
+	correlationID, err = h.correlationID(ctx)
+	if err != nil {
+		return
+	}
+
+	resp, err = h.ingest(ctx, req.GetEvent(), correlationID)
+	return
+}
+
+func (h *IngestGRPCHandler) IngestEvents(stream grpc.ClientStreamingServer[ingestv1.IngestEventsRequest, ingestv1.IngestEventsResponse]) (err error) {
+	ctx := stream.Context()
+
+	var streamID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(grpcCorrelationHeader); len(values) > 0 {
+			streamID = values[0]
+		}
+	}
+
+	summary := &ingestv1.IngestEventsResponse{}
+	for received := 1; ; received++ {
+		var req *ingestv1.IngestEventsRequest
+		req, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			err = stream.SendAndClose(summary)
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		var correlationID string
+		if streamID != "" {
+			correlationID = fmt.Sprintf("%s-%d", streamID, received)
+		} else {
+			correlationID, err = h.correlationID(ctx)
+			if err != nil {
+				return
+			}
+		}
+
+		var resp *ingestv1.IngestEventResponse
+		resp, err = h.ingest(ctx, req.GetEvent(), correlationID)
+		if err != nil {
+			st := status.Convert(err)
+			err = status.Errorf(st.Code(), "event %d: %s", received-1, st.Message())
+			return
+		}
+
+		if resp.Status == "suppressed" {
+			summary.Suppressed++
+		} else {
+			summary.Accepted++
+		}
+		summary.Events = append(summary.Events, resp)
+	}
+}
+
+func (h *IngestGRPCHandler) ingest(ctx context.Context, pb *ingestv1.IncomingEvent, correlationID string) (resp *ingestv1.IngestEventResponse, err error) {
+	if pb == nil {
+		err = status.Error(codes.InvalidArgument, "event is required")
+		return
+	}
+
+	incoming := domain.IncomingEvent{
+		Source:       pb.GetSource(),
+		EventType:    pb.GetEventType(),
+		Severity:     pb.GetSeverity(),
+		Message:      pb.GetMessage(),
+		Destination:  pb.GetDestination(),
+		PartitionKey: pb.GetPartitionKey(),
+		Action:       pb.GetAction(),
+		DedupKey:     pb.GetDedupKey(),
+	}
+	if pb.GetMetadata() != nil {
+		incoming.Metadata = pb.GetMetadata().AsMap()
+	}
+
+	ctx = correlation.WithID(ctx, correlationID)
+
+	var event domain.Event
+	event, err = h.ingestor.Ingest(ctx, incoming, correlationID)
+	if errors.Is(err, usecase.ErrSuppressed) {
+		h.logger.InfoContext(ctx, "event suppressed", "event_id", event.ID, "reason", err.Error(), "transport", "grpc")
+		resp = &ingestv1.IngestEventResponse{
+			Status:        "suppressed",
+			EventId:       event.ID,
+			CorrelationId: correlationID,
+			Reason:        err.Error(),
+		}
+		err = nil
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to ingest grpc event", "error", err.Error())
+		err = ingestStatus(err)
+		return
+	}
+
+	h.logger.InfoContext(ctx, "event accepted",
+		"event_id", event.ID,
+		"source", event.Source,
+		"type", event.EventType,
+		"transport", "grpc",
+	)
+
+	resp = &ingestv1.IngestEventResponse{
+		Status:        "accepted",
+		EventId:       event.ID,
+		CorrelationId: correlationID,
+	}
+	return
+}
+
+func (h *IngestGRPCHandler) correlationID(ctx context.Context) (id string, err error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(grpcCorrelationHeader); len(values) > 0 && values[0] != "" {
+			id = values[0]
+			return
+		}
+	}
+
+	id, err = correlation.GenerateID()
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate correlation ID", "error", err.Error())
+		err = status.Error(codes.Internal, "internal server error")
+	}
+	return
+}
+
+func ingestStatus(err error) (statusErr error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidEvent):
+		statusErr = status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrMappingFailed):
+		statusErr = status.Error(codes.Internal, "internal server error")
+	case errors.Is(err, usecase.ErrEnrichmentFailed):
+		statusErr = status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, repository.ErrQueueFull):
+		statusErr = status.Error(codes.ResourceExhausted, repository.ErrQueueFull.Error())
+	case errors.Is(err, repository.ErrQueueDraining), errors.Is(err, repository.ErrQueueClosed):
+		statusErr = status.Error(codes.Unavailable, "service temporarily unavailable")
+	case errors.Is(err, context.DeadlineExceeded):
+		statusErr = status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		statusErr = status.Error(codes.Canceled, err.Error())
+	default:
+		statusErr = status.Error(codes.Unavailable, fmt.Sprintf("service temporarily unavailable: %v", err))
+	}
+	return
+}
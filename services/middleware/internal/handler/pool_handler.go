@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/worker"
+)
+
+type PoolHandler struct {
+	pool   PoolController
+	logger HandlerLogger
+}
+
+type PoolController interface {
+	Stats() (stats worker.PoolStats)
+	Workers() (workers []worker.WorkerInfo)
+	SetTarget(target int) (err error)
+	ClearOverride()
+	Pause()
+	Resume()
+}
+
+type poolTargetRequest struct {
+	Target int `json:"target" binding:"required"`
+}
+
+func NewPoolHandler(pool PoolController, logger HandlerLogger) (handler *PoolHandler) {
+	handler = &PoolHandler{
+		pool:   pool,
+		logger: logger,
+	}
+	return
+}
+
+func (h *PoolHandler) HandleStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.pool.Stats())
+}
+
+func (h *PoolHandler) HandleSetTarget(c *gin.Context) {
+	var request poolTargetRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	err = h.pool.SetTarget(request.Target)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "worker pool target set via admin", "target", request.Target)
+	c.JSON(http.StatusOK, h.pool.Stats())
+}
+
+func (h *PoolHandler) HandleClearTarget(c *gin.Context) {
+	h.pool.ClearOverride()
+	c.JSON(http.StatusOK, h.pool.Stats())
+}
+
+func (h *PoolHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/pool", h.HandleStats)
+	router.PUT("/admin/pool/target", h.HandleSetTarget)
+	router.DELETE("/admin/pool/target", h.HandleClearTarget)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/worker"
+)
+
+func poolRouter(t *testing.T, cfg worker.Config) (router *gin.Engine, pool *worker.Pool) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	pool, err := worker.NewPool(cfg, repository.NewEventQueue(10, 0), nil, testLogger())
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+
+	router = gin.New()
+	NewPoolHandler(pool, testLogger()).RegisterRoutes(router)
+	return
+}
+
+func TestPoolHandlerSetTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        worker.Config
+		body       string
+		want       int
+		wantTarget int
+	}{
+		{name: "within range", body: `{"target": 6}`, want: http.StatusOK, wantTarget: 6},
+		{name: "at the minimum", body: `{"target": 2}`, want: http.StatusOK, wantTarget: 2},
+		{name: "below the minimum", body: `{"target": 1}`, want: http.StatusBadRequest, wantTarget: 4},
+		{name: "above the maximum", body: `{"target": 11}`, want: http.StatusBadRequest, wantTarget: 4},
+		{name: "negative", body: `{"target": -3}`, want: http.StatusBadRequest, wantTarget: 4},
+		{name: "missing target", body: `{}`, want: http.StatusBadRequest, wantTarget: 4},
+		{name: "not a number", body: `{"target": "six"}`, want: http.StatusBadRequest, wantTarget: 4},
+		{name: "not JSON", body: `six`, want: http.StatusBadRequest, wantTarget: 4},
+		{
+			name:       "partitioned pool",
+			cfg:        worker.Config{Partition: worker.PartitionConfig{Key: "source", Lanes: 2}},
+			body:       `{"target": 2}`,
+			want:       http.StatusBadRequest,
+			wantTarget: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.InitialWorkers, cfg.MinWorkers, cfg.MaxWorkers = 4, 2, 10
+			router, pool := poolRouter(t, cfg)
+
+			rec := serve(router, http.MethodPut, "/admin/pool/target", tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+
+			stats := pool.Stats()
+			if stats.Target != tt.wantTarget {
+				t.Errorf("target = %d, want %d", stats.Target, tt.wantTarget)
+			}
+			if stats.Override != (tt.want == http.StatusOK) {
+				t.Errorf("override = %v, want %v", stats.Override, tt.want == http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestPoolHandlerStatsAndClearTarget(t *testing.T) {
+	router, pool := poolRouter(t, worker.Config{InitialWorkers: 4, MinWorkers: 2, MaxWorkers: 10})
+
+	rec := serve(router, http.MethodPut, "/admin/pool/target", `{"target": 8}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = serve(router, http.MethodGet, "/admin/pool", "")
+	var body map[string]interface{}
+	err := json.Unmarshal(rec.Body.Bytes(), &body)
+	if err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("GET /admin/pool = %d %s, err %v", rec.Code, rec.Body.String(), err)
+	}
+	if body["target"] != 8.0 || body["override"] != true || body["min_workers"] != 2.0 || body["max_workers"] != 10.0 {
+		t.Errorf("stats = %v, want target 8 overridden within [2, 10]", body)
+	}
+	if _, ok := body["signals"].(map[string]interface{}); !ok {
+		t.Errorf("stats signals = %v, want an object", body["signals"])
+	}
+
+	rec = serve(router, http.MethodDelete, "/admin/pool/target", "")
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if stats := pool.Stats(); stats.Override || stats.Target != 8 {
+		t.Errorf("stats after clear = %+v, want target 8 without override", stats)
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type ReplayHandler struct {
+	replayer *usecase.Replayer
+	logger   HandlerLogger
+}
+
+func NewReplayHandler(replayer *usecase.Replayer, logger HandlerLogger) (handler *ReplayHandler) {
+	handler = &ReplayHandler{
+		replayer: replayer,
+		logger:   logger,
+	}
+	return
+}
+
+func (h *ReplayHandler) HandleStart(c *gin.Context) {
+	var request usecase.ReplayRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	job, err := h.replayer.Start(c.Request.Context(), request)
+	if errors.Is(err, repository.ErrUnknownDestination) || errors.Is(err, repository.ErrDestinationDisabled) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to start replay", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+func (h *ReplayHandler) HandleList(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"replays": h.replayer.List()})
+}
+
+func (h *ReplayHandler) HandleGet(c *gin.Context) {
+	job, err := h.replayer.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func (h *ReplayHandler) HandleCancel(c *gin.Context) {
+	err := h.replayer.Cancel(c.Param("id"))
+	if errors.Is(err, usecase.ErrReplayNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "replay cancelled via admin", "replay_id", c.Param("id"))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ReplayHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/admin/replays", h.HandleStart)
+	router.GET("/admin/replays", h.HandleList)
+	router.GET("/admin/replays/:id", h.HandleGet)
+	router.DELETE("/admin/replays/:id", h.HandleCancel)
+}
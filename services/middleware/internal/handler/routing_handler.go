@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type RoutingHandler struct {
+	router    *usecase.Router
+	escalator *usecase.Escalator
+	digester  *usecase.Digester
+}
+
+func NewRoutingHandler(router *usecase.Router, escalator *usecase.Escalator, digester *usecase.Digester) (handler *RoutingHandler) {
+	handler = &RoutingHandler{
+		router:    router,
+		escalator: escalator,
+		digester:  digester,
+	}
+	return
+}
+
+func (h *RoutingHandler) HandleRoutes(c *gin.Context) {
+	c.JSON(http.StatusOK, h.router.Config())
+}
+
+func (h *RoutingHandler) HandleEscalations(c *gin.Context) {
+	escalations := h.escalator.List()
+	c.JSON(http.StatusOK, gin.H{
+		"count":       len(escalations),
+		"escalations": escalations,
+	})
+}
+
+func (h *RoutingHandler) HandleDigests(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"digests": h.digester.Status()})
+}
+
+func (h *RoutingHandler) HandleFlushDigests(c *gin.Context) {
+	sent := h.digester.Flush(c.Request.Context())
+	c.JSON(http.StatusOK, gin.H{"sent": sent})
+}
+
+func (h *RoutingHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/routes", h.HandleRoutes)
+	router.GET("/admin/escalations", h.HandleEscalations)
+	router.GET("/admin/digests", h.HandleDigests)
+	router.POST("/admin/digests/flush", h.HandleFlushDigests)
+}
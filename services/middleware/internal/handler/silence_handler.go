@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type SilenceHandler struct {
+	silencer *usecase.Silencer
+	logger   HandlerLogger
+}
+
+func NewSilenceHandler(silencer *usecase.Silencer, logger HandlerLogger) (handler *SilenceHandler) {
+	handler = &SilenceHandler{
+		silencer: silencer,
+		logger:   logger,
+	}
+	return
+}
+
+func (h *SilenceHandler) HandleCreate(c *gin.Context) {
+	var silence domain.Silence
+	err := c.ShouldBindJSON(&silence)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	silence, err = h.silencer.Create(c.Request.Context(), silence)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "silence created",
+		"silence_id", silence.ID,
+		"created_by", silence.CreatedBy,
+		"starts_at", silence.StartsAt,
+		"ends_at", silence.EndsAt,
+	)
+	c.JSON(http.StatusCreated, silence)
+}
+
+func (h *SilenceHandler) HandleList(c *gin.Context) {
+	silences := h.silencer.List(domain.SilenceState(c.Query("state")))
+	c.JSON(http.StatusOK, gin.H{
+		"count":    len(silences),
+		"silences": silences,
+	})
+}
+
+func (h *SilenceHandler) HandleGet(c *gin.Context) {
+	silence, err := h.silencer.Get(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, silence)
+}
+
+func (h *SilenceHandler) HandleUpdate(c *gin.Context) {
+	var silence domain.Silence
+	err := c.ShouldBindJSON(&silence)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	silence, err = h.silencer.Update(c.Request.Context(), c.Param("id"), silence)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "silence updated", "silence_id", silence.ID)
+	c.JSON(http.StatusOK, silence)
+}
+
+func (h *SilenceHandler) HandleExpire(c *gin.Context) {
+	silence, err := h.silencer.Expire(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.logger.InfoContext(c.Request.Context(), "silence expired", "silence_id", silence.ID)
+	c.JSON(http.StatusOK, silence)
+}
+
+func (h *SilenceHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSilence):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSilenceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSilenceExpired):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(c.Request.Context(), "silence operation failed", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func (h *SilenceHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/admin/silences", h.HandleCreate)
+	router.GET("/admin/silences", h.HandleList)
+	router.GET("/admin/silences/:id", h.HandleGet)
+	router.PUT("/admin/silences/:id", h.HandleUpdate)
+	router.DELETE("/admin/silences/:id", h.HandleExpire)
+}
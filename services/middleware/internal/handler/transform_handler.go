@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type TransformHandler struct {
+	transformer *usecase.Transformer
+	mapper      domain.EventMapper
+}
+
+type transformTestRequest struct {
+	Expression string               `json:"expression"`
+	Route      string               `json:"route"`
+	Event      domain.IncomingEvent `json:"event"`
+}
+
+func NewTransformHandler(transformer *usecase.Transformer, mapper domain.EventMapper) (handler *TransformHandler) {
+	handler = &TransformHandler{
+		transformer: transformer,
+		mapper:      mapper,
+	}
+	return
+}
+
+func (h *TransformHandler) HandleTest(c *gin.Context) {
+	var req transformTestRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+	if (req.Expression == "") == (req.Route == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of expression or route is required"})
+		return
+	}
+
+	err = req.Event.Validate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var event domain.Event
+	event, err = h.mapper.MapIncomingEvent(req.Event, "transform-test")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Expression != "" {
+		var result interface{}
+		result, err = h.transformer.Evaluate(c.Request.Context(), req.Expression, event)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"result": result})
+		return
+	}
+
+	if !h.transformer.Has(req.Route) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "route has no transforms"})
+		return
+	}
+
+	var transformed domain.Event
+	transformed, err = h.transformer.Apply(c.Request.Context(), event, req.Route)
+	if errors.Is(err, usecase.ErrSuppressed) {
+		c.JSON(http.StatusOK, gin.H{"dropped": true, "reason": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dropped": false,
+		"event":   domain.NewEventRecord(transformed, domain.StatusAccepted),
+	})
+}
+
+func (h *TransformHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/admin/transforms/test", h.HandleTest)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/pkg/correlation"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type webhookResult struct {
+	Index   int    `json:"index"`
+	Status  string `json:"status"`
+	EventID string `json:"event_id,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type WebhookHandler struct {
+	ingestor *usecase.Ingestor
+	logger   HandlerLogger
+}
+
+func NewWebhookHandler(ingestor *usecase.Ingestor, logger HandlerLogger) (handler *WebhookHandler) {
+	handler = &WebhookHandler{
+		ingestor: ingestor,
+		logger:   logger,
+	}
+	return
+}
+
+func (h *WebhookHandler) HandleAlertmanager(c *gin.Context) {
+	var webhook domain.AlertmanagerWebhook
+	err := c.ShouldBindJSON(&webhook)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "invalid alertmanager payload", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	h.ingestAll(c, usecase.TranslateAlertmanager(webhook, usecase.AlertmanagerSource))
+}
+
+func (h *WebhookHandler) HandleGrafana(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	var unified domain.AlertmanagerWebhook
+	err = json.Unmarshal(body, &unified)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "invalid grafana payload", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	if len(unified.Alerts) > 0 {
+		h.ingestAll(c, usecase.TranslateAlertmanager(unified, usecase.GrafanaSource))
+		return
+	}
+
+	var legacy domain.GrafanaLegacyWebhook
+	err = json.Unmarshal(body, &legacy)
+	if err != nil || legacy.State == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload"})
+		return
+	}
+
+	h.ingestAll(c, []domain.IncomingEvent{usecase.TranslateGrafanaLegacy(legacy)})
+}
+
+func (h *WebhookHandler) ingestAll(c *gin.Context, events []domain.IncomingEvent) {
+	destination := c.Query("destination")
+	for i := range events {
+		events[i].Destination = destination
+
+		err := events[i].Validate()
+		if err != nil {
+			h.logger.ErrorContext(c.Request.Context(), "invalid alert in webhook", "index", i, "error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload", "index": i})
+			return
+		}
+	}
+
+	correlationID, err := correlation.GenerateID()
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to generate correlation ID", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	ctx := correlation.WithID(c.Request.Context(), correlationID)
+
+	eventIDs := make([]string, 0, len(events))
+	results := make([]webhookResult, 0, len(events))
+	suppressed, failed := 0, 0
+	for i, incoming := range events {
+		var event domain.Event
+		event, err = h.ingestor.Ingest(ctx, incoming, correlationID)
+		result := webhookResult{Index: i, EventID: event.ID}
+		switch {
+		case err == nil:
+			result.Status = "accepted"
+			eventIDs = append(eventIDs, event.ID)
+		case errors.Is(err, usecase.ErrSuppressed):
+			h.logger.InfoContext(ctx, "webhook alert suppressed", "event_id", event.ID, "reason", err.Error())
+			result.Status = "suppressed"
+			result.Reason = err.Error()
+			suppressed++
+		case errors.Is(err, usecase.ErrMappingFailed):
+			h.logger.ErrorContext(ctx, "failed to map event", "index", i, "error", err.Error())
+			result.Status = "failed"
+			result.Error = "internal server error"
+			failed++
+		case errors.Is(err, usecase.ErrEnrichmentFailed):
+			h.logger.ErrorContext(ctx, "failed to enrich event", "event_id", event.ID, "error", err.Error())
+			result.Status = "failed"
+			result.Error = "service temporarily unavailable"
+			failed++
+		default:
+			h.logger.ErrorContext(ctx, "failed to enqueue event", "event_id", event.ID, "error", err.Error())
+			result.Status = "failed"
+			result.Error = "service temporarily unavailable"
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if len(eventIDs) == 0 && failed > 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":          "service temporarily unavailable",
+			"accepted":       0,
+			"suppressed":     suppressed,
+			"failed":         failed,
+			"results":        results,
+			"correlation_id": correlationID,
+		})
+		return
+	}
+
+	status := "accepted"
+	if failed > 0 {
+		status = "partial"
+	}
+
+	h.logger.InfoContext(ctx, "webhook alerts accepted", "count", len(eventIDs), "suppressed", suppressed, "failed", failed)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":         status,
+		"accepted":       len(eventIDs),
+		"suppressed":     suppressed,
+		"failed":         failed,
+		"event_ids":      eventIDs,
+		"results":        results,
+		"correlation_id": correlationID,
+	})
+}
+
+func (h *WebhookHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/integrations/alertmanager", h.HandleAlertmanager)
+	router.POST("/integrations/grafana", h.HandleGrafana)
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcom/integration-platform/services/middleware/internal/infrastructure"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+)
+
+type webhookResponse struct {
+	Status     string          `json:"status"`
+	Accepted   int             `json:"accepted"`
+	Suppressed int             `json:"suppressed"`
+	Failed     int             `json:"failed"`
+	EventIDs   []string        `json:"event_ids"`
+	Results    []webhookResult `json:"results"`
+}
+
+func webhookRouter(queue *repository.EventQueue) (router *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+
+	tracker := usecase.NewAlertTracker(infrastructure.NewSystemClock(), 0)
+	router = gin.New()
+	NewWebhookHandler(testIngestor(queue, usecase.IngestPipeline{Alerts: tracker}), testLogger()).RegisterRoutes(router)
+	return
+}
+
+func decodeWebhookResponse(t *testing.T, body []byte) (resp webhookResponse) {
+	t.Helper()
+
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		t.Fatalf("response is not JSON: %v (%s)", err, body)
+	}
+	return
+}
+
+func TestWebhookHandlerAlertmanager(t *testing.T) {
+	queue := repository.NewEventQueue(10, 0)
+	router := webhookRouter(queue)
+
+	body := `{
+		"status": "firing",
+		"commonLabels": {"alertname": "HighLatency", "job": "api"},
+		"alerts": [
+			{"status": "firing", "fingerprint": "fp-1", "annotations": {"summary": "latency is high"}},
+			{"status": "resolved", "fingerprint": "fp-2"}
+		]
+	}`
+	rec := serve(router, http.MethodPost, "/integrations/alertmanager?destination=chat", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	resp := decodeWebhookResponse(t, rec.Body.Bytes())
+	if resp.Status != "accepted" || resp.Accepted != 1 || resp.Suppressed != 1 || resp.Failed != 0 {
+		t.Errorf("response = %+v, want 1 accepted and 1 suppressed", resp)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].Status != "accepted" || resp.Results[1].Status != "suppressed" {
+		t.Errorf("results = %+v, want [accepted suppressed]", resp.Results)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	event, ok := queue.Dequeue(ctx)
+	if !ok {
+		t.Fatalf("Dequeue returned no event")
+	}
+	if event.ID != resp.EventIDs[0] || event.Source != "api" || event.Destination != "chat" || event.DedupKey != "fp-1" {
+		t.Errorf("enqueued event = %+v, want fp-1 from api to chat", event)
+	}
+}
+
+func TestWebhookHandlerReportsPartialBatch(t *testing.T) {
+	queue := repository.NewEventQueue(1, time.Millisecond)
+	router := webhookRouter(queue)
+
+	body := `{
+		"status": "firing",
+		"commonLabels": {"alertname": "HighLatency"},
+		"alerts": [
+			{"status": "firing", "fingerprint": "fp-1"},
+			{"status": "firing", "fingerprint": "fp-2"},
+			{"status": "firing", "fingerprint": "fp-3"}
+		]
+	}`
+	rec := serve(router, http.MethodPost, "/integrations/alertmanager", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	resp := decodeWebhookResponse(t, rec.Body.Bytes())
+	if resp.Status != "partial" || resp.Accepted != 1 || resp.Failed != 2 {
+		t.Errorf("response = %+v, want partial with 1 accepted and 2 failed", resp)
+	}
+	wantStatuses := []string{"accepted", "failed", "failed"}
+	for i, result := range resp.Results {
+		if result.Index != i || result.Status != wantStatuses[i] {
+			t.Errorf("results[%d] = %+v, want index %d status %s", i, result, i, wantStatuses[i])
+		}
+	}
+
+	rec = serve(router, http.MethodPost, "/integrations/alertmanager", body)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d (body %s)", rec.Code, http.StatusServiceUnavailable, rec.Body.String())
+	}
+	resp = decodeWebhookResponse(t, rec.Body.Bytes())
+	if resp.Accepted != 0 || resp.Failed != 3 {
+		t.Errorf("response = %+v, want nothing accepted and 3 failed", resp)
+	}
+	if queue.Len() != 1 {
+		t.Errorf("queue length = %d, want 1", queue.Len())
+	}
+}
+
+func TestWebhookHandlerGrafana(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{
+			name: "unified alerting",
+			body: `{"status": "firing", "alerts": [{"status": "firing", "fingerprint": "fp-1", "labels": {"alertname": "DiskFull"}}]}`,
+			want: http.StatusOK,
+		},
+		{
+			name: "legacy alert",
+			body: `{"ruleId": 7, "ruleName": "Disk usage", "state": "alerting", "message": "disk is full"}`,
+			want: http.StatusOK,
+		},
+		{
+			name: "legacy without state",
+			body: `{"ruleId": 7, "ruleName": "Disk usage"}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "not JSON",
+			body: `alerts`,
+			want: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := webhookRouter(repository.NewEventQueue(10, 0))
+			rec := serve(router, http.MethodPost, "/integrations/grafana", tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package infrastructure
+
+import "time"
+
+type SystemClock struct{}
+
+func NewSystemClock() (clock *SystemClock) {
+	clock = &SystemClock{}
+	return
+}
+
+func (c *SystemClock) Now() (now time.Time) {
+	now = time.Now()
+	return
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/encryption"
+	bolt "go.etcd.io/bbolt"
+)
+
+var escalationsBucket = []byte("escalations")
+
+type BoltEscalationStore struct {
+	db     *bolt.DB
+	sealer *encryption.Sealer
+}
+
+func NewBoltEscalationStore(path string, sealer *encryption.Sealer) (store *BoltEscalationStore, err error) {
+	var db *bolt.DB
+	db, err = bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		err = fmt.Errorf("failed to open escalation store %s: %w", path, err)
+		return
+	}
+
+	err = db.Update(func(tx *bolt.Tx) (err error) {
+		_, err = tx.CreateBucketIfNotExists(escalationsBucket)
+		return
+	})
+	if err != nil {
+		db.Close()
+		err = fmt.Errorf("failed to initialise escalation store: %w", err)
+		return
+	}
+
+	store = &BoltEscalationStore{db: db, sealer: sealer}
+	return
+}
+
+func (s *BoltEscalationStore) Save(ctx context.Context, escalation domain.Escalation) (err error) {
+	var value []byte
+	value, err = json.Marshal(escalation)
+	if err != nil {
+		return
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		key := []byte(escalation.DedupKey)
+
+		var sealed []byte
+		sealed, err = s.sealer.Seal(tx, escalationsBucket, key, value)
+		if err != nil {
+			return
+		}
+		err = tx.Bucket(escalationsBucket).Put(key, sealed)
+		return
+	})
+	return
+}
+
+func (s *BoltEscalationStore) Delete(ctx context.Context, dedupKey string) (err error) {
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		err = tx.Bucket(escalationsBucket).Delete([]byte(dedupKey))
+		return
+	})
+	return
+}
+
+func (s *BoltEscalationStore) List(ctx context.Context) (escalations []domain.Escalation, err error) {
+	err = s.db.View(func(tx *bolt.Tx) (err error) {
+		err = tx.Bucket(escalationsBucket).ForEach(func(key, value []byte) (err error) {
+			value, err = s.sealer.Open(tx, escalationsBucket, key, value)
+			if err != nil {
+				return
+			}
+
+			var escalation domain.Escalation
+			err = json.Unmarshal(value, &escalation)
+			if err != nil {
+				return
+			}
+			escalations = append(escalations, escalation)
+			return
+		})
+		return
+	})
+	return
+}
+
+func (s *BoltEscalationStore) Reencrypt(ctx context.Context) (stats encryption.ReencryptStats, err error) {
+	stats, err = s.sealer.Reencrypt(ctx, s.db, escalationsBucket)
+	return
+}
+
+func (s *BoltEscalationStore) Verify(ctx context.Context) (report encryption.VerifyReport, err error) {
+	report, err = s.sealer.Verify(ctx, s.db, escalationsBucket)
+	return
+}
+
+func (s *BoltEscalationStore) Close() (err error) {
+	err = s.db.Close()
+	return
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/encryption"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	eventsBucket   = []byte("events")
+	eventIDsBucket = []byte("event_ids")
+)
+
+type BoltEventStore struct {
+	mu     sync.RWMutex
+	path   string
+	db     *bolt.DB
+	sealer *encryption.Sealer
+}
+
+const compactTxMaxSize = 64 * 1024 * 1024
+
+func NewBoltEventStore(path string, sealer *encryption.Sealer) (store *BoltEventStore, err error) {
+	store = &BoltEventStore{path: path, sealer: sealer}
+
+	err = store.open()
+	if err != nil {
+		store = nil
+	}
+	return
+}
+
+func (s *BoltEventStore) open() (err error) {
+	s.db, err = bolt.Open(s.path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		err = fmt.Errorf("failed to open event store %s: %w", s.path, err)
+		return
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		_, err = tx.CreateBucketIfNotExists(eventsBucket)
+		if err != nil {
+			return
+		}
+		_, err = tx.CreateBucketIfNotExists(eventIDsBucket)
+		return
+	})
+	if err != nil {
+		s.db.Close()
+		err = fmt.Errorf("failed to initialise event store: %w", err)
+	}
+	return
+}
+
+func (s *BoltEventStore) Record(ctx context.Context, record domain.EventRecord) (err error) {
+	var value []byte
+	value, err = json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		events := tx.Bucket(eventsBucket)
+		ids := tx.Bucket(eventIDsBucket)
+
+		if existing := ids.Get([]byte(record.ID)); existing != nil {
+			err = events.Delete(existing)
+			if err != nil {
+				return
+			}
+		}
+
+		key := recordKey(record.Timestamp, record.ID)
+
+		var sealed []byte
+		sealed, err = s.sealer.Seal(tx, eventsBucket, key, value)
+		if err != nil {
+			return
+		}
+		err = events.Put(key, sealed)
+		if err != nil {
+			return
+		}
+		err = ids.Put([]byte(record.ID), key)
+		return
+	})
+	return
+}
+
+func (s *BoltEventStore) UpdateStatus(ctx context.Context, id string, update domain.StatusUpdate) (err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		events := tx.Bucket(eventsBucket)
+
+		key := tx.Bucket(eventIDsBucket).Get([]byte(id))
+		if key == nil {
+			err = fmt.Errorf("%w: %s", ErrEventNotFound, id)
+			return
+		}
+
+		var value []byte
+		value, err = s.sealer.Open(tx, eventsBucket, key, events.Get(key))
+		if err != nil {
+			return
+		}
+
+		var record domain.EventRecord
+		err = json.Unmarshal(value, &record)
+		if err != nil {
+			return
+		}
+
+		record.Apply(update)
+
+		value, err = json.Marshal(record)
+		if err != nil {
+			return
+		}
+
+		value, err = s.sealer.Seal(tx, eventsBucket, key, value)
+		if err != nil {
+			return
+		}
+		err = events.Put(key, value)
+		return
+	})
+	return
+}
+
+func (s *BoltEventStore) Get(ctx context.Context, id string) (record domain.EventRecord, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err = s.db.View(func(tx *bolt.Tx) (err error) {
+		key := tx.Bucket(eventIDsBucket).Get([]byte(id))
+		if key == nil {
+			err = fmt.Errorf("%w: %s", ErrEventNotFound, id)
+			return
+		}
+
+		var value []byte
+		value, err = s.sealer.Open(tx, eventsBucket, key, tx.Bucket(eventsBucket).Get(key))
+		if err != nil {
+			return
+		}
+
+		err = json.Unmarshal(value, &record)
+		return
+	})
+	return
+}
+
+func (s *BoltEventStore) Query(ctx context.Context, query domain.EventQuery) (page domain.EventPage, err error) {
+	var upper []byte
+	upper, err = queryUpperBound(query)
+	if err != nil {
+		return
+	}
+
+	limit := queryLimit(query)
+	page.Records = make([]domain.EventRecord, 0)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err = s.db.View(func(tx *bolt.Tx) (err error) {
+		cursor := tx.Bucket(eventsBucket).Cursor()
+
+		var key, value []byte
+		if upper == nil {
+			key, value = cursor.Last()
+		} else {
+			key, value = cursor.Seek(upper)
+			if key == nil {
+				key, value = cursor.Last()
+			} else {
+				key, value = cursor.Prev()
+			}
+		}
+
+		var lastKey []byte
+		for ; key != nil; key, value = cursor.Prev() {
+			err = ctx.Err()
+			if err != nil {
+				return
+			}
+
+			value, err = s.sealer.Open(tx, eventsBucket, key, value)
+			if err != nil {
+				return
+			}
+
+			var record domain.EventRecord
+			err = json.Unmarshal(value, &record)
+			if err != nil {
+				return
+			}
+
+			if !query.From.IsZero() && record.Timestamp.Before(query.From) {
+				break
+			}
+			if !query.Matches(record) {
+				continue
+			}
+
+			if len(page.Records) == limit {
+				page.NextCursor = encodeCursor(lastKey)
+				break
+			}
+			page.Records = append(page.Records, record)
+			lastKey = bytes.Clone(key)
+		}
+		return
+	})
+	return
+}
+
+func (s *BoltEventStore) Purge(ctx context.Context, before time.Time) (removed int, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cutoff := timeKey(before)
+
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		ids := tx.Bucket(eventIDsBucket)
+		cursor := tx.Bucket(eventsBucket).Cursor()
+
+		for key, _ := cursor.First(); key != nil && bytes.Compare(key, cutoff) < 0; key, _ = cursor.First() {
+			err = ids.Delete(key[8:])
+			if err != nil {
+				return
+			}
+			err = cursor.Delete()
+			if err != nil {
+				return
+			}
+			removed++
+		}
+		return
+	})
+	return
+}
+
+func (s *BoltEventStore) Compact(ctx context.Context) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	compactedPath := s.path + ".compact"
+	_ = os.Remove(compactedPath)
+
+	var compacted *bolt.DB
+	compacted, err = bolt.Open(compactedPath, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		err = fmt.Errorf("failed to create compacted event store: %w", err)
+		return
+	}
+
+	err = bolt.Compact(compacted, s.db, compactTxMaxSize)
+	closeErr := compacted.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(compactedPath)
+		err = fmt.Errorf("failed to compact event store: %w", err)
+		return
+	}
+
+	compacted, err = bolt.Open(compactedPath, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		_ = os.Remove(compactedPath)
+		err = fmt.Errorf("failed to open compacted event store: %w", err)
+		return
+	}
+
+	err = os.Rename(compactedPath, s.path)
+	if err != nil {
+		compacted.Close()
+		_ = os.Remove(compactedPath)
+		err = fmt.Errorf("failed to replace event store: %w", err)
+		return
+	}
+
+	previous := s.db
+	s.db = compacted
+	err = previous.Close()
+	if err != nil {
+		err = fmt.Errorf("failed to close previous event store: %w", err)
+	}
+	return
+}
+
+func (s *BoltEventStore) Reencrypt(ctx context.Context) (stats encryption.ReencryptStats, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats, err = s.sealer.Reencrypt(ctx, s.db, eventsBucket)
+	return
+}
+
+func (s *BoltEventStore) Verify(ctx context.Context) (report encryption.VerifyReport, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report, err = s.sealer.Verify(ctx, s.db, eventsBucket)
+	return
+}
+
+func (s *BoltEventStore) Close() (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = s.db.Close()
+	return
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBoltEventStoreCompact(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	store, err := NewBoltEventStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewBoltEventStore returned error: %v", err)
+	}
+	recordAll(t, store, testRecord("evt-1", 0), testRecord("evt-2", 1), testRecord("evt-3", 2))
+
+	if _, err = store.Purge(ctx, storeEpoch.Add(2*time.Minute)); err != nil {
+		t.Fatalf("Purge returned error: %v", err)
+	}
+	if err = store.Compact(ctx); err != nil {
+		t.Fatalf("Compact returned error: %v", err)
+	}
+	if _, err = os.Stat(path + ".compact"); !os.IsNotExist(err) {
+		t.Errorf("compacted file left behind: %v", err)
+	}
+
+	recordAll(t, store, testRecord("evt-4", 3))
+	if _, err = store.Get(ctx, "evt-3"); err != nil {
+		t.Errorf("Get(evt-3) after compaction returned error: %v", err)
+	}
+	if err = store.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	store, err = NewBoltEventStore(path, nil)
+	if err != nil {
+		t.Fatalf("reopen returned error: %v", err)
+	}
+	defer store.Close()
+	for _, id := range []string{"evt-3", "evt-4"} {
+		if _, err = store.Get(ctx, id); err != nil {
+			t.Errorf("Get(%s) after reopen returned error: %v", id, err)
+		}
+	}
+}
+
+func TestBoltEventStoreCompactFailureKeepsStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	store, err := NewBoltEventStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewBoltEventStore returned error: %v", err)
+	}
+	defer store.Close()
+	recordAll(t, store, testRecord("evt-1", 0))
+
+	blocker := filepath.Join(path+".compact", "blocker")
+	if err = os.MkdirAll(blocker, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = store.Compact(ctx); err == nil {
+		t.Fatal("Compact returned no error, want the compaction failure")
+	}
+
+	recordAll(t, store, testRecord("evt-2", 1))
+	for _, id := range []string{"evt-1", "evt-2"} {
+		if _, err = store.Get(ctx, id); err != nil {
+			t.Errorf("Get(%s) after failed compaction returned error: %v", id, err)
+		}
+	}
+}
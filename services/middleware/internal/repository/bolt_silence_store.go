@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/encryption"
+	bolt "go.etcd.io/bbolt"
+)
+
+var silencesBucket = []byte("silences")
+
+type BoltSilenceStore struct {
+	db     *bolt.DB
+	sealer *encryption.Sealer
+}
+
+func NewBoltSilenceStore(path string, sealer *encryption.Sealer) (store *BoltSilenceStore, err error) {
+	var db *bolt.DB
+	db, err = bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		err = fmt.Errorf("failed to open silence store %s: %w", path, err)
+		return
+	}
+
+	err = db.Update(func(tx *bolt.Tx) (err error) {
+		_, err = tx.CreateBucketIfNotExists(silencesBucket)
+		return
+	})
+	if err != nil {
+		db.Close()
+		err = fmt.Errorf("failed to initialise silence store: %w", err)
+		return
+	}
+
+	store = &BoltSilenceStore{db: db, sealer: sealer}
+	return
+}
+
+func (s *BoltSilenceStore) Save(ctx context.Context, silence domain.Silence) (err error) {
+	var value []byte
+	value, err = json.Marshal(silence)
+	if err != nil {
+		return
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		key := []byte(silence.ID)
+
+		var sealed []byte
+		sealed, err = s.sealer.Seal(tx, silencesBucket, key, value)
+		if err != nil {
+			return
+		}
+		err = tx.Bucket(silencesBucket).Put(key, sealed)
+		return
+	})
+	return
+}
+
+func (s *BoltSilenceStore) Delete(ctx context.Context, id string) (err error) {
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		err = tx.Bucket(silencesBucket).Delete([]byte(id))
+		return
+	})
+	return
+}
+
+func (s *BoltSilenceStore) List(ctx context.Context) (silences []domain.Silence, err error) {
+	err = s.db.View(func(tx *bolt.Tx) (err error) {
+		err = tx.Bucket(silencesBucket).ForEach(func(key, value []byte) (err error) {
+			value, err = s.sealer.Open(tx, silencesBucket, key, value)
+			if err != nil {
+				return
+			}
+
+			var silence domain.Silence
+			err = json.Unmarshal(value, &silence)
+			if err != nil {
+				return
+			}
+			silences = append(silences, silence)
+			return
+		})
+		return
+	})
+	return
+}
+
+func (s *BoltSilenceStore) Reencrypt(ctx context.Context) (stats encryption.ReencryptStats, err error) {
+	stats, err = s.sealer.Reencrypt(ctx, s.db, silencesBucket)
+	return
+}
+
+func (s *BoltSilenceStore) Verify(ctx context.Context) (report encryption.VerifyReport, err error) {
+	report, err = s.sealer.Verify(ctx, s.db, silencesBucket)
+	return
+}
+
+func (s *BoltSilenceStore) Close() (err error) {
+	err = s.db.Close()
+	return
+}
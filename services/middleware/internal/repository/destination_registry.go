@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type DestinationRegistry struct {
+	mu           sync.RWMutex
+	destinations map[string]domain.Destination
+	disabled     map[string]bool
+	listeners    []func(name string, enabled bool)
+}
+
+type DestinationStatus struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+}
+
+func NewDestinationRegistry(destinations []domain.Destination) (registry *DestinationRegistry) {
+	registry = &DestinationRegistry{
+		destinations: make(map[string]domain.Destination, len(destinations)),
+		disabled:     make(map[string]bool),
+	}
+	for _, destination := range destinations {
+		registry.destinations[destination.Name] = destination
+	}
+	return
+}
+
+func (r *DestinationRegistry) Resolve(name string) (destination domain.Destination, err error) {
+	if name == "" {
+		name = domain.DefaultDestination
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	destination, ok := r.destinations[name]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrUnknownDestination, name)
+		return
+	}
+	if r.disabled[name] {
+		err = fmt.Errorf("%w: %s", ErrDestinationDisabled, name)
+		return
+	}
+	return
+}
+
+func (r *DestinationRegistry) SetEnabled(name string, enabled bool) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.destinations[name]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrUnknownDestination, name)
+		return
+	}
+
+	if enabled {
+		delete(r.disabled, name)
+	} else {
+		r.disabled[name] = true
+	}
+
+	for _, listener := range r.listeners {
+		listener(name, enabled)
+	}
+	return
+}
+
+func (r *DestinationRegistry) Subscribe(listener func(name string, enabled bool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, listener)
+}
+
+func (r *DestinationRegistry) Statuses() (statuses []DestinationStatus) {
+	for _, destination := range r.List() {
+		r.mu.RLock()
+		enabled := !r.disabled[destination.Name]
+		r.mu.RUnlock()
+
+		statuses = append(statuses, DestinationStatus{
+			Name:    destination.Name,
+			URL:     destination.URL,
+			Enabled: enabled,
+		})
+	}
+	return
+}
+
+func (r *DestinationRegistry) List() (destinations []domain.Destination) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	destinations = make([]domain.Destination, 0, len(r.destinations))
+	for _, destination := range r.destinations {
+		destinations = append(destinations, destination)
+	}
+	sort.Slice(destinations, func(i, j int) bool {
+		return destinations[i].Name < destinations[j].Name
+	})
+	return
+}
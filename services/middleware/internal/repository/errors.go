@@ -3,6 +3,14 @@ package repository
 import "errors"
 
 var (
-	ErrQueueClosed = errors.New("queue is closed")
-	ErrQueueFull   = errors.New("queue is full")
+	ErrQueueClosed    = errors.New("queue is closed")
+	ErrQueueFull      = errors.New("queue is full")
+	ErrQueueDraining  = errors.New("queue is draining")
+	ErrQueueSaturated = errors.New("queue is saturated")
+
+	ErrUnknownDestination  = errors.New("unknown destination")
+	ErrDestinationDisabled = errors.New("destination is disabled")
+
+	ErrEventNotFound = errors.New("event not found")
+	ErrInvalidCursor = errors.New("invalid cursor")
 )
@@ -2,27 +2,46 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/smartcom/integration-platform/pkg/health"
 	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
 )
 
 type EventQueue struct {
-	queue  chan domain.Event
-	mu     sync.RWMutex
-	closed bool
+	queue          chan domain.Event
+	enqueueTimeout time.Duration
+	mu             sync.RWMutex
+	closed         bool
+	draining       bool
 }
 
-const DefaultQueueSize = 1000
+type QueueStats struct {
+	Depth    int  `json:"depth"`
+	Capacity int  `json:"capacity"`
+	Closed   bool `json:"closed"`
+	Draining bool `json:"draining"`
+}
+
+const (
+	DefaultQueueSize      = 1000
+	DefaultEnqueueTimeout = time.Second
+)
 
-func NewEventQueue(size int) (q *EventQueue) {
+func NewEventQueue(size int, enqueueTimeout time.Duration) (q *EventQueue) {
 	if size <= 0 {
 		size = DefaultQueueSize
 	}
+	if enqueueTimeout <= 0 {
+		enqueueTimeout = DefaultEnqueueTimeout
+	}
 
 	q = &EventQueue{
-		queue:  make(chan domain.Event, size),
-		closed: false,
+		queue:          make(chan domain.Event, size),
+		enqueueTimeout: enqueueTimeout,
+		closed:         false,
 	}
 	return
 }
@@ -35,14 +54,27 @@ func (q *EventQueue) Enqueue(ctx context.Context, event domain.Event) (err error
 		err = ErrQueueClosed
 		return
 	}
+	if q.draining {
+		err = ErrQueueDraining
+		return
+	}
 
 	select {
 	case q.queue <- event:
-	case <-ctx.Done():
-		err = ctx.Err()
 		return
+	default:
 	}
 
+	timer := time.NewTimer(q.enqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case q.queue <- event:
+	case <-timer.C:
+		err = ErrQueueFull
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
 	return
 }
 
@@ -70,3 +102,49 @@ func (q *EventQueue) Len() (length int) {
 	length = len(q.queue)
 	return
 }
+
+func (q *EventQueue) Cap() (capacity int) {
+	capacity = cap(q.queue)
+	return
+}
+
+func (q *EventQueue) SetDraining(draining bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.draining = draining
+}
+
+func (q *EventQueue) Stats() (stats QueueStats) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	stats = QueueStats{
+		Depth:    len(q.queue),
+		Capacity: cap(q.queue),
+		Closed:   q.closed,
+		Draining: q.draining,
+	}
+	return
+}
+
+func (q *EventQueue) HealthCheck(threshold float64) (check health.CheckFunc) {
+	check = func(ctx context.Context) (err error) {
+		stats := q.Stats()
+		if stats.Closed {
+			err = ErrQueueClosed
+			return
+		}
+		if stats.Draining {
+			err = ErrQueueDraining
+			return
+		}
+
+		ratio := float64(stats.Depth) / float64(stats.Capacity)
+		if ratio >= threshold {
+			err = fmt.Errorf("%w: %d/%d", ErrQueueSaturated, stats.Depth, stats.Capacity)
+		}
+		return
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func TestEventQueueEnqueueFull(t *testing.T) {
+	q := NewEventQueue(1, 20*time.Millisecond)
+
+	err := q.Enqueue(context.Background(), domain.Event{ID: "a"})
+	if err != nil {
+		t.Fatalf("Enqueue returned error: %v", err)
+	}
+
+	started := time.Now()
+	err = q.Enqueue(context.Background(), domain.Event{ID: "b"})
+	if !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("error = %v, want ErrQueueFull", err)
+	}
+	if waited := time.Since(started); waited < 20*time.Millisecond {
+		t.Errorf("waited %v, want at least the enqueue timeout", waited)
+	}
+}
+
+func TestEventQueueEnqueueWaitsForSpace(t *testing.T) {
+	q := NewEventQueue(1, time.Second)
+
+	err := q.Enqueue(context.Background(), domain.Event{ID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Dequeue(context.Background())
+	}()
+
+	err = q.Enqueue(context.Background(), domain.Event{ID: "b"})
+	if err != nil {
+		t.Fatalf("Enqueue returned error: %v", err)
+	}
+
+	event, _ := q.Dequeue(context.Background())
+	if event.ID != "b" {
+		t.Errorf("dequeued %q, want b", event.ID)
+	}
+}
+
+func TestEventQueueEnqueueContext(t *testing.T) {
+	q := NewEventQueue(1, time.Minute)
+
+	err := q.Enqueue(context.Background(), domain.Event{ID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = q.Enqueue(ctx, domain.Event{ID: "b"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestEventQueueEnqueueRejectsWhenDraining(t *testing.T) {
+	q := NewEventQueue(1, 0)
+
+	q.SetDraining(true)
+	err := q.Enqueue(context.Background(), domain.Event{ID: "a"})
+	if !errors.Is(err, ErrQueueDraining) {
+		t.Errorf("error = %v, want ErrQueueDraining", err)
+	}
+
+	q.Close()
+	err = q.Enqueue(context.Background(), domain.Event{ID: "a"})
+	if !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("error = %v, want ErrQueueClosed", err)
+	}
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var storeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func eventStores(t *testing.T) (stores map[string]domain.EventStore) {
+	t.Helper()
+
+	bolt, err := NewBoltEventStore(filepath.Join(t.TempDir(), "events.db"), nil)
+	if err != nil {
+		t.Fatalf("NewBoltEventStore returned error: %v", err)
+	}
+	t.Cleanup(func() {
+		bolt.Close()
+	})
+
+	stores = map[string]domain.EventStore{
+		"memory": NewMemoryEventStore(0),
+		"bolt":   bolt,
+	}
+	return
+}
+
+func testRecord(id string, minute int) (record domain.EventRecord) {
+	record = domain.EventRecord{
+		ID:        id,
+		Source:    "db",
+		EventType: "replication_lag",
+		Priority:  domain.PriorityHigh,
+		Message:   "replica is behind",
+		Timestamp: storeEpoch.Add(time.Duration(minute) * time.Minute),
+		Status:    domain.StatusAccepted,
+	}
+	return
+}
+
+func recordAll(t *testing.T, store domain.EventStore, records ...domain.EventRecord) {
+	t.Helper()
+
+	for _, record := range records {
+		err := store.Record(context.Background(), record)
+		if err != nil {
+			t.Fatalf("Record(%s) returned error: %v", record.ID, err)
+		}
+	}
+}
+
+func recordIDs(records []domain.EventRecord) (ids []string) {
+	ids = make([]string, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return
+}
+
+func TestEventStoreRecordAndUpdate(t *testing.T) {
+	for name, store := range eventStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			recordAll(t, store, testRecord("evt-1", 0))
+
+			deliveredAt := storeEpoch.Add(time.Minute)
+			err := store.UpdateStatus(ctx, "evt-1", domain.StatusUpdate{Status: domain.StatusDelivered, UpdatedAt: deliveredAt})
+			if err != nil {
+				t.Fatalf("UpdateStatus returned error: %v", err)
+			}
+
+			record, err := store.Get(ctx, "evt-1")
+			if err != nil {
+				t.Fatalf("Get returned error: %v", err)
+			}
+			if record.Status != domain.StatusDelivered || record.Attempts != 1 || record.DeliveredAt == nil || !record.DeliveredAt.Equal(deliveredAt) {
+				t.Errorf("record = %+v, want delivered once at %v", record, deliveredAt)
+			}
+
+			if _, err = store.Get(ctx, "missing"); !errors.Is(err, ErrEventNotFound) {
+				t.Errorf("Get(missing) error = %v, want %v", err, ErrEventNotFound)
+			}
+			err = store.UpdateStatus(ctx, "missing", domain.StatusUpdate{Status: domain.StatusFailed})
+			if !errors.Is(err, ErrEventNotFound) {
+				t.Errorf("UpdateStatus(missing) error = %v, want %v", err, ErrEventNotFound)
+			}
+
+			moved := testRecord("evt-1", 5)
+			moved.Message = "rerecorded"
+			recordAll(t, store, moved)
+
+			page, err := store.Query(ctx, domain.EventQuery{})
+			if err != nil {
+				t.Fatalf("Query returned error: %v", err)
+			}
+			if len(page.Records) != 1 || page.Records[0].Message != "rerecorded" || !page.Records[0].Timestamp.Equal(moved.Timestamp) {
+				t.Errorf("records = %+v, want the re-recorded event only", page.Records)
+			}
+		})
+	}
+}
+
+func TestEventStoreQueryFilters(t *testing.T) {
+	critical := domain.PriorityCritical
+
+	records := []domain.EventRecord{
+		testRecord("evt-1", 0),
+		testRecord("evt-2", 1),
+		testRecord("evt-3", 2),
+		testRecord("evt-4", 3),
+		testRecord("evt-5", 4),
+	}
+	records[0].Source = "web"
+	records[0].Metadata = map[string]interface{}{"region": "eu", "shard": 3}
+	records[1].Priority = domain.PriorityCritical
+	records[1].Destination = "pager"
+	records[1].DedupKey = "db-lag"
+	records[2].Status = domain.StatusSuppressed
+	records[2].SilenceID = "sil-1"
+	records[2].CorrelationID = "corr-1"
+	records[3].Status = domain.StatusSuppressed
+	records[3].InhibitedBy = "db-down"
+	records[3].Metadata = map[string]interface{}{"region": "us"}
+	records[4].EventType = "disk_full"
+
+	tests := []struct {
+		name  string
+		query domain.EventQuery
+		want  []string
+	}{
+		{name: "all newest first", query: domain.EventQuery{}, want: []string{"evt-5", "evt-4", "evt-3", "evt-2", "evt-1"}},
+		{name: "source", query: domain.EventQuery{Source: "web"}, want: []string{"evt-1"}},
+		{name: "event type", query: domain.EventQuery{EventType: "disk_full"}, want: []string{"evt-5"}},
+		{name: "priority", query: domain.EventQuery{Priority: &critical}, want: []string{"evt-2"}},
+		{name: "status", query: domain.EventQuery{Status: domain.StatusSuppressed}, want: []string{"evt-4", "evt-3"}},
+		{name: "correlation ID", query: domain.EventQuery{CorrelationID: "corr-1"}, want: []string{"evt-3"}},
+		{name: "destination", query: domain.EventQuery{Destination: "pager"}, want: []string{"evt-2"}},
+		{name: "dedup key", query: domain.EventQuery{DedupKey: "db-lag"}, want: []string{"evt-2"}},
+		{name: "silence", query: domain.EventQuery{SilenceID: "sil-1"}, want: []string{"evt-3"}},
+		{name: "inhibited by", query: domain.EventQuery{InhibitedBy: "db-down"}, want: []string{"evt-4"}},
+		{name: "metadata value", query: domain.EventQuery{Metadata: map[string]string{"region": "eu"}}, want: []string{"evt-1"}},
+		{name: "metadata non-string value", query: domain.EventQuery{Metadata: map[string]string{"shard": "3"}}, want: []string{"evt-1"}},
+		{name: "metadata key present", query: domain.EventQuery{Metadata: map[string]string{"region": ""}}, want: []string{"evt-4", "evt-1"}},
+		{name: "from is inclusive", query: domain.EventQuery{From: storeEpoch.Add(3 * time.Minute)}, want: []string{"evt-5", "evt-4"}},
+		{name: "to is exclusive", query: domain.EventQuery{To: storeEpoch.Add(2 * time.Minute)}, want: []string{"evt-2", "evt-1"}},
+		{name: "time window", query: domain.EventQuery{From: storeEpoch.Add(time.Minute), To: storeEpoch.Add(3 * time.Minute)}, want: []string{"evt-3", "evt-2"}},
+		{name: "combined filters", query: domain.EventQuery{Status: domain.StatusSuppressed, Metadata: map[string]string{"region": "us"}}, want: []string{"evt-4"}},
+		{name: "no match", query: domain.EventQuery{Source: "cron"}, want: []string{}},
+	}
+
+	for name, store := range eventStores(t) {
+		recordAll(t, store, records...)
+
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				page, err := store.Query(context.Background(), tt.query)
+				if err != nil {
+					t.Fatalf("Query returned error: %v", err)
+				}
+				if got := recordIDs(page.Records); !slices.Equal(got, tt.want) {
+					t.Errorf("records = %v, want %v", got, tt.want)
+				}
+				if page.NextCursor != "" {
+					t.Errorf("next cursor = %q, want none", page.NextCursor)
+				}
+			})
+		}
+	}
+}
+
+func TestEventStoreQueryPagination(t *testing.T) {
+	for name, store := range eventStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for minute, id := range []string{"evt-1", "evt-2", "evt-3", "evt-4", "evt-5", "evt-6", "evt-7"} {
+				recordAll(t, store, testRecord(id, minute))
+			}
+			sameTime := testRecord("evt-8", 6)
+			sameTime.Source = "web"
+			recordAll(t, store, sameTime)
+
+			var pages [][]string
+			query := domain.EventQuery{Limit: 3}
+			for {
+				page, err := store.Query(ctx, query)
+				if err != nil {
+					t.Fatalf("Query returned error: %v", err)
+				}
+				pages = append(pages, recordIDs(page.Records))
+				if page.NextCursor == "" {
+					break
+				}
+				if len(pages) > 5 {
+					t.Fatalf("pagination did not terminate: %v", pages)
+				}
+				query.Cursor = page.NextCursor
+			}
+
+			want := [][]string{{"evt-8", "evt-7", "evt-6"}, {"evt-5", "evt-4", "evt-3"}, {"evt-2", "evt-1"}}
+			if !slices.EqualFunc(pages, want, slices.Equal) {
+				t.Errorf("pages = %v, want %v", pages, want)
+			}
+
+			page, err := store.Query(ctx, domain.EventQuery{Limit: 2, Source: "db", To: storeEpoch.Add(5 * time.Minute)})
+			if err != nil {
+				t.Fatalf("Query returned error: %v", err)
+			}
+			page, err = store.Query(ctx, domain.EventQuery{Limit: 2, Source: "db", To: storeEpoch.Add(5 * time.Minute), Cursor: page.NextCursor})
+			if err != nil {
+				t.Fatalf("Query returned error: %v", err)
+			}
+			if got := recordIDs(page.Records); !slices.Equal(got, []string{"evt-3", "evt-2"}) {
+				t.Errorf("second filtered page = %v, want [evt-3 evt-2]", got)
+			}
+
+			for _, cursor := range []string{"not base64!", "c2hvcnQ"} {
+				if _, err = store.Query(ctx, domain.EventQuery{Cursor: cursor}); !errors.Is(err, ErrInvalidCursor) {
+					t.Errorf("Query with cursor %q error = %v, want %v", cursor, err, ErrInvalidCursor)
+				}
+			}
+		})
+	}
+}
+
+func TestEventStoreRetention(t *testing.T) {
+	for name, store := range eventStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			recordAll(t, store, testRecord("evt-1", 0), testRecord("evt-2", 1), testRecord("evt-3", 2), testRecord("evt-4", 3))
+
+			removed, err := ApplyRetention(ctx, store, storeEpoch.Add(2*time.Minute), testRetentionLogger())
+			if err != nil {
+				t.Fatalf("ApplyRetention returned error: %v", err)
+			}
+			if removed != 2 {
+				t.Errorf("removed = %d, want 2", removed)
+			}
+
+			for _, id := range []string{"evt-1", "evt-2"} {
+				if _, err = store.Get(ctx, id); !errors.Is(err, ErrEventNotFound) {
+					t.Errorf("Get(%s) error = %v, want %v", id, err, ErrEventNotFound)
+				}
+			}
+
+			page, err := store.Query(ctx, domain.EventQuery{})
+			if err != nil {
+				t.Fatalf("Query returned error: %v", err)
+			}
+			if got := recordIDs(page.Records); !slices.Equal(got, []string{"evt-4", "evt-3"}) {
+				t.Errorf("records after retention = %v, want [evt-4 evt-3]", got)
+			}
+
+			removed, err = ApplyRetention(ctx, store, storeEpoch.Add(2*time.Minute), testRetentionLogger())
+			if err != nil || removed != 0 {
+				t.Errorf("second retention removed %d, err %v, want nothing", removed, err)
+			}
+
+			recordAll(t, store, testRecord("evt-1", 10))
+			if _, err = store.Get(ctx, "evt-1"); err != nil {
+				t.Errorf("Get of re-recorded purged ID returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMemoryEventStoreEvictsOldest(t *testing.T) {
+	store := NewMemoryEventStore(2)
+	recordAll(t, store, testRecord("evt-2", 1), testRecord("evt-1", 0), testRecord("evt-3", 2))
+
+	page, err := store.Query(context.Background(), domain.EventQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if got := recordIDs(page.Records); !slices.Equal(got, []string{"evt-3", "evt-2"}) {
+		t.Errorf("records = %v, want [evt-3 evt-2]", got)
+	}
+	if _, err = store.Get(context.Background(), "evt-1"); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("Get(evt-1) error = %v, want %v", err, ErrEventNotFound)
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type MemoryEscalationStore struct {
+	mu          sync.RWMutex
+	escalations map[string]domain.Escalation
+}
+
+func NewMemoryEscalationStore() (store *MemoryEscalationStore) {
+	store = &MemoryEscalationStore{escalations: make(map[string]domain.Escalation)}
+	return
+}
+
+func (s *MemoryEscalationStore) Save(ctx context.Context, escalation domain.Escalation) (err error) {
+	s.mu.Lock()
+	s.escalations[escalation.DedupKey] = escalation
+	s.mu.Unlock()
+	return
+}
+
+func (s *MemoryEscalationStore) Delete(ctx context.Context, dedupKey string) (err error) {
+	s.mu.Lock()
+	delete(s.escalations, dedupKey)
+	s.mu.Unlock()
+	return
+}
+
+func (s *MemoryEscalationStore) List(ctx context.Context) (escalations []domain.Escalation, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	escalations = make([]domain.Escalation, 0, len(s.escalations))
+	for _, escalation := range s.escalations {
+		escalations = append(escalations, escalation)
+	}
+	return
+}
+
+func (s *MemoryEscalationStore) Close() (err error) {
+	return
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type MemoryEventStore struct {
+	mu         sync.RWMutex
+	records    map[string]domain.EventRecord
+	keys       [][]byte
+	maxRecords int
+}
+
+const DefaultMemoryStoreSize = 100000
+
+func NewMemoryEventStore(maxRecords int) (store *MemoryEventStore) {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMemoryStoreSize
+	}
+
+	store = &MemoryEventStore{
+		records:    make(map[string]domain.EventRecord),
+		maxRecords: maxRecords,
+	}
+	return
+}
+
+func (s *MemoryEventStore) Record(ctx context.Context, record domain.EventRecord) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[record.ID]; ok {
+		s.removeKeyLocked(recordKey(existing.Timestamp, existing.ID))
+	}
+
+	key := recordKey(record.Timestamp, record.ID)
+	index := sort.Search(len(s.keys), func(i int) bool {
+		return bytes.Compare(s.keys[i], key) >= 0
+	})
+	s.keys = append(s.keys, nil)
+	copy(s.keys[index+1:], s.keys[index:])
+	s.keys[index] = key
+	s.records[record.ID] = record
+
+	for len(s.keys) > s.maxRecords {
+		delete(s.records, string(s.keys[0][8:]))
+		s.keys = s.keys[1:]
+	}
+	return
+}
+
+func (s *MemoryEventStore) UpdateStatus(ctx context.Context, id string, update domain.StatusUpdate) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrEventNotFound, id)
+		return
+	}
+
+	record.Apply(update)
+	s.records[id] = record
+	return
+}
+
+func (s *MemoryEventStore) Get(ctx context.Context, id string) (record domain.EventRecord, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrEventNotFound, id)
+	}
+	return
+}
+
+func (s *MemoryEventStore) Query(ctx context.Context, query domain.EventQuery) (page domain.EventPage, err error) {
+	var upper []byte
+	upper, err = queryUpperBound(query)
+	if err != nil {
+		return
+	}
+
+	limit := queryLimit(query)
+	page.Records = make([]domain.EventRecord, 0)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	end := len(s.keys)
+	if upper != nil {
+		end = sort.Search(len(s.keys), func(i int) bool {
+			return bytes.Compare(s.keys[i], upper) >= 0
+		})
+	}
+
+	for i := end - 1; i >= 0; i-- {
+		record := s.records[string(s.keys[i][8:])]
+		if !query.From.IsZero() && record.Timestamp.Before(query.From) {
+			break
+		}
+		if !query.Matches(record) {
+			continue
+		}
+
+		if len(page.Records) == limit {
+			page.NextCursor = encodeCursor(recordKey(page.Records[limit-1].Timestamp, page.Records[limit-1].ID))
+			break
+		}
+		page.Records = append(page.Records, record)
+	}
+	return
+}
+
+func (s *MemoryEventStore) Purge(ctx context.Context, before time.Time) (removed int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := timeKey(before)
+	removed = sort.Search(len(s.keys), func(i int) bool {
+		return bytes.Compare(s.keys[i], cutoff) >= 0
+	})
+	for _, key := range s.keys[:removed] {
+		delete(s.records, string(key[8:]))
+	}
+	s.keys = append([][]byte(nil), s.keys[removed:]...)
+	return
+}
+
+func (s *MemoryEventStore) Close() (err error) {
+	return
+}
+
+func (s *MemoryEventStore) removeKeyLocked(key []byte) {
+	index := sort.Search(len(s.keys), func(i int) bool {
+		return bytes.Compare(s.keys[i], key) >= 0
+	})
+	if index < len(s.keys) && bytes.Equal(s.keys[index], key) {
+		s.keys = append(s.keys[:index], s.keys[index+1:]...)
+	}
+}
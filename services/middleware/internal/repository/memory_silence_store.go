@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type MemorySilenceStore struct {
+	mu       sync.RWMutex
+	silences map[string]domain.Silence
+}
+
+func NewMemorySilenceStore() (store *MemorySilenceStore) {
+	store = &MemorySilenceStore{silences: make(map[string]domain.Silence)}
+	return
+}
+
+func (s *MemorySilenceStore) Save(ctx context.Context, silence domain.Silence) (err error) {
+	s.mu.Lock()
+	s.silences[silence.ID] = silence
+	s.mu.Unlock()
+	return
+}
+
+func (s *MemorySilenceStore) Delete(ctx context.Context, id string) (err error) {
+	s.mu.Lock()
+	delete(s.silences, id)
+	s.mu.Unlock()
+	return
+}
+
+func (s *MemorySilenceStore) List(ctx context.Context) (silences []domain.Silence, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	silences = make([]domain.Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		silences = append(silences, silence)
+	}
+	return
+}
+
+func (s *MemorySilenceStore) Close() (err error) {
+	return
+}
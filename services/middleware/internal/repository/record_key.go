@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+func recordKey(timestamp time.Time, id string) (key []byte) {
+	key = make([]byte, 8, 8+len(id))
+	binary.BigEndian.PutUint64(key, uint64(timestamp.UnixNano()))
+	key = append(key, id...)
+	return
+}
+
+func timeKey(timestamp time.Time) (key []byte) {
+	key = recordKey(timestamp, "")
+	return
+}
+
+func encodeCursor(key []byte) (cursor string) {
+	cursor = base64.RawURLEncoding.EncodeToString(key)
+	return
+}
+
+func decodeCursor(cursor string) (key []byte, err error) {
+	key, err = base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(key) < 8 {
+		err = ErrInvalidCursor
+	}
+	return
+}
+
+func queryLimit(query domain.EventQuery) (limit int) {
+	limit = query.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	if limit > MaxQueryLimit {
+		limit = MaxQueryLimit
+	}
+	return
+}
+
+func queryUpperBound(query domain.EventQuery) (upper []byte, err error) {
+	if query.Cursor != "" {
+		upper, err = decodeCursor(query.Cursor)
+		if err != nil {
+			return
+		}
+	}
+
+	if !query.To.IsZero() {
+		toKey := timeKey(query.To)
+		if upper == nil || bytes.Compare(toKey, upper) < 0 {
+			upper = toKey
+		}
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type RetentionConfig struct {
+	Period          time.Duration
+	Interval        time.Duration
+	CompactInterval time.Duration
+}
+
+type RetentionLogger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+type compactor interface {
+	Compact(ctx context.Context) (err error)
+}
+
+func StartRetention(ctx context.Context, store domain.EventStore, cfg RetentionConfig, logger RetentionLogger) {
+	if c, ok := store.(compactor); ok && cfg.CompactInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.CompactInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+
+				applyCompaction(ctx, c, logger)
+			}
+		}()
+	}
+
+	if cfg.Period <= 0 || cfg.Interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			ApplyRetention(ctx, store, time.Now().Add(-cfg.Period), logger)
+		}
+	}()
+}
+
+func ApplyRetention(ctx context.Context, store domain.EventStore, before time.Time, logger RetentionLogger) (removed int, err error) {
+	removed, err = store.Purge(ctx, before)
+	if err != nil {
+		logger.ErrorContext(ctx, "event store retention failed", "error", err.Error())
+		return
+	}
+	if removed == 0 {
+		return
+	}
+
+	logger.InfoContext(ctx, "event store retention applied",
+		"removed", removed,
+		"before", before.UTC().Format(time.RFC3339),
+	)
+	return
+}
+
+func applyCompaction(ctx context.Context, store compactor, logger RetentionLogger) (err error) {
+	start := time.Now()
+	err = store.Compact(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "event store compaction failed", "error", err.Error())
+		return
+	}
+
+	logger.InfoContext(ctx, "event store compacted", "duration", time.Since(start).String())
+	return
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/logger"
+)
+
+func testRetentionLogger() (log *logger.Logger) {
+	log = logger.New(io.Discard, slog.LevelError)
+	return
+}
+
+type compactingStore struct {
+	*MemoryEventStore
+
+	purges      atomic.Int64
+	compactions atomic.Int64
+}
+
+func (s *compactingStore) Purge(ctx context.Context, before time.Time) (removed int, err error) {
+	s.purges.Add(1)
+	removed, err = s.MemoryEventStore.Purge(ctx, before)
+	return
+}
+
+func (s *compactingStore) Compact(ctx context.Context) (err error) {
+	s.compactions.Add(1)
+	return
+}
+
+func TestApplyRetentionDoesNotCompact(t *testing.T) {
+	store := &compactingStore{MemoryEventStore: NewMemoryEventStore(0)}
+	recordAll(t, store, testRecord("evt-1", 0))
+
+	removed, err := ApplyRetention(context.Background(), store, storeEpoch.Add(time.Minute), testRetentionLogger())
+	if err != nil || removed != 1 {
+		t.Fatalf("ApplyRetention removed %d, err %v, want 1", removed, err)
+	}
+	if compactions := store.compactions.Load(); compactions != 0 {
+		t.Errorf("compactions = %d, want 0", compactions)
+	}
+}
+
+func TestStartRetentionSchedulesCompactionSeparately(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             RetentionConfig
+		wantPurges      bool
+		wantCompactions bool
+	}{
+		{name: "compaction only", cfg: RetentionConfig{CompactInterval: 5 * time.Millisecond}, wantCompactions: true},
+		{name: "retention only", cfg: RetentionConfig{Period: time.Hour, Interval: 5 * time.Millisecond}, wantPurges: true},
+		{name: "both", cfg: RetentionConfig{Period: time.Hour, Interval: 5 * time.Millisecond, CompactInterval: 5 * time.Millisecond}, wantPurges: true, wantCompactions: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &compactingStore{MemoryEventStore: NewMemoryEventStore(0)}
+			ctx, cancel := context.WithCancel(context.Background())
+			StartRetention(ctx, store, tt.cfg, testRetentionLogger())
+
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+
+			if purged := store.purges.Load() > 0; purged != tt.wantPurges {
+				t.Errorf("purged = %v, want %v", purged, tt.wantPurges)
+			}
+			if compacted := store.compactions.Load() > 0; compacted != tt.wantCompactions {
+				t.Errorf("compacted = %v, want %v", compacted, tt.wantCompactions)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/correlation"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const (
+	correlationHeader     = "X-Correlation-ID"
+	deadLetterErrorHeader = "X-Dead-Letter-Error"
+)
+
+const (
+	DefaultKafkaGroup        = "middleware"
+	DefaultKafkaRetryBackoff = 500 * time.Millisecond
+	maxKafkaRetryBackoff     = 30 * time.Second
+)
+
+type KafkaConfig struct {
+	Brokers         []string
+	Topic           string
+	Group           string
+	ClientID        string
+	RetryBackoff    time.Duration
+	DeadLetterTopic string
+}
+
+type KafkaSource struct {
+	cfg      KafkaConfig
+	client   *kgo.Client
+	ingestor Ingestor
+	logger   SourceLogger
+}
+
+func NewKafkaSource(cfg KafkaConfig, ingestor Ingestor, logger SourceLogger) (src *KafkaSource, err error) {
+	if len(cfg.Brokers) == 0 || cfg.Topic == "" {
+		err = errors.New("kafka source requires brokers and a topic")
+		return
+	}
+	if cfg.Group == "" {
+		cfg.Group = DefaultKafkaGroup
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultKafkaRetryBackoff
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.ConsumerGroup(cfg.Group),
+		kgo.ConsumeTopics(cfg.Topic),
+		kgo.DisableAutoCommit(),
+		kgo.BlockRebalanceOnPoll(),
+	}
+	if cfg.ClientID != "" {
+		opts = append(opts, kgo.ClientID(cfg.ClientID))
+	}
+
+	var client *kgo.Client
+	client, err = kgo.NewClient(opts...)
+	if err != nil {
+		err = fmt.Errorf("failed to create kafka client: %w", err)
+		return
+	}
+
+	src = &KafkaSource{
+		cfg:      cfg,
+		client:   client,
+		ingestor: ingestor,
+		logger:   logger,
+	}
+	return
+}
+
+func (s *KafkaSource) Name() (name string) {
+	name = "kafka:" + s.cfg.Topic
+	return
+}
+
+func (s *KafkaSource) Check(ctx context.Context) (err error) {
+	err = s.client.Ping(ctx)
+	return
+}
+
+func (s *KafkaSource) Run(ctx context.Context) (err error) {
+	defer s.client.Close()
+
+	for {
+		fetches := s.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			s.client.AllowRebalance()
+			return
+		}
+
+		fetches.EachError(func(topic string, partition int32, fetchErr error) {
+			s.logger.ErrorContext(ctx, "kafka fetch failed",
+				"topic", topic,
+				"partition", partition,
+				"error", fetchErr.Error(),
+			)
+		})
+
+		var committable []*kgo.Record
+		fetches.EachRecord(func(record *kgo.Record) {
+			if ctx.Err() != nil {
+				return
+			}
+			if s.ingestRecord(ctx, record) {
+				committable = append(committable, record)
+			}
+		})
+
+		if len(committable) > 0 {
+			commitErr := s.client.CommitRecords(context.WithoutCancel(ctx), committable...)
+			if commitErr != nil {
+				s.logger.ErrorContext(ctx, "failed to commit kafka offsets", "error", commitErr.Error())
+			}
+		}
+
+		s.client.AllowRebalance()
+	}
+}
+
+func (s *KafkaSource) ingestRecord(ctx context.Context, record *kgo.Record) (committable bool) {
+	var incoming domain.IncomingEvent
+	err := json.Unmarshal(record.Value, &incoming)
+	if err == nil {
+		err = incoming.Validate()
+	}
+	if err != nil {
+		committable = s.deadLetter(ctx, record, err)
+		return
+	}
+
+	correlationID := recordHeader(record, correlationHeader)
+	if correlationID == "" {
+		correlationID, err = correlation.GenerateID()
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to generate correlation ID", "error", err.Error())
+			return
+		}
+	}
+
+	recordCtx := correlation.WithID(ctx, correlationID)
+	backoff := s.cfg.RetryBackoff
+
+	for {
+		var event domain.Event
+		event, err = s.ingestor.Ingest(recordCtx, incoming, correlationID)
+		if err == nil {
+			s.logger.InfoContext(recordCtx, "event accepted",
+				"event_id", event.ID,
+				"source", event.Source,
+				"type", event.EventType,
+				"ingestion_source", s.Name(),
+				"partition", record.Partition,
+				"offset", record.Offset,
+			)
+			committable = true
+			return
+		}
+		if errors.Is(err, usecase.ErrSuppressed) {
+			s.logger.InfoContext(recordCtx, "event suppressed",
+				"event_id", event.ID,
+				"partition", record.Partition,
+				"offset", record.Offset,
+				"reason", err.Error(),
+			)
+			committable = true
+			return
+		}
+
+		if !retryable(err) {
+			committable = s.deadLetter(recordCtx, record, err)
+			return
+		}
+
+		s.logger.ErrorContext(recordCtx, "failed to ingest kafka record, retrying",
+			"partition", record.Partition,
+			"offset", record.Offset,
+			"backoff", backoff.String(),
+			"error", err.Error(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxKafkaRetryBackoff)
+	}
+}
+
+func (s *KafkaSource) deadLetter(ctx context.Context, record *kgo.Record, cause error) (committable bool) {
+	if s.cfg.DeadLetterTopic == "" {
+		s.logger.ErrorContext(ctx, "skipping kafka record",
+			"topic", record.Topic,
+			"partition", record.Partition,
+			"offset", record.Offset,
+			"error", cause.Error(),
+		)
+		committable = true
+		return
+	}
+
+	deadLetter := &kgo.Record{
+		Topic:   s.cfg.DeadLetterTopic,
+		Key:     record.Key,
+		Value:   record.Value,
+		Headers: append(slices.Clone(record.Headers), kgo.RecordHeader{Key: deadLetterErrorHeader, Value: []byte(cause.Error())}),
+	}
+
+	backoff := s.cfg.RetryBackoff
+	for {
+		err := s.client.ProduceSync(ctx, deadLetter).FirstErr()
+		if err == nil {
+			break
+		}
+
+		s.logger.ErrorContext(ctx, "failed to dead-letter kafka record, retrying",
+			"topic", record.Topic,
+			"partition", record.Partition,
+			"offset", record.Offset,
+			"backoff", backoff.String(),
+			"error", err.Error(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxKafkaRetryBackoff)
+	}
+
+	s.logger.ErrorContext(ctx, "kafka record moved to dead-letter topic",
+		"topic", record.Topic,
+		"partition", record.Partition,
+		"offset", record.Offset,
+		"dead_letter_topic", s.cfg.DeadLetterTopic,
+		"error", cause.Error(),
+	)
+	committable = true
+	return
+}
+
+func retryable(err error) (retry bool) {
+	retry = errors.Is(err, repository.ErrQueueFull) ||
+		errors.Is(err, repository.ErrQueueDraining) ||
+		errors.Is(err, repository.ErrQueueClosed) ||
+		errors.Is(err, context.DeadlineExceeded)
+	return
+}
+
+func recordHeader(record *kgo.Record, key string) (value string) {
+	for _, header := range record.Headers {
+		if strings.EqualFold(header.Key, key) {
+			value = string(header.Value)
+			return
+		}
+	}
+	return
+}
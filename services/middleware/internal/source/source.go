@@ -0,0 +1,64 @@
+package source
+
+import (
+	"context"
+	"sync"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type Source interface {
+	Name() (name string)
+	Run(ctx context.Context) (err error)
+	Check(ctx context.Context) (err error)
+}
+
+type Ingestor interface {
+	Ingest(ctx context.Context, incoming domain.IncomingEvent, correlationID string) (event domain.Event, err error)
+}
+
+type SourceLogger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+type Group struct {
+	sources []Source
+	logger  SourceLogger
+	wg      sync.WaitGroup
+}
+
+func NewGroup(logger SourceLogger, sources ...Source) (group *Group) {
+	group = &Group{
+		sources: sources,
+		logger:  logger,
+	}
+	return
+}
+
+func (g *Group) Sources() (sources []Source) {
+	sources = g.sources
+	return
+}
+
+func (g *Group) Start(ctx context.Context, errs chan<- error) {
+	for _, src := range g.sources {
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+
+			g.logger.InfoContext(ctx, "ingestion source started", "source", src.Name())
+			err := src.Run(ctx)
+			if err != nil && ctx.Err() == nil {
+				g.logger.ErrorContext(ctx, "ingestion source stopped", "source", src.Name(), "error", err.Error())
+				errs <- err
+				return
+			}
+			g.logger.InfoContext(ctx, "ingestion source shutting down", "source", src.Name())
+		}()
+	}
+}
+
+func (g *Group) Wait() {
+	g.wg.Wait()
+}
@@ -0,0 +1,409 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/correlation"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/usecase"
+	"golang.org/x/time/rate"
+)
+
+var ErrListenerNotReady = errors.New("listener is not accepting messages")
+
+const (
+	MaxSyslogMessageSize  = 64 * 1024
+	syslogIdleTimeout     = 5 * time.Minute
+	syslogDropLogInterval = 30 * time.Second
+	unknownSyslogHost     = "unknown"
+	defaultSyslogAppName  = "syslog"
+)
+
+type SyslogConfig struct {
+	Network   string
+	Address   string
+	TLS       *tls.Config
+	RateLimit float64
+	Burst     int
+}
+
+type SyslogSource struct {
+	cfg      SyslogConfig
+	ingestor Ingestor
+	logger   SourceLogger
+	limiter  *rate.Limiter
+
+	mu       sync.RWMutex
+	addr     net.Addr
+	listener io.Closer
+
+	dropped atomic.Int64
+	invalid atomic.Int64
+}
+
+func NewSyslogSource(cfg SyslogConfig, ingestor Ingestor, logger SourceLogger) (src *SyslogSource, err error) {
+	switch cfg.Network {
+	case "udp", "tcp":
+	default:
+		err = fmt.Errorf("unsupported syslog network: %s", cfg.Network)
+		return
+	}
+
+	limit := rate.Inf
+	if cfg.RateLimit > 0 {
+		limit = rate.Limit(cfg.RateLimit)
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = max(1, int(cfg.RateLimit))
+	}
+
+	src = &SyslogSource{
+		cfg:      cfg,
+		ingestor: ingestor,
+		logger:   logger,
+		limiter:  rate.NewLimiter(limit, cfg.Burst),
+	}
+	return
+}
+
+func (s *SyslogSource) Name() (name string) {
+	network := s.cfg.Network
+	if s.cfg.TLS != nil {
+		network = "tls"
+	}
+	name = "syslog:" + network + ":" + s.cfg.Address
+	return
+}
+
+func (s *SyslogSource) Addr() (addr net.Addr) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addr = s.addr
+	return
+}
+
+func (s *SyslogSource) Check(ctx context.Context) (err error) {
+	if s.Addr() == nil {
+		err = ErrListenerNotReady
+	}
+	return
+}
+
+func (s *SyslogSource) Run(ctx context.Context) (err error) {
+	go s.reportDrops(ctx)
+
+	if s.cfg.Network == "udp" {
+		err = s.runUDP(ctx)
+		return
+	}
+	err = s.runTCP(ctx)
+	return
+}
+
+func (s *SyslogSource) runUDP(ctx context.Context) (err error) {
+	var conn net.PacketConn
+	conn, err = net.ListenPacket("udp", s.cfg.Address)
+	if err != nil {
+		err = fmt.Errorf("failed to listen for syslog on udp %s: %w", s.cfg.Address, err)
+		return
+	}
+	s.setListener(conn.LocalAddr(), conn)
+	defer s.closeListener()
+
+	go func() {
+		<-ctx.Done()
+		s.closeListener()
+	}()
+
+	buffer := make([]byte, MaxSyslogMessageSize)
+	for {
+		var n int
+		var remote net.Addr
+		n, remote, err = conn.ReadFrom(buffer)
+		if err != nil {
+			if ctx.Err() != nil {
+				err = nil
+			}
+			return
+		}
+
+		s.handle(ctx, buffer[:n], remote)
+	}
+}
+
+func (s *SyslogSource) runTCP(ctx context.Context) (err error) {
+	var listener net.Listener
+	if s.cfg.TLS != nil {
+		listener, err = tls.Listen("tcp", s.cfg.Address, s.cfg.TLS)
+	} else {
+		listener, err = net.Listen("tcp", s.cfg.Address)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to listen for syslog on %s: %w", s.Name(), err)
+		return
+	}
+	s.setListener(listener.Addr(), listener)
+	defer s.closeListener()
+
+	go func() {
+		<-ctx.Done()
+		s.closeListener()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		var conn net.Conn
+		conn, err = listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				err = nil
+			}
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+func (s *SyslogSource) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	reader := bufio.NewReaderSize(conn, MaxSyslogMessageSize)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(syslogIdleTimeout))
+
+		frame, err := readSyslogFrame(reader)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				s.logger.ErrorContext(ctx, "syslog connection closed",
+					"listener", s.Name(),
+					"remote_addr", conn.RemoteAddr().String(),
+					"error", err.Error(),
+				)
+			}
+			return
+		}
+
+		if len(frame) > 0 {
+			s.handle(ctx, frame, conn.RemoteAddr())
+		}
+	}
+}
+
+func readSyslogFrame(reader *bufio.Reader) (frame []byte, err error) {
+	var first []byte
+	first, err = reader.Peek(1)
+	if err != nil {
+		return
+	}
+
+	if first[0] >= '1' && first[0] <= '9' {
+		var length string
+		length, err = reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+
+		var size int
+		size, err = strconv.Atoi(length[:len(length)-1])
+		if err != nil || size > MaxSyslogMessageSize {
+			err = fmt.Errorf("%w: invalid octet count %q", ErrInvalidSyslog, length)
+			return
+		}
+
+		frame = make([]byte, size)
+		_, err = io.ReadFull(reader, frame)
+		return
+	}
+
+	frame, err = reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		err = fmt.Errorf("%w: message exceeds %d bytes", ErrInvalidSyslog, MaxSyslogMessageSize)
+		return
+	}
+	if errors.Is(err, io.EOF) && len(frame) > 0 {
+		err = nil
+	}
+	return
+}
+
+func (s *SyslogSource) handle(ctx context.Context, data []byte, remote net.Addr) {
+	if !s.limiter.Allow() {
+		s.dropped.Add(1)
+		return
+	}
+
+	msg, err := ParseSyslog(data, time.Now())
+	if err != nil {
+		s.invalid.Add(1)
+		s.logger.ErrorContext(ctx, "skipping invalid syslog message",
+			"listener", s.Name(),
+			"remote_addr", remote.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	incoming := SyslogToIncoming(msg, remote)
+
+	var correlationID string
+	correlationID, err = correlation.GenerateID()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to generate correlation ID", "error", err.Error())
+		return
+	}
+
+	msgCtx := correlation.WithID(ctx, correlationID)
+
+	var event domain.Event
+	event, err = s.ingestor.Ingest(msgCtx, incoming, correlationID)
+	if errors.Is(err, usecase.ErrSuppressed) {
+		s.logger.InfoContext(msgCtx, "event suppressed",
+			"event_id", event.ID,
+			"listener", s.Name(),
+			"reason", err.Error(),
+		)
+		return
+	}
+	if err != nil {
+		s.logger.ErrorContext(msgCtx, "failed to ingest syslog message",
+			"listener", s.Name(),
+			"remote_addr", remote.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	s.logger.InfoContext(msgCtx, "event accepted",
+		"event_id", event.ID,
+		"source", event.Source,
+		"type", event.EventType,
+		"ingestion_source", s.Name(),
+	)
+}
+
+func SyslogToIncoming(msg SyslogMessage, remote net.Addr) (incoming domain.IncomingEvent) {
+	incoming = domain.IncomingEvent{
+		Source:    msg.Hostname,
+		EventType: msg.AppName,
+		Severity:  syslogSeverityToLevel(msg.Severity),
+		Message:   msg.Message,
+		Metadata: map[string]interface{}{
+			"syslog_format":   msg.Format,
+			"syslog_facility": msg.FacilityName(),
+			"syslog_severity": msg.SeverityName(),
+		},
+	}
+
+	if incoming.Source == "" && remote != nil {
+		host, _, err := net.SplitHostPort(remote.String())
+		if err == nil {
+			incoming.Source = host
+		}
+	}
+	if incoming.Source == "" {
+		incoming.Source = unknownSyslogHost
+	}
+	if incoming.EventType == "" {
+		incoming.EventType = defaultSyslogAppName
+	}
+	if incoming.Message == "" {
+		incoming.Message = "(empty syslog message)"
+	}
+
+	if remote != nil {
+		incoming.Metadata["remote_addr"] = remote.String()
+	}
+	if !msg.Timestamp.IsZero() {
+		incoming.Metadata["syslog_timestamp"] = msg.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	if msg.ProcID != "" {
+		incoming.Metadata["syslog_procid"] = msg.ProcID
+	}
+	if msg.MsgID != "" {
+		incoming.Metadata["syslog_msgid"] = msg.MsgID
+	}
+	for id, params := range msg.StructuredData {
+		for name, value := range params {
+			incoming.Metadata[id+"."+name] = value
+		}
+	}
+	return
+}
+
+func syslogSeverityToLevel(severity int) (level string) {
+	switch {
+	case severity <= 2:
+		level = "critical"
+	case severity == 3:
+		level = "error"
+	case severity == 4:
+		level = "warning"
+	default:
+		level = "low"
+	}
+	return
+}
+
+func (s *SyslogSource) setListener(addr net.Addr, listener io.Closer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addr = addr
+	s.listener = listener
+}
+
+func (s *SyslogSource) closeListener() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+		s.addr = nil
+	}
+}
+
+func (s *SyslogSource) reportDrops(ctx context.Context) {
+	ticker := time.NewTicker(syslogDropLogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		dropped := s.dropped.Swap(0)
+		invalid := s.invalid.Swap(0)
+		if dropped > 0 || invalid > 0 {
+			s.logger.ErrorContext(ctx, "syslog messages discarded",
+				"listener", s.Name(),
+				"rate_limited", dropped,
+				"invalid", invalid,
+				"interval", syslogDropLogInterval.String(),
+			)
+		}
+	}
+}
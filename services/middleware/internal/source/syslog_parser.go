@@ -0,0 +1,250 @@
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSyslog = errors.New("invalid syslog message")
+
+const (
+	SyslogFormatRFC5424 = "rfc5424"
+	SyslogFormatRFC3164 = "rfc3164"
+
+	syslogNil          = "-"
+	rfc3164TimeLayout  = "Jan _2 15:04:05"
+	rfc3164TimeLength  = len(rfc3164TimeLayout)
+	maxRFC3164TagChars = 32
+)
+
+var syslogFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+var syslogSeverities = []string{
+	"emergency", "alert", "critical", "error", "warning", "notice", "informational", "debug",
+}
+
+type SyslogMessage struct {
+	Format         string
+	Facility       int
+	Severity       int
+	Timestamp      time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData map[string]map[string]string
+	Message        string
+}
+
+func (m SyslogMessage) FacilityName() (name string) {
+	name = strconv.Itoa(m.Facility)
+	if m.Facility >= 0 && m.Facility < len(syslogFacilities) {
+		name = syslogFacilities[m.Facility]
+	}
+	return
+}
+
+func (m SyslogMessage) SeverityName() (name string) {
+	name = strconv.Itoa(m.Severity)
+	if m.Severity >= 0 && m.Severity < len(syslogSeverities) {
+		name = syslogSeverities[m.Severity]
+	}
+	return
+}
+
+func ParseSyslog(data []byte, received time.Time) (msg SyslogMessage, err error) {
+	line := strings.TrimRight(string(data), "\r\n\x00")
+
+	var rest string
+	msg.Facility, msg.Severity, rest, err = parsePriority(line)
+	if err != nil {
+		return
+	}
+
+	if strings.HasPrefix(rest, "1 ") {
+		err = parseRFC5424(&msg, rest[2:])
+		return
+	}
+
+	parseRFC3164(&msg, rest, received)
+	return
+}
+
+func parsePriority(line string) (facility, severity int, rest string, err error) {
+	end := strings.IndexByte(line, '>')
+	if !strings.HasPrefix(line, "<") || end < 2 || end > 4 {
+		err = fmt.Errorf("%w: missing priority", ErrInvalidSyslog)
+		return
+	}
+
+	var pri int
+	pri, err = strconv.Atoi(line[1:end])
+	if err != nil || pri < 0 || pri > 191 {
+		err = fmt.Errorf("%w: invalid priority %q", ErrInvalidSyslog, line[1:end])
+		return
+	}
+
+	facility = pri / 8
+	severity = pri % 8
+	rest = line[end+1:]
+	return
+}
+
+func parseRFC5424(msg *SyslogMessage, rest string) (err error) {
+	msg.Format = SyslogFormatRFC5424
+
+	fields := strings.SplitN(rest, " ", 6)
+	if len(fields) < 5 {
+		err = fmt.Errorf("%w: truncated RFC 5424 header", ErrInvalidSyslog)
+		return
+	}
+
+	if fields[0] != syslogNil {
+		msg.Timestamp, err = time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			err = fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSyslog, fields[0])
+			return
+		}
+	}
+	msg.Hostname = nilValue(fields[1])
+	msg.AppName = nilValue(fields[2])
+	msg.ProcID = nilValue(fields[3])
+	msg.MsgID = nilValue(fields[4])
+
+	if len(fields) < 6 {
+		return
+	}
+
+	rest = fields[5]
+	if strings.HasPrefix(rest, syslogNil) {
+		rest = rest[1:]
+	} else {
+		msg.StructuredData, rest, err = parseStructuredData(rest)
+		if err != nil {
+			return
+		}
+	}
+
+	msg.Message = strings.TrimPrefix(strings.TrimPrefix(rest, " "), "\ufeff")
+	return
+}
+
+func parseStructuredData(input string) (data map[string]map[string]string, rest string, err error) {
+	data = make(map[string]map[string]string)
+	rest = input
+
+	for strings.HasPrefix(rest, "[") {
+		end := strings.IndexAny(rest, " ]")
+		if end < 0 {
+			err = fmt.Errorf("%w: unterminated structured data", ErrInvalidSyslog)
+			return
+		}
+
+		id := rest[1:end]
+		params := make(map[string]string)
+		rest = rest[end:]
+
+		for strings.HasPrefix(rest, " ") {
+			rest = rest[1:]
+
+			eq := strings.Index(rest, "=\"")
+			if eq < 1 {
+				err = fmt.Errorf("%w: invalid structured data parameter in %s", ErrInvalidSyslog, id)
+				return
+			}
+			name := rest[:eq]
+			rest = rest[eq+2:]
+
+			var value bytes.Buffer
+			closed := false
+			for i := 0; i < len(rest); i++ {
+				switch {
+				case rest[i] == '\\' && i+1 < len(rest) && strings.IndexByte(`"\]`, rest[i+1]) >= 0:
+					i++
+					value.WriteByte(rest[i])
+				case rest[i] == '"':
+					closed = true
+					rest = rest[i+1:]
+				default:
+					value.WriteByte(rest[i])
+				}
+				if closed {
+					break
+				}
+			}
+			if !closed {
+				err = fmt.Errorf("%w: unterminated parameter value in %s", ErrInvalidSyslog, id)
+				return
+			}
+
+			params[name] = value.String()
+		}
+
+		if !strings.HasPrefix(rest, "]") {
+			err = fmt.Errorf("%w: unterminated structured data element %s", ErrInvalidSyslog, id)
+			return
+		}
+		rest = rest[1:]
+		data[id] = params
+	}
+	return
+}
+
+func parseRFC3164(msg *SyslogMessage, rest string, received time.Time) {
+	msg.Format = SyslogFormatRFC3164
+	msg.Message = rest
+
+	if len(rest) < rfc3164TimeLength+1 {
+		return
+	}
+
+	timestamp, err := time.ParseInLocation(rfc3164TimeLayout, rest[:rfc3164TimeLength], received.Location())
+	if err != nil {
+		return
+	}
+
+	msg.Timestamp = time.Date(received.Year(), timestamp.Month(), timestamp.Day(),
+		timestamp.Hour(), timestamp.Minute(), timestamp.Second(), 0, received.Location())
+	if msg.Timestamp.After(received.AddDate(0, 1, 0)) {
+		msg.Timestamp = msg.Timestamp.AddDate(-1, 0, 0)
+	}
+	rest = strings.TrimLeft(rest[rfc3164TimeLength:], " ")
+
+	host, content, found := strings.Cut(rest, " ")
+	if !found {
+		msg.Message = rest
+		return
+	}
+	msg.Hostname = host
+	msg.Message = content
+
+	tagEnd := strings.IndexAny(content, ":[ ")
+	if tagEnd <= 0 || tagEnd > maxRFC3164TagChars {
+		return
+	}
+	msg.AppName = content[:tagEnd]
+	content = content[tagEnd:]
+
+	if strings.HasPrefix(content, "[") {
+		if end := strings.IndexByte(content, ']'); end > 0 {
+			msg.ProcID = content[1:end]
+			content = content[end+1:]
+		}
+	}
+	msg.Message = strings.TrimLeft(strings.TrimPrefix(content, ":"), " ")
+}
+
+func nilValue(field string) (value string) {
+	if field != syslogNil {
+		value = field
+	}
+	return
+}
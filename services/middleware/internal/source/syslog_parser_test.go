@@ -0,0 +1,140 @@
+package source
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSyslogRFC5424(t *testing.T) {
+	received := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	line := `<165>1 2024-03-01T11:59:58.123Z db01 postgres 4242 ID47 [exampleSDID@32473 iut="3" eventSource="App\"lication" eventID="1011"][meta region="eu"] ` + "\ufeffconnection lost\n"
+
+	msg, err := ParseSyslog([]byte(line), received)
+	if err != nil {
+		t.Fatalf("ParseSyslog returned error: %v", err)
+	}
+
+	if msg.Format != SyslogFormatRFC5424 {
+		t.Errorf("format = %q, want %q", msg.Format, SyslogFormatRFC5424)
+	}
+	if msg.Facility != 20 || msg.Severity != 5 {
+		t.Errorf("facility/severity = %d/%d, want 20/5", msg.Facility, msg.Severity)
+	}
+	if msg.FacilityName() != "local4" || msg.SeverityName() != "notice" {
+		t.Errorf("names = %s/%s, want local4/notice", msg.FacilityName(), msg.SeverityName())
+	}
+	if !msg.Timestamp.Equal(time.Date(2024, 3, 1, 11, 59, 58, 123000000, time.UTC)) {
+		t.Errorf("timestamp = %s", msg.Timestamp)
+	}
+	if msg.Hostname != "db01" || msg.AppName != "postgres" || msg.ProcID != "4242" || msg.MsgID != "ID47" {
+		t.Errorf("header = %q %q %q %q", msg.Hostname, msg.AppName, msg.ProcID, msg.MsgID)
+	}
+	if got := msg.StructuredData["exampleSDID@32473"]["eventSource"]; got != `App"lication` {
+		t.Errorf("escaped structured data value = %q", got)
+	}
+	if got := msg.StructuredData["meta"]["region"]; got != "eu" {
+		t.Errorf("second structured data element = %q", got)
+	}
+	if msg.Message != "connection lost" {
+		t.Errorf("message = %q", msg.Message)
+	}
+}
+
+func TestParseSyslogRFC5424NilFields(t *testing.T) {
+	msg, err := ParseSyslog([]byte("<14>1 - - - - - - hello"), time.Now())
+	if err != nil {
+		t.Fatalf("ParseSyslog returned error: %v", err)
+	}
+	if !msg.Timestamp.IsZero() || msg.Hostname != "" || msg.AppName != "" || msg.StructuredData != nil {
+		t.Errorf("nil fields were not left empty: %+v", msg)
+	}
+	if msg.Message != "hello" {
+		t.Errorf("message = %q", msg.Message)
+	}
+}
+
+func TestParseSyslogRFC3164(t *testing.T) {
+	received := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	msg, err := ParseSyslog([]byte("<34>Feb 29 23:59:01 web01 nginx[812]: upstream timed out"), received)
+	if err != nil {
+		t.Fatalf("ParseSyslog returned error: %v", err)
+	}
+
+	if msg.Format != SyslogFormatRFC3164 {
+		t.Errorf("format = %q", msg.Format)
+	}
+	if msg.Facility != 4 || msg.Severity != 2 {
+		t.Errorf("facility/severity = %d/%d, want 4/2", msg.Facility, msg.Severity)
+	}
+	if !msg.Timestamp.Equal(time.Date(2024, 2, 29, 23, 59, 1, 0, time.UTC)) {
+		t.Errorf("timestamp = %s", msg.Timestamp)
+	}
+	if msg.Hostname != "web01" || msg.AppName != "nginx" || msg.ProcID != "812" {
+		t.Errorf("header = %q %q %q", msg.Hostname, msg.AppName, msg.ProcID)
+	}
+	if msg.Message != "upstream timed out" {
+		t.Errorf("message = %q", msg.Message)
+	}
+}
+
+func TestParseSyslogRFC3164YearRollover(t *testing.T) {
+	received := time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC)
+
+	msg, err := ParseSyslog([]byte("<13>Dec 31 23:59:59 host app: late"), received)
+	if err != nil {
+		t.Fatalf("ParseSyslog returned error: %v", err)
+	}
+	if msg.Timestamp.Year() != 2023 {
+		t.Errorf("timestamp = %s, want a date in 2023", msg.Timestamp)
+	}
+}
+
+func TestParseSyslogRFC3164WithoutHeader(t *testing.T) {
+	msg, err := ParseSyslog([]byte("<13>just some text"), time.Now())
+	if err != nil {
+		t.Fatalf("ParseSyslog returned error: %v", err)
+	}
+	if msg.Message != "just some text" || msg.Hostname != "" {
+		t.Errorf("message = %q, hostname = %q", msg.Message, msg.Hostname)
+	}
+}
+
+func TestParseSyslogInvalid(t *testing.T) {
+	cases := map[string]string{
+		"missing priority":      "hello",
+		"unterminated priority": "<13 hello",
+		"priority out of range": "<192>1 - - - - - -",
+		"truncated header":      "<13>1 - host",
+		"bad timestamp":         "<13>1 yesterday host app - - - msg",
+		"unterminated sd":       `<13>1 - host app - - [id key="value`,
+		"sd without quotes":     `<13>1 - host app - - [id key=value] msg`,
+	}
+
+	for name, line := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseSyslog([]byte(line), time.Now())
+			if !errors.Is(err, ErrInvalidSyslog) {
+				t.Errorf("error = %v, want ErrInvalidSyslog", err)
+			}
+		})
+	}
+}
+
+func TestReadSyslogFrame(t *testing.T) {
+	reader := bufio.NewReaderSize(strings.NewReader("11 <13>1 - a b<13>hello\n<14>last"), MaxSyslogMessageSize)
+
+	want := []string{"<13>1 - a b", "<13>hello\n", "<14>last"}
+	for i, expected := range want {
+		frame, err := readSyslogFrame(reader)
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if string(frame) != expected {
+			t.Errorf("frame %d = %q, want %q", i, frame, expected)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var (
+	ErrSuppressed    = errors.New("event suppressed")
+	ErrAlertNotFound = errors.New("alert not found")
+)
+
+const (
+	DefaultAutoResolveAfter = 24 * time.Hour
+	rebuildPageSize         = 500
+)
+
+type AlertTracker struct {
+	clock            Clock
+	autoResolveAfter time.Duration
+
+	mu     sync.RWMutex
+	open   map[string]*domain.Alert
+	labels map[string]map[string]*domain.Alert
+}
+
+func NewAlertTracker(clock Clock, autoResolveAfter time.Duration) (tracker *AlertTracker) {
+	tracker = &AlertTracker{
+		clock:            clock,
+		autoResolveAfter: autoResolveAfter,
+		open:             make(map[string]*domain.Alert),
+		labels:           make(map[string]map[string]*domain.Alert),
+	}
+	return
+}
+
+func (t *AlertTracker) Check(event domain.Event) (err error) {
+	if event.DedupKey == "" || event.Action == domain.ActionTrigger || event.Action == "" {
+		return
+	}
+
+	t.mu.RLock()
+	_, ok := t.open[event.DedupKey]
+	t.mu.RUnlock()
+
+	if !ok {
+		err = fmt.Errorf("%w: no open alert for dedup key %s", ErrSuppressed, event.DedupKey)
+	}
+	return
+}
+
+func (t *AlertTracker) Apply(event domain.Event) {
+	if event.DedupKey == "" {
+		return
+	}
+
+	now := t.clock.Now().UTC()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.applyLocked(event, now)
+}
+
+func (t *AlertTracker) Rebuild(ctx context.Context, store domain.EventStore) (restored int, err error) {
+	var records []domain.EventRecord
+	query := domain.EventQuery{Limit: rebuildPageSize}
+	for {
+		var page domain.EventPage
+		page, err = store.Query(ctx, query)
+		if err != nil {
+			err = fmt.Errorf("failed to query events: %w", err)
+			return
+		}
+
+		for _, record := range page.Records {
+			if tracked(record) {
+				records = append(records, record)
+			}
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := len(records) - 1; i >= 0; i-- {
+		t.applyLocked(records[i].Event(), records[i].Timestamp.UTC())
+	}
+	restored = len(t.open)
+	return
+}
+
+func tracked(record domain.EventRecord) (ok bool) {
+	if record.DedupKey == "" || record.ReplayOf != "" {
+		return
+	}
+	if _, escalation := record.Metadata["escalation_of"]; escalation {
+		return
+	}
+
+	switch record.Status {
+	case domain.StatusAccepted, domain.StatusDelivered, domain.StatusFailed, domain.StatusDigested:
+		ok = true
+	}
+	return
+}
+
+func (t *AlertTracker) applyLocked(event domain.Event, now time.Time) {
+	alert, ok := t.open[event.DedupKey]
+
+	switch event.Action {
+	case domain.ActionAcknowledge:
+		if ok && alert.State != domain.AlertAcknowledged {
+			alert.State = domain.AlertAcknowledged
+			alert.AcknowledgedAt = &now
+			alert.LastEventID = event.ID
+			alert.UpdatedAt = now
+		}
+	case domain.ActionResolve:
+		if ok {
+			t.unindexLocked(alert)
+			delete(t.open, event.DedupKey)
+		}
+	default:
+		if ok {
+			t.unindexLocked(alert)
+		} else {
+			alert = &domain.Alert{
+				DedupKey:     event.DedupKey,
+				State:        domain.AlertTriggered,
+				FirstEventID: event.ID,
+				TriggeredAt:  now,
+			}
+			t.open[event.DedupKey] = alert
+		}
+		alert.Source = event.Source
+		alert.EventType = event.EventType
+		alert.Priority = event.Priority
+		alert.Message = event.Message
+		alert.Destination = event.Destination
+		alert.Metadata = event.Metadata
+		alert.LastEventID = event.ID
+		alert.TriggerCount++
+		alert.LastTriggeredAt = now
+		alert.UpdatedAt = now
+		t.indexLocked(alert)
+	}
+}
+
+func (t *AlertTracker) Restore(event domain.Event, triggeredAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.open[event.DedupKey]; ok {
+		return
+	}
+
+	alert := &domain.Alert{
+		DedupKey:        event.DedupKey,
+		State:           domain.AlertTriggered,
+		Source:          event.Source,
+		EventType:       event.EventType,
+		Priority:        event.Priority,
+		Message:         event.Message,
+		Destination:     event.Destination,
+		Metadata:        event.Metadata,
+		FirstEventID:    event.ID,
+		LastEventID:     event.ID,
+		TriggerCount:    1,
+		TriggeredAt:     triggeredAt,
+		LastTriggeredAt: triggeredAt,
+		UpdatedAt:       triggeredAt,
+	}
+	t.open[event.DedupKey] = alert
+	t.indexLocked(alert)
+}
+
+func (t *AlertTracker) Get(dedupKey string) (alert domain.Alert, err error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	open, ok := t.open[dedupKey]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrAlertNotFound, dedupKey)
+		return
+	}
+	alert = *open
+	return
+}
+
+func (t *AlertTracker) Open() (alerts []domain.Alert) {
+	t.mu.RLock()
+	alerts = make([]domain.Alert, 0, len(t.open))
+	for _, alert := range t.open {
+		alerts = append(alerts, *alert)
+	}
+	t.mu.RUnlock()
+
+	slices.SortFunc(alerts, func(a, b domain.Alert) int {
+		return a.TriggeredAt.Compare(b.TriggeredAt)
+	})
+	return
+}
+
+func (t *AlertTracker) findOpen(labels []string, match func(alert domain.Alert) bool) (found domain.Alert, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	candidates := t.open
+	for _, label := range labels {
+		indexed := t.labels[label]
+		if len(indexed) < len(candidates) {
+			candidates = indexed
+		}
+	}
+
+	for _, alert := range candidates {
+		if ok && !alert.TriggeredAt.Before(found.TriggeredAt) {
+			continue
+		}
+		if match(*alert) {
+			found, ok = *alert, true
+		}
+	}
+	return
+}
+
+func (t *AlertTracker) indexLocked(alert *domain.Alert) {
+	for _, label := range alertLabels(alert) {
+		indexed, ok := t.labels[label]
+		if !ok {
+			indexed = make(map[string]*domain.Alert)
+			t.labels[label] = indexed
+		}
+		indexed[alert.DedupKey] = alert
+	}
+}
+
+func (t *AlertTracker) unindexLocked(alert *domain.Alert) {
+	for _, label := range alertLabels(alert) {
+		delete(t.labels[label], alert.DedupKey)
+		if len(t.labels[label]) == 0 {
+			delete(t.labels, label)
+		}
+	}
+}
+
+func alertLabels(alert *domain.Alert) (labels []string) {
+	event := alert.Event()
+	fields := []string{"source", "event_type", "priority"}
+	for name := range alert.Metadata {
+		fields = append(fields, "metadata."+name)
+	}
+
+	labels = make([]string, 0, len(fields))
+	for _, field := range fields {
+		value, _ := domain.FieldValue(event, field)
+		labels = append(labels, alertLabel(field, value))
+	}
+	return
+}
+
+func alertLabel(field, value string) (label string) {
+	label = field + "=" + value
+	return
+}
+
+func (t *AlertTracker) Expired() (alerts []domain.Alert) {
+	if t.autoResolveAfter <= 0 {
+		return
+	}
+
+	cutoff := t.clock.Now().UTC().Add(-t.autoResolveAfter)
+	for _, alert := range t.Open() {
+		if alert.LastTriggeredAt.Before(cutoff) {
+			alerts = append(alerts, alert)
+		}
+	}
+	return
+}
+
+func (t *AlertTracker) AutoResolveAfter() (after time.Duration) {
+	after = t.autoResolveAfter
+	return
+}
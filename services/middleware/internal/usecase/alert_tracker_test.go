@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+func alertEvent(id, dedupKey string, action domain.AlertAction) (event domain.Event) {
+	event = domain.Event{
+		ID:        id,
+		Source:    "db",
+		EventType: "replication_lag",
+		Priority:  domain.PriorityHigh,
+		Message:   "replica is behind",
+		DedupKey:  dedupKey,
+		Action:    action,
+	}
+	return
+}
+
+func TestAlertTrackerCheck(t *testing.T) {
+	tracker := NewAlertTracker(&testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, 0)
+	tracker.Apply(alertEvent("evt-1", "db-lag", domain.ActionTrigger))
+
+	tests := []struct {
+		name       string
+		event      domain.Event
+		suppressed bool
+	}{
+		{name: "no dedup key", event: alertEvent("evt-2", "", domain.ActionResolve)},
+		{name: "trigger for unknown key", event: alertEvent("evt-2", "disk-full", domain.ActionTrigger)},
+		{name: "event without action", event: alertEvent("evt-2", "disk-full", "")},
+		{name: "acknowledge open alert", event: alertEvent("evt-2", "db-lag", domain.ActionAcknowledge)},
+		{name: "resolve open alert", event: alertEvent("evt-2", "db-lag", domain.ActionResolve)},
+		{name: "acknowledge unknown key", event: alertEvent("evt-2", "disk-full", domain.ActionAcknowledge), suppressed: true},
+		{name: "resolve unknown key", event: alertEvent("evt-2", "disk-full", domain.ActionResolve), suppressed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tracker.Check(tt.event)
+			if suppressed := errors.Is(err, ErrSuppressed); suppressed != tt.suppressed {
+				t.Errorf("Check error = %v, want suppressed %v", err, tt.suppressed)
+			}
+		})
+	}
+}
+
+func TestAlertTrackerLifecycle(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &testClock{now: start}
+	tracker := NewAlertTracker(clock, time.Hour)
+
+	tracker.Apply(alertEvent("evt-1", "db-lag", domain.ActionTrigger))
+	clock.Advance(10 * time.Minute)
+	tracker.Apply(alertEvent("evt-2", "db-lag", domain.ActionTrigger))
+
+	alert, err := tracker.Get("db-lag")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if alert.TriggerCount != 2 || alert.FirstEventID != "evt-1" || alert.LastEventID != "evt-2" {
+		t.Errorf("alert = %+v, want two triggers from evt-1 to evt-2", alert)
+	}
+	if !alert.TriggeredAt.Equal(start) || !alert.LastTriggeredAt.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("triggered at %v, last %v", alert.TriggeredAt, alert.LastTriggeredAt)
+	}
+
+	clock.Advance(time.Minute)
+	tracker.Apply(alertEvent("evt-3", "db-lag", domain.ActionAcknowledge))
+	alert, _ = tracker.Get("db-lag")
+	if alert.State != domain.AlertAcknowledged || alert.AcknowledgedAt == nil {
+		t.Errorf("state = %q, acknowledged at %v, want acknowledged", alert.State, alert.AcknowledgedAt)
+	}
+
+	if expired := tracker.Expired(); len(expired) != 0 {
+		t.Errorf("Expired = %d alerts, want 0", len(expired))
+	}
+	clock.Advance(time.Hour)
+	if expired := tracker.Expired(); len(expired) != 1 || expired[0].DedupKey != "db-lag" {
+		t.Errorf("Expired = %+v, want db-lag", expired)
+	}
+
+	tracker.Apply(alertEvent("evt-4", "db-lag", domain.ActionResolve))
+	if _, err = tracker.Get("db-lag"); !errors.Is(err, ErrAlertNotFound) {
+		t.Errorf("Get after resolve error = %v, want %v", err, ErrAlertNotFound)
+	}
+	if open := tracker.Open(); len(open) != 0 {
+		t.Errorf("Open = %d alerts, want 0", len(open))
+	}
+}
+
+func TestAlertTrackerRebuild(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := repository.NewMemoryEventStore(100)
+	ctx := context.Background()
+
+	record := func(event domain.Event, status domain.DeliveryStatus, at time.Time) {
+		event.Timestamp = at
+		err := store.Record(ctx, domain.NewEventRecord(event, status))
+		if err != nil {
+			t.Fatalf("Record returned error: %v", err)
+		}
+	}
+
+	record(alertEvent("evt-1", "db-lag", domain.ActionTrigger), domain.StatusDelivered, start)
+	record(alertEvent("evt-2", "db-lag", domain.ActionTrigger), domain.StatusFailed, start.Add(time.Minute))
+	record(alertEvent("evt-3", "db-lag", domain.ActionAcknowledge), domain.StatusDelivered, start.Add(2*time.Minute))
+	record(alertEvent("evt-4", "disk-full", domain.ActionTrigger), domain.StatusDigested, start.Add(3*time.Minute))
+	record(alertEvent("evt-5", "cpu-high", domain.ActionTrigger), domain.StatusAccepted, start.Add(4*time.Minute))
+	record(alertEvent("evt-6", "cpu-high", domain.ActionResolve), domain.StatusDelivered, start.Add(5*time.Minute))
+	record(alertEvent("evt-7", "silenced", domain.ActionTrigger), domain.StatusSuppressed, start.Add(6*time.Minute))
+	record(alertEvent("evt-8", "rejected", domain.ActionTrigger), domain.StatusRejected, start.Add(7*time.Minute))
+
+	replay := alertEvent("evt-9", "replayed", domain.ActionTrigger)
+	replay.ReplayOf = "evt-0"
+	record(replay, domain.StatusDelivered, start.Add(8*time.Minute))
+
+	escalation := alertEvent("evt-10", "disk-full", domain.ActionTrigger)
+	escalation.Metadata = map[string]interface{}{"escalation_of": "evt-4"}
+	record(escalation, domain.StatusDelivered, start.Add(9*time.Minute))
+
+	tracker := NewAlertTracker(&testClock{now: start.Add(time.Hour)}, 0)
+	restored, err := tracker.Rebuild(ctx, store)
+	if err != nil {
+		t.Fatalf("Rebuild returned error: %v", err)
+	}
+	if restored != 2 {
+		t.Errorf("restored = %d, want 2", restored)
+	}
+
+	alert, err := tracker.Get("db-lag")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if alert.State != domain.AlertAcknowledged || alert.TriggerCount != 2 || alert.FirstEventID != "evt-1" {
+		t.Errorf("db-lag = %+v, want acknowledged with two triggers from evt-1", alert)
+	}
+	if !alert.TriggeredAt.Equal(start) || !alert.AcknowledgedAt.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("db-lag triggered at %v, acknowledged at %v, want the recorded timestamps", alert.TriggeredAt, alert.AcknowledgedAt)
+	}
+
+	alert, err = tracker.Get("disk-full")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if alert.TriggerCount != 1 {
+		t.Errorf("disk-full trigger count = %d, want 1", alert.TriggerCount)
+	}
+
+	for _, key := range []string{"cpu-high", "silenced", "rejected", "replayed"} {
+		if _, err = tracker.Get(key); !errors.Is(err, ErrAlertNotFound) {
+			t.Errorf("Get(%q) error = %v, want %v", key, err, ErrAlertNotFound)
+		}
+	}
+
+	if err = tracker.Check(alertEvent("evt-11", "db-lag", domain.ActionResolve)); err != nil {
+		t.Errorf("Check resolve after rebuild returned error: %v", err)
+	}
+}
+
+func TestAlertTrackerRebuildPaginates(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := repository.NewMemoryEventStore(0)
+	ctx := context.Background()
+
+	for i := range rebuildPageSize + 10 {
+		event := alertEvent(fmt.Sprintf("evt-%d", i), fmt.Sprintf("key-%d", i), domain.ActionTrigger)
+		event.Timestamp = start.Add(time.Duration(i) * time.Second)
+		err := store.Record(ctx, domain.NewEventRecord(event, domain.StatusDelivered))
+		if err != nil {
+			t.Fatalf("Record returned error: %v", err)
+		}
+	}
+
+	tracker := NewAlertTracker(&testClock{now: start}, 0)
+	restored, err := tracker.Rebuild(ctx, store)
+	if err != nil {
+		t.Fatalf("Rebuild returned error: %v", err)
+	}
+	if restored != rebuildPageSize+10 {
+		t.Errorf("restored = %d, want %d", restored, rebuildPageSize+10)
+	}
+}
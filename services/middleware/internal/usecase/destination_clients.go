@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/smartcom/integration-platform/pkg/httpclient"
+	"github.com/smartcom/integration-platform/pkg/tlsconfig"
+)
+
+func NewDestinationClients(cfg httpclient.Config, tlsConfigs map[string]tlsconfig.ClientConfig, destinations DestinationResolver) (clients map[string]*httpclient.Client, err error) {
+	clients = make(map[string]*httpclient.Client, len(tlsConfigs))
+	for name, tlsConfig := range tlsConfigs {
+		_, err = destinations.Resolve(name)
+		if err != nil {
+			clients = nil
+			err = fmt.Errorf("%w: destination %s: %w", tlsconfig.ErrInvalidConfig, name, err)
+			return
+		}
+
+		clientConfig := cfg
+		clientConfig.TLS = &tlsConfig
+
+		var client *httpclient.Client
+		client, err = httpclient.New(clientConfig)
+		if err != nil {
+			clients = nil
+			err = fmt.Errorf("destination %s: %w", name, err)
+			return
+		}
+		clients[name] = client
+	}
+	return
+}
@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/correlation"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+const (
+	DefaultDigestSamples = 3
+	DefaultDigestTick    = 10 * time.Second
+	DigestSource         = "middleware"
+	DigestEventType      = "digest"
+)
+
+const defaultDigestTemplate = `{{.Total}} events on route {{.Route}} from {{.From.Format "2006-01-02 15:04 MST"}} to {{.To.Format "2006-01-02 15:04 MST"}}
+{{- range .Groups}}
+- {{.Source}} / {{.EventType}}: {{.Count}}{{range .Samples}}
+    {{.}}{{end}}
+{{- end}}`
+
+type DigestGroup struct {
+	Source    string          `json:"source"`
+	EventType string          `json:"event_type"`
+	Count     int             `json:"count"`
+	Priority  domain.Priority `json:"priority"`
+	Samples   []string        `json:"samples"`
+	FirstSeen time.Time       `json:"first_seen"`
+	LastSeen  time.Time       `json:"last_seen"`
+}
+
+type DigestSummary struct {
+	Route  string        `json:"route"`
+	From   time.Time     `json:"from"`
+	To     time.Time     `json:"to"`
+	Total  int           `json:"total"`
+	Groups []DigestGroup `json:"groups"`
+}
+
+type DigestStatus struct {
+	Route       string    `json:"route"`
+	Schedule    string    `json:"schedule"`
+	Destination string    `json:"destination"`
+	NextAt      time.Time `json:"next_at"`
+	Pending     int       `json:"pending"`
+	Groups      int       `json:"groups"`
+}
+
+type digestBuffer struct {
+	route       domain.Route
+	schedule    Schedule
+	template    *template.Template
+	destination string
+	maxSamples  int
+	nextAt      time.Time
+	from        time.Time
+	total       int
+	groups      map[string]*DigestGroup
+}
+
+type pendingDigest struct {
+	buffer  *digestBuffer
+	summary DigestSummary
+}
+
+type Digester struct {
+	store       domain.EventStore
+	queue       EventEnqueuer
+	idGenerator IDGenerator
+	clock       Clock
+	logger      EventLogger
+
+	mu      sync.Mutex
+	buffers map[string]*digestBuffer
+}
+
+func NewDigester(router *Router, store domain.EventStore, queue EventEnqueuer, idGen IDGenerator, clock Clock, logger EventLogger) (digester *Digester, err error) {
+	digester = &Digester{
+		store:       store,
+		queue:       queue,
+		idGenerator: idGen,
+		clock:       clock,
+		logger:      logger,
+		buffers:     make(map[string]*digestBuffer),
+	}
+
+	now := clock.Now().UTC()
+	for _, route := range router.DigestRoutes() {
+		var buffer *digestBuffer
+		buffer, err = newDigestBuffer(route, now)
+		if err != nil {
+			digester = nil
+			err = fmt.Errorf("%w: route %s: %w", domain.ErrInvalidRoute, route.Name, err)
+			return
+		}
+		digester.buffers[route.Name] = buffer
+	}
+	return
+}
+
+func newDigestBuffer(route domain.Route, now time.Time) (buffer *digestBuffer, err error) {
+	location := time.UTC
+	if route.Digest.Timezone != "" {
+		location, err = time.LoadLocation(route.Digest.Timezone)
+		if err != nil {
+			return
+		}
+	}
+
+	buffer = &digestBuffer{
+		route:       route,
+		destination: digestDestination(route),
+		maxSamples:  route.Digest.MaxSamples,
+		from:        now,
+		groups:      make(map[string]*DigestGroup),
+	}
+	if buffer.maxSamples <= 0 {
+		buffer.maxSamples = DefaultDigestSamples
+	}
+
+	buffer.schedule, err = ParseSchedule(route.Digest.Schedule, location)
+	if err != nil {
+		return
+	}
+	buffer.nextAt = buffer.schedule.Next(now)
+	if buffer.nextAt.IsZero() {
+		err = fmt.Errorf("%w: %q never fires", ErrInvalidSchedule, route.Digest.Schedule)
+		return
+	}
+
+	text := route.Digest.Template
+	if text == "" {
+		text = defaultDigestTemplate
+	}
+	buffer.template, err = template.New(route.Name).Parse(text)
+	if err != nil {
+		return
+	}
+
+	sample := DigestSummary{Route: route.Name, From: now, To: now, Groups: []DigestGroup{{Samples: []string{""}}}}
+	err = buffer.template.Execute(io.Discard, sample)
+	return
+}
+
+func (d *Digester) Add(event domain.Event, route string) (added bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	buffer, ok := d.buffers[route]
+	if !ok {
+		return
+	}
+
+	key := event.Source + "\x00" + event.EventType
+	group, ok := buffer.groups[key]
+	if !ok {
+		group = &DigestGroup{
+			Source:    event.Source,
+			EventType: event.EventType,
+			FirstSeen: event.Timestamp,
+		}
+		buffer.groups[key] = group
+	}
+
+	group.Count++
+	group.Priority = max(group.Priority, event.Priority)
+	group.LastSeen = event.Timestamp
+	if len(group.Samples) < buffer.maxSamples {
+		group.Samples = append(group.Samples, event.Message)
+	}
+	buffer.total++
+
+	added = true
+	return
+}
+
+func (d *Digester) Start(ctx context.Context, interval time.Duration) {
+	if len(d.buffers) == 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultDigestTick
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.Tick(ctx)
+			}
+		}
+	}()
+}
+
+func (d *Digester) Tick(ctx context.Context) (sent int) {
+	now := d.clock.Now().UTC()
+
+	var pending []pendingDigest
+	d.mu.Lock()
+	for _, buffer := range d.buffers {
+		if now.Before(buffer.nextAt) {
+			continue
+		}
+
+		if digest, ok := buffer.take(now); ok {
+			pending = append(pending, digest)
+		}
+		buffer.nextAt = buffer.schedule.Next(now)
+	}
+	d.mu.Unlock()
+
+	sent = d.send(ctx, pending, now)
+	return
+}
+
+func (d *Digester) Flush(ctx context.Context) (sent int) {
+	now := d.clock.Now().UTC()
+
+	var pending []pendingDigest
+	d.mu.Lock()
+	for _, buffer := range d.buffers {
+		if digest, ok := buffer.take(now); ok {
+			pending = append(pending, digest)
+		}
+	}
+	d.mu.Unlock()
+
+	sent = d.send(ctx, pending, now)
+	return
+}
+
+func (d *Digester) Status() (statuses []DigestStatus) {
+	d.mu.Lock()
+	for _, buffer := range d.buffers {
+		statuses = append(statuses, DigestStatus{
+			Route:       buffer.route.Name,
+			Schedule:    buffer.route.Digest.Schedule,
+			Destination: buffer.destination,
+			NextAt:      buffer.nextAt,
+			Pending:     buffer.total,
+			Groups:      len(buffer.groups),
+		})
+	}
+	d.mu.Unlock()
+
+	slices.SortFunc(statuses, func(a, b DigestStatus) int {
+		return strings.Compare(a.Route, b.Route)
+	})
+	return
+}
+
+func (b *digestBuffer) take(now time.Time) (digest pendingDigest, ok bool) {
+	if b.total == 0 {
+		b.from = now
+		return
+	}
+
+	digest = pendingDigest{
+		buffer: b,
+		summary: DigestSummary{
+			Route: b.route.Name,
+			From:  b.from,
+			To:    now,
+			Total: b.total,
+		},
+	}
+	for _, group := range b.groups {
+		digest.summary.Groups = append(digest.summary.Groups, *group)
+	}
+	slices.SortFunc(digest.summary.Groups, func(a, b DigestGroup) int {
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			strings.Compare(a.Source, b.Source),
+			strings.Compare(a.EventType, b.EventType),
+		)
+	})
+
+	b.from = now
+	b.total = 0
+	b.groups = make(map[string]*DigestGroup)
+
+	ok = true
+	return
+}
+
+func (d *Digester) send(ctx context.Context, pending []pendingDigest, now time.Time) (sent int) {
+	for _, digest := range pending {
+		if d.sendDigest(ctx, digest.buffer, digest.summary, now) {
+			sent++
+		}
+	}
+	return
+}
+
+func (d *Digester) sendDigest(ctx context.Context, buffer *digestBuffer, summary DigestSummary, now time.Time) (sent bool) {
+	event, err := d.buildEvent(buffer, summary)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "failed to build digest", "route", summary.Route, "events", summary.Total, "error", err.Error())
+		return
+	}
+
+	ctx = correlation.WithID(ctx, event.CorrelationID)
+	if d.store != nil {
+		err = d.store.Record(ctx, domain.NewEventRecord(event, domain.StatusAccepted))
+		if err != nil {
+			d.logger.ErrorContext(ctx, "failed to record digest", "event_id", event.ID, "error", err.Error())
+		}
+	}
+
+	err = d.queue.Enqueue(ctx, event)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "failed to enqueue digest", "event_id", event.ID, "route", summary.Route, "error", err.Error())
+		if d.store != nil {
+			d.store.UpdateStatus(ctx, event.ID, domain.StatusUpdate{
+				Status:    domain.StatusRejected,
+				Error:     err.Error(),
+				UpdatedAt: now,
+			})
+		}
+		return
+	}
+
+	d.logger.InfoContext(ctx, "digest sent",
+		"event_id", event.ID,
+		"route", summary.Route,
+		"events", summary.Total,
+		"groups", len(summary.Groups),
+	)
+	sent = true
+	return
+}
+
+func (d *Digester) buildEvent(buffer *digestBuffer, summary DigestSummary) (event domain.Event, err error) {
+	var message strings.Builder
+	err = buffer.template.Execute(&message, summary)
+	if err != nil {
+		err = fmt.Errorf("failed to render digest template: %w", err)
+		return
+	}
+
+	var id, correlationID string
+	id, err = d.idGenerator.Generate()
+	if err != nil {
+		err = fmt.Errorf("failed to generate digest ID: %w", err)
+		return
+	}
+	correlationID, err = correlation.GenerateID()
+	if err != nil {
+		return
+	}
+
+	priority := domain.PriorityLow
+	for _, group := range summary.Groups {
+		priority = max(priority, group.Priority)
+	}
+
+	event = domain.Event{
+		ID:            id,
+		Source:        DigestSource,
+		EventType:     DigestEventType,
+		Priority:      priority,
+		Message:       message.String(),
+		Timestamp:     summary.To,
+		CorrelationID: correlationID,
+		Destination:   buffer.destination,
+		Action:        domain.ActionTrigger,
+		Metadata: map[string]interface{}{
+			"digest_route":  summary.Route,
+			"digest_total":  summary.Total,
+			"digest_from":   summary.From.Format(time.RFC3339),
+			"digest_to":     summary.To.Format(time.RFC3339),
+			"digest_groups": summary.Groups,
+		},
+	}
+	return
+}
+
+func digestDestination(route domain.Route) (destination string) {
+	if route.Digest == nil {
+		return
+	}
+
+	destination = cmp.Or(route.Digest.Destination, route.Destination, domain.DefaultDestination)
+	return
+}
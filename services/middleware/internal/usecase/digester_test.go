@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+func TestDigesterEnqueuesOutsideLock(t *testing.T) {
+	router, err := NewRouter(domain.RoutingConfig{
+		Routes: []domain.Route{{
+			Name:        "batch",
+			Destination: "chat",
+			Digest:      &domain.Digest{Schedule: "hourly"},
+		}},
+	}, testResolver{})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)}
+	queue := &testQueue{}
+	digester, err := NewDigester(router, repository.NewMemoryEventStore(100), queue, &testIDs{}, clock, logger.New(io.Discard, slog.LevelError))
+	if err != nil {
+		t.Fatalf("NewDigester returned error: %v", err)
+	}
+	queue.onEnqueue = func() {
+		digester.Status()
+	}
+
+	event := domain.Event{ID: "in-1", Source: "cron", EventType: "job", Message: "job done", Timestamp: clock.Now()}
+	if !digester.Add(event, "batch") {
+		t.Fatalf("Add returned false, want true")
+	}
+
+	clock.Advance(30 * time.Minute)
+	withinTimeout(t, "Tick", func() {
+		if sent := digester.Tick(context.Background()); sent != 1 {
+			t.Errorf("Tick sent = %d, want 1", sent)
+		}
+	})
+
+	digester.Add(event, "batch")
+	withinTimeout(t, "Flush", func() {
+		if sent := digester.Flush(context.Background()); sent != 1 {
+			t.Errorf("Flush sent = %d, want 1", sent)
+		}
+	})
+
+	if destinations := queue.Destinations(); len(destinations) != 2 || destinations[0] != "chat" {
+		t.Errorf("digest destinations = %v, want [chat chat]", destinations)
+	}
+	if status := digester.Status(); len(status) != 1 || status[0].Pending != 0 {
+		t.Errorf("status = %+v, want one route with nothing pending", status)
+	}
+}
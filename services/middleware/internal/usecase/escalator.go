@@ -0,0 +1,352 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+const (
+	DefaultEscalationTick    = 10 * time.Second
+	escalationEnqueueTimeout = 5 * time.Second
+)
+
+type Escalator struct {
+	router      *Router
+	store       domain.EscalationStore
+	events      domain.EventStore
+	queue       EventEnqueuer
+	idGenerator IDGenerator
+	clock       Clock
+	logger      EventLogger
+
+	mu          sync.Mutex
+	escalations map[string]*domain.Escalation
+}
+
+func NewEscalator(ctx context.Context, router *Router, store domain.EscalationStore, events domain.EventStore, queue EventEnqueuer, idGen IDGenerator, clock Clock, logger EventLogger) (escalator *Escalator, err error) {
+	var stored []domain.Escalation
+	stored, err = store.List(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to load escalations: %w", err)
+		return
+	}
+
+	escalator = &Escalator{
+		router:      router,
+		store:       store,
+		events:      events,
+		queue:       queue,
+		idGenerator: idGen,
+		clock:       clock,
+		logger:      logger,
+		escalations: make(map[string]*domain.Escalation, len(stored)),
+	}
+
+	for _, escalation := range stored {
+		escalator.escalations[escalation.DedupKey] = &escalation
+	}
+	return
+}
+
+func (e *Escalator) Restore(alerts *AlertTracker) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, escalation := range e.escalations {
+		alerts.Restore(escalation.Event.Event(), escalation.StartedAt)
+	}
+}
+
+func (e *Escalator) Prepare(event *domain.Event, route domain.Route) {
+	if event.DedupKey == "" {
+		return
+	}
+
+	e.mu.Lock()
+	escalation, exists := e.escalations[event.DedupKey]
+	e.mu.Unlock()
+
+	if exists {
+		event.Destination = escalation.Notified[0]
+		event.Metadata = escalationMetadata(event.Metadata, escalation.Policy, escalation.Tier, escalation.Repeats, "")
+		return
+	}
+
+	if event.Action != domain.ActionTrigger || route.Escalation == "" {
+		return
+	}
+
+	policy, ok := e.router.Policy(route.Escalation)
+	if !ok {
+		return
+	}
+	event.Destination = policy.Tiers[0].Destinations[0]
+	event.Metadata = escalationMetadata(event.Metadata, policy.Name, 0, 0, "")
+}
+
+func (e *Escalator) Handle(ctx context.Context, event domain.Event, route domain.Route) {
+	if event.DedupKey == "" {
+		return
+	}
+
+	var notifications []domain.Event
+	defer func() {
+		e.send(ctx, notifications)
+	}()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	escalation, exists := e.escalations[event.DedupKey]
+
+	switch {
+	case exists && event.Action == domain.ActionAcknowledge:
+		now := e.clock.Now().UTC()
+		escalation.AcknowledgedAt = &now
+		escalation.NextAt = nil
+		e.save(ctx, escalation)
+
+		notifications = e.notifications(ctx, event, escalation, remove(escalation.Notified, event.Destination))
+		e.logger.InfoContext(ctx, "escalation acknowledged",
+			"dedup_key", event.DedupKey,
+			"policy", escalation.Policy,
+			"tier", escalation.Tier+1,
+		)
+	case exists && event.Action != domain.ActionTrigger:
+		delete(e.escalations, event.DedupKey)
+		err := e.store.Delete(ctx, event.DedupKey)
+		if err != nil {
+			e.logger.ErrorContext(ctx, "failed to delete escalation", "dedup_key", event.DedupKey, "error", err.Error())
+		}
+
+		notifications = e.notifications(ctx, event, escalation, remove(escalation.Notified, event.Destination))
+		e.logger.InfoContext(ctx, "escalation stopped",
+			"dedup_key", event.DedupKey,
+			"policy", escalation.Policy,
+			"action", event.Action,
+			"tier", escalation.Tier+1,
+		)
+	case !exists && event.Action == domain.ActionTrigger && route.Escalation != "":
+		policy, ok := e.router.Policy(route.Escalation)
+		if !ok {
+			return
+		}
+
+		now := e.clock.Now().UTC()
+		escalation = &domain.Escalation{
+			DedupKey:   event.DedupKey,
+			Policy:     policy.Name,
+			Route:      route.Name,
+			Notified:   append([]string{event.Destination}, remove(policy.Tiers[0].Destinations, event.Destination)...),
+			Event:      domain.NewEventRecord(event, domain.StatusAccepted),
+			StartedAt:  now,
+			NotifiedAt: now,
+		}
+		e.escalations[event.DedupKey] = escalation
+
+		notifications = e.notifications(ctx, event, escalation, escalation.Notified[1:])
+		e.schedule(escalation, policy, now)
+		e.save(ctx, escalation)
+
+		e.logger.InfoContext(ctx, "escalation started", "dedup_key", event.DedupKey, "policy", policy.Name)
+	}
+}
+
+func (e *Escalator) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultEscalationTick
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.Tick(ctx)
+			}
+		}
+	}()
+}
+
+func (e *Escalator) Tick(ctx context.Context) (escalated int) {
+	now := e.clock.Now().UTC()
+
+	var notifications []domain.Event
+	defer func() {
+		e.send(ctx, notifications)
+	}()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key, escalation := range e.escalations {
+		if escalation.NextAt == nil || escalation.NextAt.After(now) {
+			continue
+		}
+
+		policy, ok := e.router.Policy(escalation.Policy)
+		if !ok {
+			e.logger.ErrorContext(ctx, "dropping escalation with unknown policy", "dedup_key", key, "policy", escalation.Policy)
+			delete(e.escalations, key)
+			err := e.store.Delete(ctx, key)
+			if err != nil {
+				e.logger.ErrorContext(ctx, "failed to delete escalation", "dedup_key", key, "error", err.Error())
+			}
+			continue
+		}
+
+		if escalation.Tier+1 < len(policy.Tiers) {
+			escalation.Tier++
+		} else {
+			escalation.Tier = 0
+			escalation.Repeats++
+		}
+
+		destinations := policy.Tiers[escalation.Tier].Destinations
+		for _, destination := range destinations {
+			if !slices.Contains(escalation.Notified, destination) {
+				escalation.Notified = append(escalation.Notified, destination)
+			}
+		}
+
+		template := escalation.Event.Event()
+		template.Metadata = escalationMetadata(template.Metadata, policy.Name, escalation.Tier, escalation.Repeats, "")
+		notifications = append(notifications, e.notifications(ctx, template, escalation, destinations)...)
+
+		escalation.NotifiedAt = now
+		e.schedule(escalation, policy, now)
+		e.save(ctx, escalation)
+
+		e.logger.InfoContext(ctx, "alert escalated",
+			"dedup_key", key,
+			"policy", policy.Name,
+			"tier", escalation.Tier+1,
+			"repeat", escalation.Repeats,
+		)
+		escalated++
+	}
+	return
+}
+
+func (e *Escalator) List() (escalations []domain.Escalation) {
+	e.mu.Lock()
+	escalations = make([]domain.Escalation, 0, len(e.escalations))
+	for _, escalation := range e.escalations {
+		escalations = append(escalations, *escalation)
+	}
+	e.mu.Unlock()
+
+	slices.SortFunc(escalations, func(a, b domain.Escalation) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return
+}
+
+func (e *Escalator) schedule(escalation *domain.Escalation, policy domain.EscalationPolicy, now time.Time) {
+	escalation.NextAt = nil
+
+	var wait time.Duration
+	switch {
+	case escalation.Tier+1 < len(policy.Tiers):
+		wait = time.Duration(policy.Tiers[escalation.Tier].EscalateAfter)
+	case policy.RepeatInterval > 0 && (policy.MaxRepeats == 0 || escalation.Repeats < policy.MaxRepeats):
+		wait = time.Duration(policy.RepeatInterval)
+	default:
+		return
+	}
+
+	next := now.Add(wait)
+	escalation.NextAt = &next
+}
+
+func (e *Escalator) notifications(ctx context.Context, template domain.Event, escalation *domain.Escalation, destinations []string) (events []domain.Event) {
+	for _, destination := range destinations {
+		id, err := e.idGenerator.Generate()
+		if err != nil {
+			e.logger.ErrorContext(ctx, "failed to generate escalation event ID", "error", err.Error())
+			return
+		}
+
+		event := template
+		event.ID = id
+		event.Timestamp = e.clock.Now().UTC()
+		event.Destination = destination
+		event.Metadata = escalationMetadata(template.Metadata, escalation.Policy, escalation.Tier, escalation.Repeats, escalation.Event.ID)
+		events = append(events, event)
+	}
+	return
+}
+
+func (e *Escalator) send(ctx context.Context, events []domain.Event) {
+	for _, event := range events {
+		if e.events != nil {
+			err := e.events.Record(ctx, domain.NewEventRecord(event, domain.StatusAccepted))
+			if err != nil {
+				e.logger.ErrorContext(ctx, "failed to record escalation event", "event_id", event.ID, "error", err.Error())
+			}
+		}
+
+		enqueueCtx, cancel := context.WithTimeout(ctx, escalationEnqueueTimeout)
+		err := e.queue.Enqueue(enqueueCtx, event)
+		cancel()
+		if err != nil {
+			e.logger.ErrorContext(ctx, "failed to enqueue escalation event",
+				"event_id", event.ID,
+				"dedup_key", event.DedupKey,
+				"destination", event.Destination,
+				"error", err.Error(),
+			)
+			if e.events != nil {
+				e.events.UpdateStatus(ctx, event.ID, domain.StatusUpdate{
+					Status:    domain.StatusRejected,
+					Error:     err.Error(),
+					UpdatedAt: event.Timestamp,
+				})
+			}
+		}
+	}
+}
+
+func (e *Escalator) save(ctx context.Context, escalation *domain.Escalation) {
+	err := e.store.Save(ctx, *escalation)
+	if err != nil {
+		e.logger.ErrorContext(ctx, "failed to persist escalation", "dedup_key", escalation.DedupKey, "error", err.Error())
+	}
+}
+
+func escalationMetadata(metadata map[string]interface{}, policy string, tier, repeats int, escalationOf string) (result map[string]interface{}) {
+	result = maps.Clone(metadata)
+	if result == nil {
+		result = make(map[string]interface{})
+	}
+
+	result["escalation_policy"] = policy
+	result["escalation_tier"] = strconv.Itoa(tier + 1)
+	if repeats > 0 {
+		result["escalation_repeat"] = strconv.Itoa(repeats)
+	}
+	if escalationOf != "" {
+		result["escalation_of"] = escalationOf
+	}
+	return
+}
+
+func remove(values []string, value string) (result []string) {
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return
+}
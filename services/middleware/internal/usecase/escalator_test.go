@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() (now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now = c.now
+	return
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+type testIDs struct {
+	mu   sync.Mutex
+	next int
+}
+
+func (g *testIDs) Generate() (id string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.next++
+	id = fmt.Sprintf("evt-%d", g.next)
+	return
+}
+
+type testResolver struct{}
+
+func (testResolver) Resolve(name string) (destination domain.Destination, err error) {
+	destination = domain.Destination{Name: name, URL: "http://" + name}
+	return
+}
+
+type testQueue struct {
+	mu        sync.Mutex
+	events    []domain.Event
+	onEnqueue func()
+}
+
+func (q *testQueue) Enqueue(ctx context.Context, event domain.Event) (err error) {
+	if q.onEnqueue != nil {
+		q.onEnqueue()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.events = append(q.events, event)
+	return
+}
+
+func (q *testQueue) Destinations() (destinations []string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, event := range q.events {
+		destinations = append(destinations, event.Destination)
+	}
+	return
+}
+
+func withinTimeout(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("%s did not return, is the lock held while enqueuing?", name)
+	}
+}
+
+func TestEscalatorEnqueuesOutsideLock(t *testing.T) {
+	router, err := NewRouter(domain.RoutingConfig{
+		EscalationPolicies: []domain.EscalationPolicy{{
+			Name: "oncall",
+			Tiers: []domain.EscalationTier{
+				{Destinations: []string{"primary", "chat"}, EscalateAfter: domain.Duration(time.Minute)},
+				{Destinations: []string{"secondary"}},
+			},
+		}},
+	}, testResolver{})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	queue := &testQueue{}
+	escalator, err := NewEscalator(context.Background(), router, repository.NewMemoryEscalationStore(), nil, queue, &testIDs{}, clock, logger.New(io.Discard, slog.LevelError))
+	if err != nil {
+		t.Fatalf("NewEscalator returned error: %v", err)
+	}
+	queue.onEnqueue = func() {
+		escalator.List()
+	}
+
+	route := domain.Route{Name: "db", Escalation: "oncall"}
+	event := domain.Event{ID: "trigger", Source: "db", DedupKey: "db/down", Action: domain.ActionTrigger}
+	escalator.Prepare(&event, route)
+
+	withinTimeout(t, "Handle", func() {
+		escalator.Handle(context.Background(), event, route)
+	})
+
+	clock.Advance(time.Minute)
+	withinTimeout(t, "Tick", func() {
+		if escalated := escalator.Tick(context.Background()); escalated != 1 {
+			t.Errorf("escalated = %d, want 1", escalated)
+		}
+	})
+
+	resolve := domain.Event{ID: "resolve", Source: "db", DedupKey: "db/down", Action: domain.ActionResolve, Destination: "primary"}
+	withinTimeout(t, "Handle", func() {
+		escalator.Handle(context.Background(), resolve, route)
+	})
+
+	want := []string{"chat", "secondary", "chat", "secondary"}
+	got := queue.Destinations()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("notified destinations = %v, want %v", got, want)
+	}
+	if len(escalator.List()) != 0 {
+		t.Errorf("escalations = %v, want none after resolve", escalator.List())
+	}
+}
+
+func TestEscalatorAcknowledgeStopsEscalationUntilResolve(t *testing.T) {
+	router, err := NewRouter(domain.RoutingConfig{
+		EscalationPolicies: []domain.EscalationPolicy{{
+			Name: "oncall",
+			Tiers: []domain.EscalationTier{
+				{Destinations: []string{"primary", "chat"}, EscalateAfter: domain.Duration(time.Minute)},
+				{Destinations: []string{"secondary"}, EscalateAfter: domain.Duration(time.Minute)},
+				{Destinations: []string{"manager"}},
+			},
+		}},
+	}, testResolver{})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	queue := &testQueue{}
+	escalator, err := NewEscalator(context.Background(), router, repository.NewMemoryEscalationStore(), nil, queue, &testIDs{}, clock, logger.New(io.Discard, slog.LevelError))
+	if err != nil {
+		t.Fatalf("NewEscalator returned error: %v", err)
+	}
+
+	route := domain.Route{Name: "db", Escalation: "oncall", Destination: "default"}
+	trigger := domain.Event{ID: "trigger", Source: "db", DedupKey: "db/down", Action: domain.ActionTrigger}
+	escalator.Prepare(&trigger, route)
+	escalator.Handle(context.Background(), trigger, route)
+
+	clock.Advance(time.Minute)
+	if escalated := escalator.Tick(context.Background()); escalated != 1 {
+		t.Fatalf("escalated = %d, want 1", escalated)
+	}
+
+	ack := domain.Event{ID: "ack", Source: "db", DedupKey: "db/down", Action: domain.ActionAcknowledge}
+	escalator.Prepare(&ack, route)
+	if ack.Destination != "primary" {
+		t.Errorf("ack destination = %q, want %q", ack.Destination, "primary")
+	}
+	escalator.Handle(context.Background(), ack, route)
+
+	escalations := escalator.List()
+	if len(escalations) != 1 || escalations[0].AcknowledgedAt == nil || escalations[0].NextAt != nil {
+		t.Fatalf("escalations after ack = %+v, want one acknowledged escalation with no next tier", escalations)
+	}
+
+	clock.Advance(time.Hour)
+	if escalated := escalator.Tick(context.Background()); escalated != 0 {
+		t.Errorf("escalated after ack = %d, want 0", escalated)
+	}
+
+	resolve := domain.Event{ID: "resolve", Source: "db", DedupKey: "db/down", Action: domain.ActionResolve}
+	escalator.Prepare(&resolve, route)
+	if resolve.Destination != "primary" {
+		t.Errorf("resolve destination = %q, want %q", resolve.Destination, "primary")
+	}
+	escalator.Handle(context.Background(), resolve, route)
+
+	want := []string{"chat", "secondary", "chat", "secondary", "chat", "secondary"}
+	got := queue.Destinations()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("notified destinations = %v, want %v", got, want)
+	}
+	if len(escalator.List()) != 0 {
+		t.Errorf("escalations = %v, want none after resolve", escalator.List())
+	}
+}
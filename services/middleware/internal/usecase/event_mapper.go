@@ -10,15 +10,21 @@ import (
 
 type eventMapper struct {
 	idGenerator IDGenerator
+	clock       Clock
 }
 
 type IDGenerator interface {
 	Generate() (id string, err error)
 }
 
-func NewEventMapper(idGen IDGenerator) (mapper domain.EventMapper) {
+type Clock interface {
+	Now() (now time.Time)
+}
+
+func NewEventMapper(idGen IDGenerator, clock Clock) (mapper domain.EventMapper) {
 	mapper = &eventMapper{
 		idGenerator: idGen,
+		clock:       clock,
 	}
 	return
 }
@@ -34,14 +40,29 @@ func (m *eventMapper) MapIncomingEvent(incoming domain.IncomingEvent, correlatio
 	var priority domain.Priority
 	priority = m.mapSeverityToPriority(incoming.Severity)
 
+	var action domain.AlertAction
+	action, err = domain.ParseAlertAction(incoming.Action)
+	if err != nil {
+		return
+	}
+
+	destination := strings.TrimSpace(incoming.Destination)
+	if destination == "" {
+		destination = domain.DefaultDestination
+	}
+
 	event = domain.Event{
 		ID:            id,
 		Source:        incoming.Source,
 		EventType:     incoming.EventType,
 		Priority:      priority,
 		Message:       incoming.Message,
-		Timestamp:     time.Now().UTC(),
+		Timestamp:     m.clock.Now().UTC(),
 		CorrelationID: correlationID,
+		Destination:   destination,
+		PartitionKey:  strings.TrimSpace(incoming.PartitionKey),
+		Action:        action,
+		DedupKey:      strings.TrimSpace(incoming.DedupKey),
 		Metadata:      incoming.Metadata,
 	}
 
@@ -6,13 +6,20 @@ import (
 	"fmt"
 
 	"github.com/smartcom/integration-platform/pkg/httpclient"
+	"github.com/smartcom/integration-platform/pkg/redact"
 	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
 )
 
 type eventProcessor struct {
-	httpClient  *httpclient.Client
-	targetURL   string
-	eventLogger EventLogger
+	httpClient   *httpclient.Client
+	clients      map[string]*httpclient.Client
+	destinations DestinationResolver
+	redactors    map[string]*redact.Redactor
+	eventLogger  EventLogger
+}
+
+type DestinationResolver interface {
+	Resolve(name string) (destination domain.Destination, err error)
 }
 
 type EventLogger interface {
@@ -20,11 +27,13 @@ type EventLogger interface {
 	ErrorContext(ctx context.Context, msg string, args ...any)
 }
 
-func NewEventProcessor(client *httpclient.Client, targetURL string, logger EventLogger) (processor domain.EventProcessor) {
+func NewEventProcessor(client *httpclient.Client, clients map[string]*httpclient.Client, destinations DestinationResolver, redactors map[string]*redact.Redactor, logger EventLogger) (processor domain.EventProcessor) {
 	processor = &eventProcessor{
-		httpClient:  client,
-		targetURL:   targetURL,
-		eventLogger: logger,
+		httpClient:   client,
+		clients:      clients,
+		destinations: destinations,
+		redactors:    redactors,
+		eventLogger:  logger,
 	}
 	return
 }
@@ -41,26 +50,49 @@ func (p *eventProcessor) ProcessEvent(event domain.Event) (err error) {
 		"source", event.Source,
 		"type", event.EventType,
 		"priority", event.Priority,
+		"destination", event.Destination,
 	)
 
+	var destination domain.Destination
+	destination, err = p.destinations.Resolve(event.Destination)
+	if err != nil {
+		p.eventLogger.ErrorContext(ctx, "failed to resolve destination",
+			"event_id", event.ID,
+			"destination", event.Destination,
+			"error", err.Error(),
+		)
+		return
+	}
+
 	var payload map[string]interface{}
-	payload = p.buildPayload(event)
+	payload = p.buildPayload(event, p.redactors[destination.Name])
 
 	headers := make(map[string]string)
 	if event.CorrelationID != "" {
 		headers["X-Correlation-ID"] = event.CorrelationID
 	}
+	if event.ReplayOf != "" {
+		headers["X-Replay"] = "true"
+		headers["X-Replay-Of"] = event.ReplayOf
+		headers["X-Replay-Job"] = event.ReplayJobID
+	}
+
+	client := p.httpClient
+	if destinationClient, ok := p.clients[destination.Name]; ok {
+		client = destinationClient
+	}
 
 	var statusCode int
 	var body []byte
-	statusCode, body, err = p.httpClient.PostJSON(ctx, p.targetURL, payload, headers)
+	statusCode, body, err = client.PostJSON(ctx, destination.URL, payload, headers)
 
 	if err != nil {
 		p.eventLogger.ErrorContext(ctx, "failed to send event",
 			"event_id", event.ID,
+			"destination", destination.Name,
 			"error", err.Error(),
 		)
-		err = fmt.Errorf("failed to send event to external endpoint: %w", err)
+		err = fmt.Errorf("failed to send event to destination %s: %w", destination.Name, err)
 		return
 	}
 
@@ -73,15 +105,30 @@ func (p *eventProcessor) ProcessEvent(event domain.Event) (err error) {
 	return
 }
 
-func (p *eventProcessor) buildPayload(event domain.Event) (payload map[string]interface{}) {
+func (p *eventProcessor) buildPayload(event domain.Event, redactor *redact.Redactor) (payload map[string]interface{}) {
+	action := event.Action
+	if action == "" {
+		action = domain.ActionTrigger
+	}
+
+	if redactor != nil {
+		event.Message = redactor.String(event.Message)
+		event.Metadata = redactor.Map(event.Metadata)
+	}
+
 	payload = map[string]interface{}{
 		"event_id":       event.ID,
 		"source":         event.Source,
 		"event_type":     event.EventType,
-		"priority":       p.priorityToString(event.Priority),
+		"priority":       event.Priority.String(),
 		"message":        event.Message,
 		"timestamp":      event.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
 		"correlation_id": event.CorrelationID,
+		"action":         string(action),
+	}
+
+	if event.DedupKey != "" {
+		payload["dedup_key"] = event.DedupKey
 	}
 
 	if event.Metadata != nil {
@@ -92,19 +139,3 @@ func (p *eventProcessor) buildPayload(event domain.Event) (payload map[string]in
 
 	return
 }
-
-func (p *eventProcessor) priorityToString(priority domain.Priority) (result string) {
-	switch priority {
-	case domain.PriorityCritical:
-		result = "critical"
-	case domain.PriorityHigh:
-		result = "high"
-	case domain.PriorityMedium:
-		result = "medium"
-	case domain.PriorityLow:
-		result = "low"
-	default:
-		result = "unknown"
-	}
-	return
-}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/correlation"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var (
+	ErrMappingFailed    = errors.New("failed to map event")
+	ErrEnrichmentFailed = errors.New("failed to enrich event")
+)
+
+type Enricher interface {
+	Enrich(ctx context.Context, event domain.Event) (enriched domain.Event, err error)
+}
+
+type Suppressor interface {
+	Check(event domain.Event) (err error)
+}
+
+type IngestPipeline struct {
+	Enricher    Enricher
+	Suppressors []Suppressor
+	Alerts      *AlertTracker
+	Router      *Router
+	Transformer *Transformer
+	Escalator   *Escalator
+	Digester    *Digester
+}
+
+type Ingestor struct {
+	mapper   domain.EventMapper
+	queue    EventEnqueuer
+	store    domain.EventStore
+	pipeline IngestPipeline
+	logger   EventLogger
+}
+
+func NewIngestor(mapper domain.EventMapper, queue EventEnqueuer, store domain.EventStore, pipeline IngestPipeline, logger EventLogger) (ingestor *Ingestor) {
+	ingestor = &Ingestor{
+		mapper:   mapper,
+		queue:    queue,
+		store:    store,
+		pipeline: pipeline,
+		logger:   logger,
+	}
+	return
+}
+
+func (i *Ingestor) Ingest(ctx context.Context, incoming domain.IncomingEvent, correlationID string) (event domain.Event, err error) {
+	err = incoming.Validate()
+	if err != nil {
+		return
+	}
+
+	event, err = i.mapper.MapIncomingEvent(incoming, correlationID)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrMappingFailed, err)
+		return
+	}
+
+	if i.pipeline.Enricher != nil {
+		var enriched domain.Event
+		enriched, err = i.pipeline.Enricher.Enrich(ctx, event)
+		if err != nil {
+			err = fmt.Errorf("%w: %w", ErrEnrichmentFailed, err)
+			i.recordFailed(ctx, event, err)
+			return
+		}
+		event = enriched
+	}
+
+	for _, suppressor := range i.pipeline.Suppressors {
+		err = suppressor.Check(event)
+		if err != nil {
+			i.recordSuppressed(ctx, event, err)
+			return
+		}
+	}
+
+	if i.pipeline.Alerts != nil {
+		err = i.pipeline.Alerts.Check(event)
+		if err != nil {
+			i.recordSuppressed(ctx, event, err)
+			return
+		}
+	}
+
+	var route domain.Route
+	if i.pipeline.Router != nil {
+		var matched bool
+		route, matched = i.pipeline.Router.Match(event)
+		if matched && route.Destination != "" && event.Destination == domain.DefaultDestination {
+			event.Destination = route.Destination
+		}
+	}
+
+	if route.Name != "" && i.pipeline.Transformer != nil {
+		event, err = i.pipeline.Transformer.Apply(ctx, event, route.Name)
+		if err != nil {
+			i.recordSuppressed(ctx, event, err)
+			return
+		}
+	}
+
+	if route.Digest != nil && i.pipeline.Digester != nil {
+		i.recordEvent(ctx, event, domain.StatusDigested)
+		i.pipeline.Digester.Add(event, route.Name)
+
+		if i.pipeline.Alerts != nil {
+			i.pipeline.Alerts.Apply(event)
+		}
+		return
+	}
+
+	if i.pipeline.Escalator != nil {
+		i.pipeline.Escalator.Prepare(&event, route)
+	}
+
+	i.recordEvent(ctx, event, domain.StatusAccepted)
+
+	err = i.queue.Enqueue(ctx, event)
+	if err != nil {
+		i.recordRejected(ctx, event, err)
+		err = fmt.Errorf("failed to enqueue event: %w", err)
+		return
+	}
+
+	if i.pipeline.Alerts != nil {
+		i.pipeline.Alerts.Apply(event)
+	}
+	if i.pipeline.Escalator != nil {
+		i.pipeline.Escalator.Handle(ctx, event, route)
+	}
+	return
+}
+
+func (i *Ingestor) StartAutoResolve(ctx context.Context, interval time.Duration) {
+	if i.pipeline.Alerts == nil || i.pipeline.Alerts.AutoResolveAfter() <= 0 || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				i.AutoResolve(ctx)
+			}
+		}
+	}()
+}
+
+func (i *Ingestor) AutoResolve(ctx context.Context) (resolved int) {
+	if i.pipeline.Alerts == nil {
+		return
+	}
+
+	for _, alert := range i.pipeline.Alerts.Expired() {
+		correlationID, err := correlation.GenerateID()
+		if err != nil {
+			i.logger.ErrorContext(ctx, "failed to generate correlation ID", "error", err.Error())
+			return
+		}
+
+		incoming := domain.IncomingEvent{
+			Source:      alert.Source,
+			EventType:   alert.EventType,
+			Severity:    alert.Priority.String(),
+			Message:     fmt.Sprintf("auto-resolved after %s without a trigger: %s", i.pipeline.Alerts.AutoResolveAfter(), alert.Message),
+			Destination: alert.Destination,
+			Action:      string(domain.ActionResolve),
+			DedupKey:    alert.DedupKey,
+			Metadata: map[string]interface{}{
+				"auto_resolved":     true,
+				"last_triggered_at": alert.LastTriggeredAt.Format(time.RFC3339),
+			},
+		}
+
+		var event domain.Event
+		event, err = i.Ingest(correlation.WithID(ctx, correlationID), incoming, correlationID)
+		if err != nil {
+			i.logger.ErrorContext(ctx, "failed to auto-resolve alert", "dedup_key", alert.DedupKey, "error", err.Error())
+			continue
+		}
+
+		i.logger.InfoContext(ctx, "alert auto-resolved", "dedup_key", alert.DedupKey, "event_id", event.ID)
+		resolved++
+	}
+	return
+}
+
+func (i *Ingestor) recordEvent(ctx context.Context, event domain.Event, status domain.DeliveryStatus) {
+	if i.store == nil {
+		return
+	}
+
+	err := i.store.Record(ctx, domain.NewEventRecord(event, status))
+	if err != nil {
+		i.logger.ErrorContext(ctx, "failed to record event", "event_id", event.ID, "error", err.Error())
+	}
+}
+
+func (i *Ingestor) recordSuppressed(ctx context.Context, event domain.Event, reason error) {
+	if i.store == nil {
+		return
+	}
+
+	record := domain.NewEventRecord(event, domain.StatusSuppressed)
+	record.Error = reason.Error()
+
+	var dropped *DroppedError
+	if errors.As(reason, &dropped) {
+		record.Status = domain.StatusDropped
+	}
+
+	var silenced *SilencedError
+	if errors.As(reason, &silenced) {
+		record.SilenceID = silenced.SilenceID
+	}
+
+	var inhibited *InhibitedError
+	if errors.As(reason, &inhibited) {
+		record.InhibitedBy = inhibited.AlertKey
+	}
+
+	err := i.store.Record(ctx, record)
+	if err != nil {
+		i.logger.ErrorContext(ctx, "failed to record suppressed event", "event_id", event.ID, "error", err.Error())
+	}
+}
+
+func (i *Ingestor) recordFailed(ctx context.Context, event domain.Event, reason error) {
+	if i.store == nil {
+		return
+	}
+
+	record := domain.NewEventRecord(event, domain.StatusRejected)
+	record.Error = reason.Error()
+
+	err := i.store.Record(ctx, record)
+	if err != nil {
+		i.logger.ErrorContext(ctx, "failed to record rejected event", "event_id", event.ID, "error", err.Error())
+	}
+}
+
+func (i *Ingestor) recordRejected(ctx context.Context, event domain.Event, reason error) {
+	if i.store == nil {
+		return
+	}
+
+	err := i.store.UpdateStatus(ctx, event.ID, domain.StatusUpdate{
+		Status:    domain.StatusRejected,
+		Error:     reason.Error(),
+		UpdatedAt: event.Timestamp,
+	})
+	if err != nil {
+		i.logger.ErrorContext(ctx, "failed to record rejected event", "event_id", event.ID, "error", err.Error())
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type Inhibitor struct {
+	rules  []domain.InhibitRule
+	alerts *AlertTracker
+}
+
+func NewInhibitor(rules []domain.InhibitRule, alerts *AlertTracker) (inhibitor *Inhibitor, err error) {
+	for i := range rules {
+		err = rules[i].Validate()
+		if err != nil {
+			return
+		}
+	}
+
+	inhibitor = &Inhibitor{
+		rules:  rules,
+		alerts: alerts,
+	}
+	return
+}
+
+func (i *Inhibitor) Check(event domain.Event) (err error) {
+	if len(i.rules) == 0 || (event.Action != "" && event.Action != domain.ActionTrigger) {
+		return
+	}
+
+	for _, rule := range i.rules {
+		if !domain.MatchAll(rule.TargetMatchers, event) {
+			continue
+		}
+
+		source, ok := i.alerts.findOpen(inhibitLabels(rule, event), func(alert domain.Alert) bool {
+			if event.DedupKey != "" && alert.DedupKey == event.DedupKey {
+				return false
+			}
+			return rule.Inhibits(alert.Event(), event)
+		})
+		if ok {
+			err = &InhibitedError{Rule: rule.Name, AlertKey: source.DedupKey}
+			return
+		}
+	}
+	return
+}
+
+func inhibitLabels(rule domain.InhibitRule, target domain.Event) (labels []string) {
+	for _, matcher := range rule.SourceMatchers {
+		if matcher.Operator == domain.MatchEqual {
+			labels = append(labels, alertLabel(matcher.Field, matcher.Value))
+		}
+	}
+	for _, field := range rule.Equal {
+		if value, present := domain.FieldValue(target, field); present && value != "" {
+			labels = append(labels, alertLabel(field, value))
+		}
+	}
+	return
+}
+
+func (i *Inhibitor) Rules() (rules []domain.InhibitRule) {
+	rules = i.rules
+	return
+}
+
+type InhibitedError struct {
+	Rule     string
+	AlertKey string
+}
+
+func (e *InhibitedError) Error() (msg string) {
+	msg = fmt.Sprintf("%s: inhibited by alert %s (rule %s)", ErrSuppressed, e.AlertKey, e.Rule)
+	return
+}
+
+func (e *InhibitedError) Unwrap() (err error) {
+	err = ErrSuppressed
+	return
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func inhibitEvent(id, source, eventType string, priority domain.Priority, metadata map[string]interface{}) (event domain.Event) {
+	event = domain.Event{
+		ID:        id,
+		Source:    source,
+		EventType: eventType,
+		Priority:  priority,
+		Message:   eventType + " on " + source,
+		DedupKey:  source + "/" + eventType,
+		Action:    domain.ActionTrigger,
+		Metadata:  metadata,
+	}
+	return
+}
+
+func testInhibitor(t *testing.T, tracker *AlertTracker) (inhibitor *Inhibitor) {
+	t.Helper()
+
+	inhibitor, err := NewInhibitor([]domain.InhibitRule{{
+		Name:           "datacenter-down",
+		SourceMatchers: []domain.Matcher{{Field: "event_type", Value: "datacenter_down"}},
+		TargetMatchers: []domain.Matcher{{Field: "event_type", Operator: domain.MatchNotEqual, Value: "datacenter_down"}},
+		Equal:          []string{"metadata.datacenter"},
+	}}, tracker)
+	if err != nil {
+		t.Fatalf("NewInhibitor returned error: %v", err)
+	}
+	return
+}
+
+func TestInhibitorCheck(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tracker := NewAlertTracker(clock, 0)
+	inhibitor := testInhibitor(t, tracker)
+
+	tracker.Apply(inhibitEvent("evt-1", "dc-fra", "datacenter_down", domain.PriorityCritical, map[string]interface{}{"datacenter": "fra"}))
+	tracker.Apply(inhibitEvent("evt-2", "dc-ams", "datacenter_down", domain.PriorityMedium, map[string]interface{}{"datacenter": "ams"}))
+
+	resolve := inhibitEvent("evt-3", "web", "server_down", domain.PriorityHigh, map[string]interface{}{"datacenter": "fra"})
+	resolve.Action = domain.ActionResolve
+
+	tests := []struct {
+		name      string
+		event     domain.Event
+		inhibited bool
+	}{
+		{
+			name:      "lower priority target with equal label",
+			event:     inhibitEvent("evt-3", "web", "server_down", domain.PriorityHigh, map[string]interface{}{"datacenter": "fra"}),
+			inhibited: true,
+		},
+		{
+			name:  "different label value",
+			event: inhibitEvent("evt-3", "web", "server_down", domain.PriorityHigh, map[string]interface{}{"datacenter": "lhr"}),
+		},
+		{
+			name:  "missing equal label",
+			event: inhibitEvent("evt-3", "web", "server_down", domain.PriorityHigh, nil),
+		},
+		{
+			name:  "source priority is not higher",
+			event: inhibitEvent("evt-3", "web", "server_down", domain.PriorityMedium, map[string]interface{}{"datacenter": "ams"}),
+		},
+		{
+			name:  "target matchers do not match",
+			event: inhibitEvent("evt-3", "dc-fra-2", "datacenter_down", domain.PriorityLow, map[string]interface{}{"datacenter": "fra"}),
+		},
+		{
+			name:  "resolve is never inhibited",
+			event: resolve,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := inhibitor.Check(tt.event)
+			if inhibited := errors.Is(err, ErrSuppressed); inhibited != tt.inhibited {
+				t.Errorf("Check error = %v, want inhibited %v", err, tt.inhibited)
+			}
+
+			var inhibitedErr *InhibitedError
+			if tt.inhibited && (!errors.As(err, &inhibitedErr) || inhibitedErr.AlertKey != "dc-fra/datacenter_down" || inhibitedErr.Rule != "datacenter-down") {
+				t.Errorf("Check error = %v, want inhibited by dc-fra/datacenter_down under datacenter-down", err)
+			}
+		})
+	}
+}
+
+func TestInhibitorFollowsAlertLifecycle(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tracker := NewAlertTracker(clock, 0)
+	inhibitor := testInhibitor(t, tracker)
+
+	target := inhibitEvent("evt-9", "web", "server_down", domain.PriorityHigh, map[string]interface{}{"datacenter": "fra"})
+	source := inhibitEvent("evt-1", "dc", "datacenter_down", domain.PriorityCritical, map[string]interface{}{"datacenter": "ams"})
+
+	tracker.Apply(source)
+	if err := inhibitor.Check(target); err != nil {
+		t.Errorf("Check error = %v, want nil while the source alert is in another datacenter", err)
+	}
+
+	source.ID = "evt-2"
+	source.Metadata = map[string]interface{}{"datacenter": "fra"}
+	tracker.Apply(source)
+	if err := inhibitor.Check(target); !errors.Is(err, ErrSuppressed) {
+		t.Errorf("Check error = %v, want inhibited after the source alert moved to fra", err)
+	}
+
+	source.ID = "evt-3"
+	source.Action = domain.ActionResolve
+	tracker.Apply(source)
+	if err := inhibitor.Check(target); err != nil {
+		t.Errorf("Check error = %v, want nil after the source alert resolved", err)
+	}
+
+	tracker.Restore(inhibitEvent("evt-4", "dc", "datacenter_down", domain.PriorityCritical, map[string]interface{}{"datacenter": "fra"}), clock.Now())
+	if err := inhibitor.Check(target); !errors.Is(err, ErrSuppressed) {
+		t.Errorf("Check error = %v, want inhibited by the restored alert", err)
+	}
+}
+
+func TestInhibitorReportsEarliestSource(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tracker := NewAlertTracker(clock, 0)
+	inhibitor := testInhibitor(t, tracker)
+
+	for i := 0; i < 5; i++ {
+		tracker.Apply(inhibitEvent(fmt.Sprintf("evt-%d", i), fmt.Sprintf("dc-%d", i), "datacenter_down", domain.PriorityCritical, map[string]interface{}{"datacenter": "fra"}))
+		clock.Advance(time.Minute)
+	}
+
+	var inhibitedErr *InhibitedError
+	err := inhibitor.Check(inhibitEvent("evt-9", "web", "server_down", domain.PriorityHigh, map[string]interface{}{"datacenter": "fra"}))
+	if !errors.As(err, &inhibitedErr) || inhibitedErr.AlertKey != "dc-0/datacenter_down" {
+		t.Errorf("Check error = %v, want inhibited by dc-0/datacenter_down", err)
+	}
+}
+
+func TestNewInhibitorRejectsInvalidRules(t *testing.T) {
+	tracker := NewAlertTracker(&testClock{}, 0)
+
+	tests := []struct {
+		name string
+		rule domain.InhibitRule
+	}{
+		{name: "no name", rule: domain.InhibitRule{SourceMatchers: []domain.Matcher{{Field: "source", Value: "dc"}}, TargetMatchers: []domain.Matcher{{Field: "source", Value: "web"}}}},
+		{name: "no target matchers", rule: domain.InhibitRule{Name: "r", SourceMatchers: []domain.Matcher{{Field: "source", Value: "dc"}}}},
+		{name: "unknown equal field", rule: domain.InhibitRule{Name: "r", SourceMatchers: []domain.Matcher{{Field: "source", Value: "dc"}}, TargetMatchers: []domain.Matcher{{Field: "source", Value: "web"}}, Equal: []string{"host"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewInhibitor([]domain.InhibitRule{tt.rule}, tracker)
+			if !errors.Is(err, domain.ErrInvalidInhibitRule) {
+				t.Errorf("NewInhibitor error = %v, want %v", err, domain.ErrInvalidInhibitRule)
+			}
+		})
+	}
+}
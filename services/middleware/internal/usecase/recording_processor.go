@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+type recordingProcessor struct {
+	next   domain.EventProcessor
+	store  domain.EventStore
+	clock  Clock
+	logger EventLogger
+}
+
+func NewRecordingProcessor(next domain.EventProcessor, store domain.EventStore, clock Clock, logger EventLogger) (processor domain.EventProcessor) {
+	processor = &recordingProcessor{
+		next:   next,
+		store:  store,
+		clock:  clock,
+		logger: logger,
+	}
+	return
+}
+
+func (p *recordingProcessor) ProcessEvent(event domain.Event) (err error) {
+	err = p.next.ProcessEvent(event)
+	if errors.Is(err, repository.ErrDestinationDisabled) {
+		return
+	}
+
+	update := domain.StatusUpdate{
+		Status:    domain.StatusDelivered,
+		UpdatedAt: p.clock.Now().UTC(),
+	}
+	if err != nil {
+		update.Status = domain.StatusFailed
+		update.Error = err.Error()
+	}
+
+	ctx := context.Background()
+	storeErr := p.store.UpdateStatus(ctx, event.ID, update)
+	if storeErr != nil {
+		p.logger.ErrorContext(ctx, "failed to record delivery outcome",
+			"event_id", event.ID,
+			"status", update.Status,
+			"error", storeErr.Error(),
+		)
+	}
+	return
+}
+
+func (p *recordingProcessor) FailEvent(ctx context.Context, event domain.Event, err error) {
+	storeErr := p.store.UpdateStatus(ctx, event.ID, domain.StatusUpdate{
+		Status:    domain.StatusFailed,
+		Error:     err.Error(),
+		UpdatedAt: p.clock.Now().UTC(),
+	})
+	if storeErr != nil {
+		p.logger.ErrorContext(ctx, "failed to record delivery outcome",
+			"event_id", event.ID,
+			"status", domain.StatusFailed,
+			"error", storeErr.Error(),
+		)
+	}
+}
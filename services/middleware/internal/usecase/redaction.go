@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/smartcom/integration-platform/pkg/redact"
+)
+
+type RedactionConfig struct {
+	redact.Config
+	Destinations map[string]DestinationRedaction `json:"destinations,omitempty"`
+}
+
+type DestinationRedaction struct {
+	Strategy string `json:"strategy,omitempty"`
+}
+
+func DefaultRedactionConfig() (cfg RedactionConfig) {
+	cfg = RedactionConfig{Config: redact.DefaultConfig()}
+	return
+}
+
+func NewDestinationRedactors(cfg RedactionConfig, base *redact.Redactor, destinations DestinationResolver) (redactors map[string]*redact.Redactor, err error) {
+	redactors = make(map[string]*redact.Redactor, len(cfg.Destinations))
+	for name, override := range cfg.Destinations {
+		_, err = destinations.Resolve(name)
+		if err != nil {
+			redactors = nil
+			err = fmt.Errorf("%w: destination %s: %w", redact.ErrInvalidConfig, name, err)
+			return
+		}
+
+		redactor := base
+		if override.Strategy != "" {
+			redactor, err = base.WithStrategy(override.Strategy)
+			if err != nil {
+				redactors = nil
+				err = fmt.Errorf("destination %s: %w", name, err)
+				return
+			}
+		}
+		redactors[name] = redactor
+	}
+	return
+}
@@ -0,0 +1,407 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var (
+	ErrReplayNotFound      = errors.New("replay not found")
+	ErrReplayNotRunning    = errors.New("replay is not running")
+	ErrInvalidReplayWindow = errors.New("replay window requires from before to")
+	ErrInvalidReplayStatus = errors.New("unknown replay status")
+)
+
+var defaultReplayStatuses = []domain.DeliveryStatus{
+	domain.StatusDelivered,
+	domain.StatusFailed,
+	domain.StatusRejected,
+}
+
+type ReplayState string
+
+const (
+	ReplayRunning   ReplayState = "running"
+	ReplayCompleted ReplayState = "completed"
+	ReplayCancelled ReplayState = "cancelled"
+	ReplayFailed    ReplayState = "failed"
+)
+
+const (
+	DefaultReplayRate      = 50
+	DefaultReplayMaxEvents = 10000
+)
+
+type ReplayFilter struct {
+	Source        string            `json:"source,omitempty"`
+	EventType     string            `json:"event_type,omitempty"`
+	Priority      string            `json:"priority,omitempty"`
+	Status        string            `json:"status,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	Destination   string            `json:"destination,omitempty"`
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to,omitzero"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+type ReplayRequest struct {
+	Filter      ReplayFilter `json:"filter"`
+	Destination string       `json:"destination,omitempty"`
+	Rate        float64      `json:"rate,omitempty"`
+	MaxEvents   int          `json:"max_events,omitempty"`
+}
+
+type ReplayJob struct {
+	ID         string        `json:"id"`
+	State      ReplayState   `json:"state"`
+	Request    ReplayRequest `json:"request"`
+	Selected   int           `json:"selected"`
+	Enqueued   int           `json:"enqueued"`
+	Delivered  int           `json:"delivered"`
+	Failed     int           `json:"failed"`
+	Pending    int           `json:"pending"`
+	Error      string        `json:"error,omitempty"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt *time.Time    `json:"finished_at,omitempty"`
+}
+
+type EventEnqueuer interface {
+	Enqueue(ctx context.Context, event domain.Event) (err error)
+}
+
+type Replayer struct {
+	store        domain.EventStore
+	queue        EventEnqueuer
+	destinations DestinationResolver
+	idGenerator  IDGenerator
+	clock        Clock
+	logger       EventLogger
+
+	mu   sync.RWMutex
+	jobs map[string]*replayRun
+}
+
+type replayRun struct {
+	job      ReplayJob
+	eventIDs []string
+	cancel   context.CancelFunc
+}
+
+func NewReplayer(store domain.EventStore, queue EventEnqueuer, destinations DestinationResolver, idGen IDGenerator, clock Clock, logger EventLogger) (replayer *Replayer) {
+	replayer = &Replayer{
+		store:        store,
+		queue:        queue,
+		destinations: destinations,
+		idGenerator:  idGen,
+		clock:        clock,
+		logger:       logger,
+		jobs:         make(map[string]*replayRun),
+	}
+	return
+}
+
+func (r *Replayer) Start(ctx context.Context, request ReplayRequest) (job ReplayJob, err error) {
+	request, err = r.validate(request)
+	if err != nil {
+		return
+	}
+
+	var id string
+	id, err = r.idGenerator.Generate()
+	if err != nil {
+		err = fmt.Errorf("failed to generate replay ID: %w", err)
+		return
+	}
+
+	var records []domain.EventRecord
+	records, err = r.selectRecords(ctx, request)
+	if err != nil {
+		err = fmt.Errorf("failed to select events: %w", err)
+		return
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	run := &replayRun{
+		job: ReplayJob{
+			ID:        id,
+			State:     ReplayRunning,
+			Request:   request,
+			Selected:  len(records),
+			StartedAt: r.clock.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+
+	r.mu.Lock()
+	r.jobs[id] = run
+	r.mu.Unlock()
+
+	r.logger.InfoContext(ctx, "replay started",
+		"replay_id", id,
+		"selected", len(records),
+		"destination", request.Destination,
+		"rate", request.Rate,
+	)
+
+	go r.run(runCtx, run, records)
+
+	job = run.job
+	return
+}
+
+func (r *Replayer) Get(ctx context.Context, id string) (job ReplayJob, err error) {
+	r.mu.RLock()
+	run, ok := r.jobs[id]
+	if !ok {
+		r.mu.RUnlock()
+		err = fmt.Errorf("%w: %s", ErrReplayNotFound, id)
+		return
+	}
+	job = run.job
+	eventIDs := slices.Clone(run.eventIDs)
+	r.mu.RUnlock()
+
+	for _, eventID := range eventIDs {
+		record, getErr := r.store.Get(ctx, eventID)
+		switch {
+		case getErr != nil:
+			job.Pending++
+		case record.Status == domain.StatusDelivered:
+			job.Delivered++
+		case record.Status == domain.StatusFailed, record.Status == domain.StatusRejected:
+			job.Failed++
+		default:
+			job.Pending++
+		}
+	}
+	return
+}
+
+func (r *Replayer) List() (jobs []ReplayJob) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	jobs = make([]ReplayJob, 0, len(r.jobs))
+	for _, run := range r.jobs {
+		jobs = append(jobs, run.job)
+	}
+	slices.SortFunc(jobs, func(a, b ReplayJob) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return
+}
+
+func (r *Replayer) Cancel(id string) (err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	run, ok := r.jobs[id]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrReplayNotFound, id)
+		return
+	}
+	if run.job.State != ReplayRunning {
+		err = fmt.Errorf("%w: %s", ErrReplayNotRunning, id)
+		return
+	}
+
+	run.cancel()
+	return
+}
+
+func (r *Replayer) validate(request ReplayRequest) (validated ReplayRequest, err error) {
+	validated = request
+
+	if validated.Filter.To.IsZero() {
+		validated.Filter.To = r.clock.Now().UTC()
+	}
+	if validated.Filter.From.IsZero() || !validated.Filter.From.Before(validated.Filter.To) {
+		err = ErrInvalidReplayWindow
+		return
+	}
+
+	switch domain.DeliveryStatus(validated.Filter.Status) {
+	case "", domain.StatusAccepted, domain.StatusRejected, domain.StatusDelivered, domain.StatusFailed,
+		domain.StatusSuppressed, domain.StatusDigested, domain.StatusDropped:
+	default:
+		err = fmt.Errorf("%w: %s", ErrInvalidReplayStatus, validated.Filter.Status)
+		return
+	}
+
+	if validated.Filter.Priority != "" {
+		_, err = domain.ParsePriority(validated.Filter.Priority)
+		if err != nil {
+			return
+		}
+	}
+
+	if validated.Destination != "" {
+		_, err = r.destinations.Resolve(validated.Destination)
+		if err != nil {
+			return
+		}
+	}
+
+	if validated.Rate <= 0 {
+		validated.Rate = DefaultReplayRate
+	}
+	if validated.MaxEvents <= 0 {
+		validated.MaxEvents = DefaultReplayMaxEvents
+	}
+	return
+}
+
+func (r *Replayer) selectRecords(ctx context.Context, request ReplayRequest) (records []domain.EventRecord, err error) {
+	filter := request.Filter
+	query := domain.EventQuery{
+		Source:        filter.Source,
+		EventType:     filter.EventType,
+		Status:        domain.DeliveryStatus(filter.Status),
+		CorrelationID: filter.CorrelationID,
+		Destination:   filter.Destination,
+		From:          filter.From,
+		To:            filter.To,
+		Metadata:      filter.Metadata,
+	}
+	if filter.Priority != "" {
+		var priority domain.Priority
+		priority, _ = domain.ParsePriority(filter.Priority)
+		query.Priority = &priority
+	}
+
+	for {
+		var page domain.EventPage
+		page, err = r.store.Query(ctx, query)
+		if err != nil {
+			return
+		}
+
+		for _, record := range page.Records {
+			if record.ReplayOf != "" {
+				continue
+			}
+			if query.Status == "" && !slices.Contains(defaultReplayStatuses, record.Status) {
+				continue
+			}
+			records = append(records, record)
+			if len(records) >= request.MaxEvents {
+				break
+			}
+		}
+
+		if page.NextCursor == "" || len(records) >= request.MaxEvents {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	slices.Reverse(records)
+	return
+}
+
+func (r *Replayer) run(ctx context.Context, run *replayRun, records []domain.EventRecord) {
+	defer run.cancel()
+
+	interval := time.Duration(float64(time.Second) / run.job.Request.Rate)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var err error
+	for i, record := range records {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		if err != nil {
+			break
+		}
+
+		err = r.replayOne(ctx, run, record)
+		if err != nil {
+			break
+		}
+	}
+
+	r.finish(ctx, run, err)
+}
+
+func (r *Replayer) replayOne(ctx context.Context, run *replayRun, record domain.EventRecord) (err error) {
+	event := record.Event()
+
+	event.ID, err = r.idGenerator.Generate()
+	if err != nil {
+		err = fmt.Errorf("failed to generate event ID: %w", err)
+		return
+	}
+	event.ReplayOf = record.ID
+	event.ReplayJobID = run.job.ID
+	if run.job.Request.Destination != "" {
+		event.Destination = run.job.Request.Destination
+	}
+
+	event.Metadata = maps.Clone(record.Metadata)
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]interface{})
+	}
+	event.Metadata["replay"] = true
+	event.Metadata["replay_of"] = record.ID
+	event.Metadata["replay_job_id"] = run.job.ID
+
+	err = r.store.Record(ctx, domain.NewEventRecord(event, domain.StatusAccepted))
+	if err != nil {
+		err = fmt.Errorf("failed to record replayed event: %w", err)
+		return
+	}
+
+	err = r.queue.Enqueue(ctx, event)
+	if err != nil {
+		_ = r.store.UpdateStatus(ctx, event.ID, domain.StatusUpdate{
+			Status:    domain.StatusRejected,
+			Error:     err.Error(),
+			UpdatedAt: r.clock.Now().UTC(),
+		})
+		err = fmt.Errorf("failed to enqueue replayed event: %w", err)
+		return
+	}
+
+	r.mu.Lock()
+	run.job.Enqueued++
+	run.eventIDs = append(run.eventIDs, event.ID)
+	r.mu.Unlock()
+	return
+}
+
+func (r *Replayer) finish(ctx context.Context, run *replayRun, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	finishedAt := r.clock.Now().UTC()
+	run.job.FinishedAt = &finishedAt
+
+	switch {
+	case err == nil:
+		run.job.State = ReplayCompleted
+	case errors.Is(err, context.Canceled):
+		run.job.State = ReplayCancelled
+	default:
+		run.job.State = ReplayFailed
+		run.job.Error = err.Error()
+	}
+
+	r.logger.InfoContext(ctx, "replay finished",
+		"replay_id", run.job.ID,
+		"state", run.job.State,
+		"selected", run.job.Selected,
+		"enqueued", run.job.Enqueued,
+	)
+}
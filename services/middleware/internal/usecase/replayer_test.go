@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+func TestReplayerSelectsStatuses(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := repository.NewMemoryEventStore(100)
+
+	statuses := []domain.DeliveryStatus{
+		domain.StatusAccepted,
+		domain.StatusRejected,
+		domain.StatusDelivered,
+		domain.StatusFailed,
+		domain.StatusSuppressed,
+		domain.StatusDigested,
+		domain.StatusDropped,
+	}
+	for i, status := range statuses {
+		event := domain.Event{
+			ID:          string(status),
+			Source:      "db",
+			Destination: domain.DefaultDestination,
+			Timestamp:   start.Add(time.Duration(i) * time.Minute),
+		}
+		err := store.Record(context.Background(), domain.NewEventRecord(event, status))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		status string
+		want   []string
+	}{
+		{"", []string{"rejected", "delivered", "failed"}},
+		{"suppressed", []string{"suppressed"}},
+		{"dropped", []string{"dropped"}},
+		{"accepted", []string{"accepted"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("status="+tt.status, func(t *testing.T) {
+			queue := &testQueue{}
+			clock := &testClock{now: start.Add(time.Hour)}
+			replayer := NewReplayer(store, queue, testResolver{}, &testIDs{}, clock, logger.New(io.Discard, slog.LevelError))
+
+			job, err := replayer.Start(context.Background(), ReplayRequest{
+				Filter: ReplayFilter{From: start, Status: tt.status},
+				Rate:   1000,
+			})
+			if err != nil {
+				t.Fatalf("Start returned error: %v", err)
+			}
+			if job.Selected != len(tt.want) {
+				t.Fatalf("selected = %d, want %d", job.Selected, len(tt.want))
+			}
+
+			waitForReplay(t, replayer, job.ID)
+
+			var replayed []string
+			for _, event := range queue.events {
+				replayed = append(replayed, event.ReplayOf)
+			}
+			if !slices.Equal(replayed, tt.want) {
+				t.Errorf("replayed = %v, want %v", replayed, tt.want)
+			}
+		})
+	}
+
+	replayer := NewReplayer(store, &testQueue{}, testResolver{}, &testIDs{}, &testClock{now: start.Add(time.Hour)}, logger.New(io.Discard, slog.LevelError))
+	_, err := replayer.Start(context.Background(), ReplayRequest{Filter: ReplayFilter{From: start, Status: "lost"}})
+	if !errors.Is(err, ErrInvalidReplayStatus) {
+		t.Errorf("error = %v, want ErrInvalidReplayStatus", err)
+	}
+}
+
+func waitForReplay(t *testing.T, replayer *Replayer, id string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := replayer.Get(context.Background(), id)
+		if err == nil && job.State != ReplayRunning {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("replay %s still running", id)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
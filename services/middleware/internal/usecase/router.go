@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+type Router struct {
+	config   domain.RoutingConfig
+	policies map[string]domain.EscalationPolicy
+}
+
+func NewRouter(config domain.RoutingConfig, destinations DestinationResolver) (router *Router, err error) {
+	router = &Router{
+		config:   config,
+		policies: make(map[string]domain.EscalationPolicy, len(config.EscalationPolicies)),
+	}
+
+	for _, policy := range config.EscalationPolicies {
+		err = policy.Validate()
+		if err != nil {
+			router = nil
+			return
+		}
+		if _, exists := router.policies[policy.Name]; exists {
+			router = nil
+			err = fmt.Errorf("%w: duplicate escalation policy %s", domain.ErrInvalidRoute, policy.Name)
+			return
+		}
+
+		for _, tier := range policy.Tiers {
+			for _, name := range tier.Destinations {
+				_, err = destinations.Resolve(name)
+				if err != nil {
+					router = nil
+					err = fmt.Errorf("%w: escalation policy %s: %w", domain.ErrInvalidRoute, policy.Name, err)
+					return
+				}
+			}
+		}
+		router.policies[policy.Name] = policy
+	}
+
+	for i := range router.config.Routes {
+		route := &router.config.Routes[i]
+
+		err = route.Validate()
+		if err != nil {
+			router = nil
+			return
+		}
+		for _, name := range []string{route.Destination, digestDestination(*route)} {
+			if name == "" {
+				continue
+			}
+			_, err = destinations.Resolve(name)
+			if err != nil {
+				router = nil
+				err = fmt.Errorf("%w: route %s: %w", domain.ErrInvalidRoute, route.Name, err)
+				return
+			}
+		}
+		if _, ok := router.policies[route.Escalation]; route.Escalation != "" && !ok {
+			router = nil
+			err = fmt.Errorf("%w: route %s: unknown escalation policy %s", domain.ErrInvalidRoute, route.Name, route.Escalation)
+			return
+		}
+	}
+	return
+}
+
+func (r *Router) DigestRoutes() (routes []domain.Route) {
+	for _, route := range r.config.Routes {
+		if route.Digest != nil {
+			routes = append(routes, route)
+		}
+	}
+	return
+}
+
+func (r *Router) Match(event domain.Event) (route domain.Route, ok bool) {
+	for _, candidate := range r.config.Routes {
+		if candidate.Matches(event) {
+			route, ok = candidate, true
+			return
+		}
+	}
+	return
+}
+
+func (r *Router) Policy(name string) (policy domain.EscalationPolicy, ok bool) {
+	policy, ok = r.policies[name]
+	return
+}
+
+func (r *Router) Config() (config domain.RoutingConfig) {
+	config = r.config
+	return
+}
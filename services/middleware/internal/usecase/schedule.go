@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+type Schedule interface {
+	Next(after time.Time) (next time.Time)
+}
+
+var scheduleAliases = map[string]string{
+	"hourly":  "0 * * * *",
+	"daily":   "0 0 * * *",
+	"weekly":  "0 0 * * 0",
+	"monthly": "0 0 1 * *",
+}
+
+type intervalSchedule struct {
+	every time.Duration
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+	location                      *time.Location
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func ParseSchedule(spec string, location *time.Location) (schedule Schedule, err error) {
+	spec = strings.TrimSpace(spec)
+	if location == nil {
+		location = time.UTC
+	}
+
+	if every, ok := strings.CutPrefix(spec, "@every "); ok {
+		var interval time.Duration
+		interval, err = time.ParseDuration(strings.TrimSpace(every))
+		if err != nil || interval < time.Minute {
+			err = fmt.Errorf("%w: @every needs a duration of at least 1m: %q", ErrInvalidSchedule, spec)
+			return
+		}
+		schedule = intervalSchedule{every: interval}
+		return
+	}
+
+	if alias, ok := scheduleAliases[strings.TrimPrefix(spec, "@")]; ok {
+		spec = alias
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		err = fmt.Errorf("%w: %q must be hourly, daily, weekly, monthly, @every <duration> or a 5-field cron expression", ErrInvalidSchedule, spec)
+		return
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		bits[i], err = parseCronField(field, cronFields[i])
+		if err != nil {
+			return
+		}
+	}
+
+	dow := bits[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+
+	schedule = &cronSchedule{
+		minute:   bits[0],
+		hour:     bits[1],
+		dom:      bits[2],
+		month:    bits[3],
+		dow:      dow,
+		domAny:   fields[2] == "*",
+		dowAny:   fields[4] == "*",
+		location: location,
+	}
+	return
+}
+
+func parseCronField(field string, spec cronField) (bits uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				err = fmt.Errorf("%w: invalid step %q in %s field", ErrInvalidSchedule, part, spec.name)
+				return
+			}
+		}
+
+		low, high := spec.min, spec.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			low, err = strconv.Atoi(from)
+			if err == nil {
+				high, err = strconv.Atoi(to)
+			}
+		default:
+			low, err = strconv.Atoi(rangePart)
+			high = low
+			if hasStep {
+				high = spec.max
+			}
+		}
+		if err != nil || low < spec.min || high > spec.max || low > high {
+			err = fmt.Errorf("%w: invalid value %q in %s field", ErrInvalidSchedule, part, spec.name)
+			return
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return
+}
+
+func (s intervalSchedule) Next(after time.Time) (next time.Time) {
+	next = after.Truncate(time.Minute).Add(s.every)
+	return
+}
+
+func (s *cronSchedule) Next(after time.Time) (next time.Time) {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			next = t
+			return
+		}
+	}
+	return
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) (matches bool) {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case s.domAny && s.dowAny:
+		matches = true
+	case s.domAny:
+		matches = dowMatch
+	case s.dowAny:
+		matches = domMatch
+	default:
+		matches = domMatch || dowMatch
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseScheduleNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// 2024-03-01 is a Friday.
+	after := time.Date(2024, 3, 1, 10, 17, 30, 0, time.UTC)
+
+	cases := []struct {
+		spec     string
+		location *time.Location
+		want     time.Time
+	}{
+		{"hourly", nil, time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", nil, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"weekly", nil, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"monthly", nil, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 15m", nil, time.Date(2024, 3, 1, 10, 32, 0, 0, time.UTC)},
+		{"*/20 * * * *", nil, time.Date(2024, 3, 1, 10, 20, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", nil, time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", nil, time.Date(2024, 3, 4, 8, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", nil, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", nil, time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{"0 0 15 * 1", nil, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * *", berlin, time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC)},
+		{"5,10 10 1 3 *", nil, time.Date(2025, 3, 1, 10, 5, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.spec, tc.location)
+			if err != nil {
+				t.Fatalf("ParseSchedule returned error: %v", err)
+			}
+
+			next := schedule.Next(after)
+			if !next.Equal(tc.want) {
+				t.Errorf("Next = %s, want %s", next.UTC(), tc.want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleDaylightSaving(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	schedule, err := ParseSchedule("30 2 * * *", berlin)
+	if err != nil {
+		t.Fatalf("ParseSchedule returned error: %v", err)
+	}
+
+	// 02:30 does not exist on 2024-03-31 in Berlin; the next run is the following day.
+	next := schedule.Next(time.Date(2024, 3, 30, 12, 0, 0, 0, berlin))
+	want := time.Date(2024, 4, 1, 2, 30, 0, 0, berlin)
+	if !next.Equal(want) {
+		t.Errorf("Next = %s, want %s", next, want)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"yearly",
+		"@every 30s",
+		"@every soon",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+	}
+
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseSchedule(spec, nil)
+			if !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("error = %v, want ErrInvalidSchedule", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var (
+	ErrSilenceNotFound = errors.New("silence not found")
+	ErrSilenceExpired  = errors.New("silence has expired")
+)
+
+type Silencer struct {
+	store       domain.SilenceStore
+	idGenerator IDGenerator
+	clock       Clock
+
+	mu       sync.RWMutex
+	silences map[string]domain.Silence
+}
+
+func NewSilencer(ctx context.Context, store domain.SilenceStore, idGen IDGenerator, clock Clock) (silencer *Silencer, err error) {
+	var stored []domain.Silence
+	stored, err = store.List(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to load silences: %w", err)
+		return
+	}
+
+	silencer = &Silencer{
+		store:       store,
+		idGenerator: idGen,
+		clock:       clock,
+		silences:    make(map[string]domain.Silence, len(stored)),
+	}
+
+	for _, silence := range stored {
+		err = domain.CompileMatchers(silence.Matchers)
+		if err != nil {
+			silencer = nil
+			err = fmt.Errorf("failed to load silence %s: %w", silence.ID, err)
+			return
+		}
+		silencer.silences[silence.ID] = silence
+	}
+	return
+}
+
+func (s *Silencer) Create(ctx context.Context, silence domain.Silence) (created domain.Silence, err error) {
+	now := s.clock.Now().UTC()
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = now
+	}
+
+	err = silence.Validate()
+	if err != nil {
+		return
+	}
+	if !silence.EndsAt.After(now) {
+		err = fmt.Errorf("%w: ends_at must be in the future", domain.ErrInvalidSilence)
+		return
+	}
+
+	silence.ID, err = s.idGenerator.Generate()
+	if err != nil {
+		err = fmt.Errorf("failed to generate silence ID: %w", err)
+		return
+	}
+	silence.CreatedAt = now
+	silence.UpdatedAt = now
+
+	err = s.save(ctx, silence)
+	if err != nil {
+		return
+	}
+
+	created = silence
+	return
+}
+
+func (s *Silencer) Update(ctx context.Context, id string, silence domain.Silence) (updated domain.Silence, err error) {
+	var existing domain.Silence
+	existing, err = s.Get(id)
+	if err != nil {
+		return
+	}
+
+	now := s.clock.Now().UTC()
+	if existing.State(now) == domain.SilenceExpired {
+		err = fmt.Errorf("%w: %s", ErrSilenceExpired, id)
+		return
+	}
+
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = existing.StartsAt
+	}
+
+	err = silence.Validate()
+	if err != nil {
+		return
+	}
+
+	silence.ID = existing.ID
+	silence.CreatedAt = existing.CreatedAt
+	silence.UpdatedAt = now
+
+	err = s.save(ctx, silence)
+	if err != nil {
+		return
+	}
+
+	updated = silence
+	return
+}
+
+func (s *Silencer) Expire(ctx context.Context, id string) (expired domain.Silence, err error) {
+	expired, err = s.Get(id)
+	if err != nil {
+		return
+	}
+
+	now := s.clock.Now().UTC()
+	switch expired.State(now) {
+	case domain.SilenceExpired:
+		err = fmt.Errorf("%w: %s", ErrSilenceExpired, id)
+		return
+	case domain.SilencePending:
+		expired.StartsAt = now
+	}
+	expired.EndsAt = now
+	expired.UpdatedAt = now
+
+	err = s.save(ctx, expired)
+	return
+}
+
+func (s *Silencer) Get(id string) (silence domain.Silence, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	silence, ok := s.silences[id]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrSilenceNotFound, id)
+	}
+	return
+}
+
+func (s *Silencer) List(state domain.SilenceState) (silences []domain.Silence) {
+	now := s.clock.Now().UTC()
+
+	s.mu.RLock()
+	silences = make([]domain.Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		if state == "" || silence.State(now) == state {
+			silences = append(silences, silence)
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(silences, func(a, b domain.Silence) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+	return
+}
+
+func (s *Silencer) Check(event domain.Event) (err error) {
+	now := s.clock.Now().UTC()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched *domain.Silence
+	for _, silence := range s.silences {
+		if silence.State(now) != domain.SilenceActive || !silence.Matches(event) {
+			continue
+		}
+		if matched == nil || silence.StartsAt.Before(matched.StartsAt) {
+			matched = &silence
+		}
+	}
+
+	if matched != nil {
+		err = &SilencedError{SilenceID: matched.ID}
+	}
+	return
+}
+
+func (s *Silencer) save(ctx context.Context, silence domain.Silence) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = s.store.Save(ctx, silence)
+	if err != nil {
+		err = fmt.Errorf("failed to persist silence: %w", err)
+		return
+	}
+
+	s.silences[silence.ID] = silence
+	return
+}
+
+type SilencedError struct {
+	SilenceID string
+}
+
+func (e *SilencedError) Error() (msg string) {
+	msg = fmt.Sprintf("%s: silenced by %s", ErrSuppressed, e.SilenceID)
+	return
+}
+
+func (e *SilencedError) Unwrap() (err error) {
+	err = ErrSuppressed
+	return
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+func testSilencer(t *testing.T, clock *testClock) (silencer *Silencer) {
+	t.Helper()
+
+	silencer, err := NewSilencer(context.Background(), repository.NewMemorySilenceStore(), &testIDs{}, clock)
+	if err != nil {
+		t.Fatalf("NewSilencer returned error: %v", err)
+	}
+	return
+}
+
+func testSilence(matchers ...domain.Matcher) (silence domain.Silence) {
+	silence = domain.Silence{
+		Matchers:  matchers,
+		EndsAt:    time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+		CreatedBy: "ops",
+		Comment:   "maintenance",
+	}
+	return
+}
+
+func TestSilencerMatchers(t *testing.T) {
+	event := domain.Event{
+		ID:        "evt-1",
+		Source:    "db",
+		EventType: "replication_lag",
+		Priority:  domain.PriorityHigh,
+		Metadata:  map[string]interface{}{"region": "eu-west", "replicas": 3},
+	}
+
+	tests := []struct {
+		name     string
+		matchers []domain.Matcher
+		silenced bool
+	}{
+		{name: "equal", matchers: []domain.Matcher{{Field: "source", Value: "db"}}, silenced: true},
+		{name: "equal mismatch", matchers: []domain.Matcher{{Field: "source", Value: "web"}}},
+		{name: "not equal", matchers: []domain.Matcher{{Field: "source", Operator: domain.MatchNotEqual, Value: "web"}}, silenced: true},
+		{name: "not equal mismatch", matchers: []domain.Matcher{{Field: "source", Operator: domain.MatchNotEqual, Value: "db"}}},
+		{name: "regex is anchored", matchers: []domain.Matcher{{Field: "metadata.region", Operator: domain.MatchRegex, Value: "eu"}}},
+		{name: "regex", matchers: []domain.Matcher{{Field: "metadata.region", Operator: domain.MatchRegex, Value: "eu-.*"}}, silenced: true},
+		{name: "not regex", matchers: []domain.Matcher{{Field: "metadata.region", Operator: domain.MatchNotRegex, Value: "us-.*"}}, silenced: true},
+		{name: "priority", matchers: []domain.Matcher{{Field: "priority", Value: "high"}}, silenced: true},
+		{name: "non-string metadata", matchers: []domain.Matcher{{Field: "metadata.replicas", Value: "3"}}, silenced: true},
+		{name: "equal on missing metadata", matchers: []domain.Matcher{{Field: "metadata.team", Value: ""}}},
+		{name: "not equal on missing metadata", matchers: []domain.Matcher{{Field: "metadata.team", Operator: domain.MatchNotEqual, Value: "dba"}}, silenced: true},
+		{name: "not regex on missing metadata", matchers: []domain.Matcher{{Field: "metadata.team", Operator: domain.MatchNotRegex, Value: "dba"}}, silenced: true},
+		{
+			name:     "all matchers must match",
+			matchers: []domain.Matcher{{Field: "source", Value: "db"}, {Field: "event_type", Value: "disk_full"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+			silencer := testSilencer(t, clock)
+
+			_, err := silencer.Create(context.Background(), testSilence(tt.matchers...))
+			if err != nil {
+				t.Fatalf("Create returned error: %v", err)
+			}
+
+			err = silencer.Check(event)
+			if silenced := errors.Is(err, ErrSuppressed); silenced != tt.silenced {
+				t.Errorf("Check error = %v, want silenced %v", err, tt.silenced)
+			}
+		})
+	}
+}
+
+func TestSilencerCreateRejectsInvalidSilences(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	silencer := testSilencer(t, clock)
+
+	past := testSilence(domain.Matcher{Field: "source", Value: "db"})
+	past.StartsAt = clock.Now().Add(-2 * time.Hour)
+	past.EndsAt = clock.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		silence domain.Silence
+	}{
+		{name: "no matchers", silence: testSilence()},
+		{name: "unknown field", silence: testSilence(domain.Matcher{Field: "host", Value: "db"})},
+		{name: "unknown operator", silence: testSilence(domain.Matcher{Field: "source", Operator: "~=", Value: "db"})},
+		{name: "invalid regex", silence: testSilence(domain.Matcher{Field: "source", Operator: domain.MatchRegex, Value: "("})},
+		{name: "already ended", silence: past},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := silencer.Create(context.Background(), tt.silence)
+			if !errors.Is(err, domain.ErrInvalidSilence) {
+				t.Errorf("Create error = %v, want %v", err, domain.ErrInvalidSilence)
+			}
+		})
+	}
+}
+
+func TestSilencerWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &testClock{now: start}
+	silencer := testSilencer(t, clock)
+	event := domain.Event{ID: "evt-1", Source: "db", EventType: "replication_lag"}
+
+	silence := testSilence(domain.Matcher{Field: "source", Value: "db"})
+	silence.StartsAt = start.Add(time.Hour)
+	created, err := silencer.Create(context.Background(), silence)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	steps := []struct {
+		advance  time.Duration
+		state    domain.SilenceState
+		silenced bool
+	}{
+		{advance: 0, state: domain.SilencePending},
+		{advance: time.Hour, state: domain.SilenceActive, silenced: true},
+		{advance: 59 * time.Minute, state: domain.SilenceActive, silenced: true},
+		{advance: time.Minute, state: domain.SilenceExpired},
+	}
+
+	for i, step := range steps {
+		clock.Advance(step.advance)
+
+		if state := created.State(clock.Now()); state != step.state {
+			t.Errorf("step %d: state = %q, want %q", i, state, step.state)
+		}
+		if listed := silencer.List(step.state); len(listed) != 1 {
+			t.Errorf("step %d: List(%q) = %d silences, want 1", i, step.state, len(listed))
+		}
+
+		err = silencer.Check(event)
+		if silenced := errors.Is(err, ErrSuppressed); silenced != step.silenced {
+			t.Errorf("step %d: Check error = %v, want silenced %v", i, err, step.silenced)
+		}
+	}
+
+	_, err = silencer.Expire(context.Background(), created.ID)
+	if !errors.Is(err, ErrSilenceExpired) {
+		t.Errorf("Expire error = %v, want %v", err, ErrSilenceExpired)
+	}
+	_, err = silencer.Update(context.Background(), created.ID, silence)
+	if !errors.Is(err, ErrSilenceExpired) {
+		t.Errorf("Update error = %v, want %v", err, ErrSilenceExpired)
+	}
+}
+
+func TestSilencerExpireEndsSilenceEarly(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	silencer := testSilencer(t, clock)
+	event := domain.Event{ID: "evt-1", Source: "db", EventType: "replication_lag"}
+
+	first, err := silencer.Create(context.Background(), testSilence(domain.Matcher{Field: "source", Value: "db"}))
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	clock.Advance(time.Minute)
+	second, err := silencer.Create(context.Background(), testSilence(domain.Matcher{Field: "event_type", Value: "replication_lag"}))
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	var silenced *SilencedError
+	err = silencer.Check(event)
+	if !errors.As(err, &silenced) || silenced.SilenceID != first.ID {
+		t.Fatalf("Check error = %v, want silenced by the earliest silence %s", err, first.ID)
+	}
+
+	expired, err := silencer.Expire(context.Background(), first.ID)
+	if err != nil {
+		t.Fatalf("Expire returned error: %v", err)
+	}
+	if !expired.EndsAt.Equal(clock.Now()) {
+		t.Errorf("EndsAt = %v, want %v", expired.EndsAt, clock.Now())
+	}
+
+	err = silencer.Check(event)
+	if !errors.As(err, &silenced) || silenced.SilenceID != second.ID {
+		t.Errorf("Check error = %v, want silenced by %s", err, second.ID)
+	}
+
+	_, err = silencer.Expire(context.Background(), "missing")
+	if !errors.Is(err, ErrSilenceNotFound) {
+		t.Errorf("Expire error = %v, want %v", err, ErrSilenceNotFound)
+	}
+}
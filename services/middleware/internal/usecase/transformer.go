@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	ErrInvalidExpression = errors.New("invalid expression")
+	structpbValueType    = reflect.TypeOf(&structpb.Value{})
+)
+
+type TransformLogger interface {
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+type compiledTransform struct {
+	transform domain.Transform
+	when      cel.Program
+	set       map[string]cel.Program
+}
+
+type Transformer struct {
+	env    *cel.Env
+	routes map[string][]compiledTransform
+	logger TransformLogger
+}
+
+type DroppedError struct {
+	Route     string
+	Transform string
+}
+
+func (e *DroppedError) Error() (msg string) {
+	msg = fmt.Sprintf("%s: dropped by route %s transform %s", ErrSuppressed, e.Route, e.Transform)
+	return
+}
+
+func (e *DroppedError) Unwrap() (err error) {
+	err = ErrSuppressed
+	return
+}
+
+func NewTransformer(config domain.RoutingConfig, logger TransformLogger) (transformer *Transformer, err error) {
+	var env *cel.Env
+	env, err = cel.NewEnv(
+		cel.Variable("id", cel.StringType),
+		cel.Variable("source", cel.StringType),
+		cel.Variable("event_type", cel.StringType),
+		cel.Variable("priority", cel.StringType),
+		cel.Variable("message", cel.StringType),
+		cel.Variable("destination", cel.StringType),
+		cel.Variable("action", cel.StringType),
+		cel.Variable("dedup_key", cel.StringType),
+		cel.Variable("timestamp", cel.TimestampType),
+		cel.Variable("metadata", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return
+	}
+
+	transformer = &Transformer{
+		env:    env,
+		routes: make(map[string][]compiledTransform),
+		logger: logger,
+	}
+
+	for _, route := range config.Routes {
+		for _, transform := range route.Transforms {
+			compiled := compiledTransform{
+				transform: transform,
+				set:       make(map[string]cel.Program, len(transform.Set)),
+			}
+
+			if transform.When != "" {
+				compiled.when, err = transformer.compile(transform.When, cel.BoolType)
+				if err != nil {
+					transformer = nil
+					err = fmt.Errorf("%w: route %s transform %s: when: %w", domain.ErrInvalidRoute, route.Name, transform.Name, err)
+					return
+				}
+			}
+
+			for field, expression := range transform.Set {
+				expected := cel.StringType
+				if strings.HasPrefix(field, "metadata.") {
+					expected = cel.DynType
+				}
+
+				compiled.set[field], err = transformer.compile(expression, expected)
+				if err != nil {
+					transformer = nil
+					err = fmt.Errorf("%w: route %s transform %s: set %s: %w", domain.ErrInvalidRoute, route.Name, transform.Name, field, err)
+					return
+				}
+			}
+
+			transformer.routes[route.Name] = append(transformer.routes[route.Name], compiled)
+		}
+	}
+	return
+}
+
+func (t *Transformer) compile(expression string, expected *cel.Type) (program cel.Program, err error) {
+	ast, issues := t.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		positions := make([]string, 0, len(issues.Errors()))
+		for _, issue := range issues.Errors() {
+			positions = append(positions, fmt.Sprintf("line %d, column %d: %s", issue.Location.Line(), issue.Location.Column()+1, issue.Message))
+		}
+		err = fmt.Errorf("%w: %s", ErrInvalidExpression, strings.Join(positions, "; "))
+		return
+	}
+
+	output := ast.OutputType()
+	if expected != cel.DynType && !output.IsExactType(expected) && !output.IsExactType(cel.DynType) {
+		err = fmt.Errorf("%w: expression returns %s, expected %s", ErrInvalidExpression, output, expected)
+		return
+	}
+
+	program, err = t.env.Program(ast)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+	}
+	return
+}
+
+func (t *Transformer) Apply(ctx context.Context, event domain.Event, route string) (transformed domain.Event, err error) {
+	transformed = event
+
+	for _, compiled := range t.routes[route] {
+		vars := transformVars(transformed)
+
+		matched := true
+		if compiled.when != nil {
+			var value ref.Val
+			value, _, err = compiled.when.ContextEval(ctx, vars)
+			if err == nil {
+				var ok bool
+				matched, ok = value.Value().(bool)
+				if !ok {
+					err = fmt.Errorf("when returned %s, expected bool", value.Type().TypeName())
+				}
+			}
+			if err != nil {
+				t.logFailure(ctx, event, route, compiled.transform.Name, err)
+				err = nil
+				continue
+			}
+		}
+
+		if compiled.transform.Drop && matched || compiled.transform.Keep && !matched {
+			err = &DroppedError{Route: route, Transform: compiled.transform.Name}
+			return
+		}
+		if !matched {
+			continue
+		}
+
+		var next domain.Event
+		next, err = t.set(ctx, transformed, vars, compiled.set)
+		if err != nil {
+			t.logFailure(ctx, event, route, compiled.transform.Name, err)
+			err = nil
+			continue
+		}
+		transformed = next
+	}
+	return
+}
+
+func (t *Transformer) set(ctx context.Context, event domain.Event, vars map[string]interface{}, programs map[string]cel.Program) (updated domain.Event, err error) {
+	updated = event
+	if len(programs) == 0 {
+		return
+	}
+	updated.Metadata = maps.Clone(event.Metadata)
+
+	for field, program := range programs {
+		var value ref.Val
+		value, _, err = program.ContextEval(ctx, vars)
+		if err != nil {
+			err = fmt.Errorf("set %s: %w", field, err)
+			return
+		}
+
+		if name, ok := strings.CutPrefix(field, "metadata."); ok {
+			if updated.Metadata == nil {
+				updated.Metadata = make(map[string]interface{})
+			}
+			updated.Metadata[name], err = nativeValue(value)
+			if err != nil {
+				err = fmt.Errorf("set %s: %w", field, err)
+				return
+			}
+			continue
+		}
+
+		text, ok := value.Value().(string)
+		if !ok {
+			err = fmt.Errorf("set %s: expression returned %s, expected string", field, value.Type().TypeName())
+			return
+		}
+
+		switch field {
+		case "source":
+			updated.Source = text
+		case "event_type":
+			updated.EventType = text
+		case "message":
+			updated.Message = text
+		case "destination":
+			updated.Destination = text
+		case "priority":
+			updated.Priority, err = domain.ParsePriority(text)
+			if err != nil {
+				err = fmt.Errorf("set %s: %w", field, err)
+				return
+			}
+		}
+	}
+	return
+}
+
+func (t *Transformer) Has(route string) (ok bool) {
+	_, ok = t.routes[route]
+	return
+}
+
+func (t *Transformer) Evaluate(ctx context.Context, expression string, event domain.Event) (result interface{}, err error) {
+	var program cel.Program
+	program, err = t.compile(expression, cel.DynType)
+	if err != nil {
+		return
+	}
+
+	var value ref.Val
+	value, _, err = program.ContextEval(ctx, transformVars(event))
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+		return
+	}
+
+	result, err = nativeValue(value)
+	return
+}
+
+func (t *Transformer) logFailure(ctx context.Context, event domain.Event, route, transform string, err error) {
+	t.logger.ErrorContext(ctx, "transform failed, skipping it",
+		"event_id", event.ID,
+		"route", route,
+		"transform", transform,
+		"error", err.Error(),
+	)
+}
+
+func transformVars(event domain.Event) (vars map[string]interface{}) {
+	metadata := event.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	vars = map[string]interface{}{
+		"id":          event.ID,
+		"source":      event.Source,
+		"event_type":  event.EventType,
+		"priority":    event.Priority.String(),
+		"message":     event.Message,
+		"destination": event.Destination,
+		"action":      string(event.Action),
+		"dedup_key":   event.DedupKey,
+		"timestamp":   event.Timestamp,
+		"metadata":    metadata,
+	}
+	return
+}
+
+func nativeValue(value ref.Val) (native interface{}, err error) {
+	if types.IsError(value) {
+		err = fmt.Errorf("%v", value)
+		return
+	}
+
+	var converted interface{}
+	converted, err = value.ConvertToNative(structpbValueType)
+	if err != nil {
+		return
+	}
+	native = converted.(*structpb.Value).AsInterface()
+	return
+}
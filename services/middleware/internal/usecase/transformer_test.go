@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func newTestTransformer(t *testing.T, transforms ...domain.Transform) (transformer *Transformer) {
+	t.Helper()
+
+	transformer, err := NewTransformer(domain.RoutingConfig{
+		Routes: []domain.Route{{Name: "db", Transforms: transforms}},
+	}, logger.New(io.Discard, slog.LevelError))
+	if err != nil {
+		t.Fatalf("NewTransformer returned error: %v", err)
+	}
+	return
+}
+
+func testTransformEvent() (event domain.Event) {
+	event = domain.Event{
+		ID:          "evt-1",
+		Source:      "db",
+		EventType:   "replication_lag",
+		Priority:    domain.PriorityMedium,
+		Message:     "lag is 42s",
+		Destination: domain.DefaultDestination,
+		Timestamp:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Metadata:    map[string]interface{}{"region": "eu", "lag_seconds": 42.0},
+	}
+	return
+}
+
+func TestNewTransformerCompileErrors(t *testing.T) {
+	cases := []struct {
+		name      string
+		transform domain.Transform
+		contains  string
+	}{
+		{"syntax", domain.Transform{Name: "t", When: "source ==", Drop: true}, "line 1, column"},
+		{"unknown variable", domain.Transform{Name: "t", When: "host == 'a'", Drop: true}, "undeclared reference"},
+		{"non-bool when", domain.Transform{Name: "t", When: "source", Drop: true}, "expected bool"},
+		{"non-string set", domain.Transform{Name: "t", Set: map[string]string{"message": "1 + 2"}}, "expected string"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewTransformer(domain.RoutingConfig{
+				Routes: []domain.Route{{Name: "db", Transforms: []domain.Transform{tc.transform}}},
+			}, logger.New(io.Discard, slog.LevelError))
+
+			if !errors.Is(err, ErrInvalidExpression) || !errors.Is(err, domain.ErrInvalidRoute) {
+				t.Fatalf("error = %v, want ErrInvalidExpression and ErrInvalidRoute", err)
+			}
+			if !strings.Contains(err.Error(), tc.contains) || !strings.Contains(err.Error(), "route db transform t") {
+				t.Errorf("error %q does not mention %q and the transform", err, tc.contains)
+			}
+		})
+	}
+}
+
+func TestTransformerApplySet(t *testing.T) {
+	transformer := newTestTransformer(t, domain.Transform{
+		Name: "escalate",
+		When: "metadata.lag_seconds > 30.0",
+		Set: map[string]string{
+			"priority":       "'critical'",
+			"message":        "'[' + metadata.region + '] ' + message",
+			"metadata.owner": "'dba'",
+			"metadata.count": "2 * 21",
+		},
+	})
+
+	event := testTransformEvent()
+	transformed, err := transformer.Apply(context.Background(), event, "db")
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	if transformed.Priority != domain.PriorityCritical {
+		t.Errorf("priority = %s, want critical", transformed.Priority)
+	}
+	if transformed.Message != "[eu] lag is 42s" {
+		t.Errorf("message = %q", transformed.Message)
+	}
+	if transformed.Metadata["owner"] != "dba" || transformed.Metadata["count"] != 42.0 {
+		t.Errorf("metadata = %v", transformed.Metadata)
+	}
+	if _, ok := event.Metadata["owner"]; ok {
+		t.Error("Apply modified the original event metadata")
+	}
+}
+
+func TestTransformerApplyDropAndKeep(t *testing.T) {
+	cases := []struct {
+		name      string
+		transform domain.Transform
+		dropped   bool
+	}{
+		{"drop matching", domain.Transform{Name: "t", When: "metadata.region == 'eu'", Drop: true}, true},
+		{"drop not matching", domain.Transform{Name: "t", When: "metadata.region == 'us'", Drop: true}, false},
+		{"keep matching", domain.Transform{Name: "t", When: "source == 'db'", Keep: true}, false},
+		{"keep not matching", domain.Transform{Name: "t", When: "source == 'web'", Keep: true}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			transformer := newTestTransformer(t, tc.transform)
+
+			_, err := transformer.Apply(context.Background(), testTransformEvent(), "db")
+			var dropped *DroppedError
+			if errors.As(err, &dropped) != tc.dropped {
+				t.Fatalf("error = %v, dropped want %v", err, tc.dropped)
+			}
+			if tc.dropped && !errors.Is(err, ErrSuppressed) {
+				t.Errorf("dropped error does not unwrap to ErrSuppressed")
+			}
+		})
+	}
+}
+
+func TestTransformerApplySkipsRuntimeFailures(t *testing.T) {
+	transformer := newTestTransformer(t,
+		domain.Transform{Name: "broken", When: "metadata.missing == 'x'", Drop: true},
+		domain.Transform{Name: "tag", Set: map[string]string{"metadata.tagged": "true"}},
+	)
+
+	transformed, err := transformer.Apply(context.Background(), testTransformEvent(), "db")
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if transformed.Metadata["tagged"] != true {
+		t.Errorf("later transform did not run: %v", transformed.Metadata)
+	}
+}
+
+func TestTransformerEvaluate(t *testing.T) {
+	transformer := newTestTransformer(t)
+
+	result, err := transformer.Evaluate(context.Background(), "{'up': source + '!', 'lag': metadata.lag_seconds}", testTransformEvent())
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+
+	values, ok := result.(map[string]interface{})
+	if !ok || values["up"] != "db!" || values["lag"] != 42.0 {
+		t.Errorf("result = %#v", result)
+	}
+
+	_, err = transformer.Evaluate(context.Background(), "source +", testTransformEvent())
+	if !errors.Is(err, ErrInvalidExpression) {
+		t.Errorf("error = %v, want ErrInvalidExpression", err)
+	}
+}
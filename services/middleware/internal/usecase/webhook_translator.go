@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+const (
+	AlertmanagerSource = "alertmanager"
+	GrafanaSource      = "grafana"
+
+	defaultAlertSeverity = "warning"
+	defaultAlertType     = "alert"
+)
+
+var alertSourceLabels = []string{"source", "service", "job", "instance"}
+
+func TranslateAlertmanager(webhook domain.AlertmanagerWebhook, fallbackSource string) (events []domain.IncomingEvent) {
+	events = make([]domain.IncomingEvent, 0, len(webhook.Alerts))
+
+	for _, alert := range webhook.Alerts {
+		labels := mergeLabels(webhook.CommonLabels, alert.Labels)
+		annotations := mergeLabels(webhook.CommonAnnotations, alert.Annotations)
+
+		status := alert.Status
+		if status == "" {
+			status = webhook.Status
+		}
+
+		event := domain.IncomingEvent{
+			Source:       firstLabel(labels, alertSourceLabels, fallbackSource),
+			EventType:    firstLabel(labels, []string{"alertname"}, defaultAlertType),
+			Severity:     firstLabel(labels, []string{"severity"}, defaultAlertSeverity),
+			Message:      firstLabel(annotations, []string{"summary", "description", "message"}, ""),
+			PartitionKey: alert.Fingerprint,
+			Action:       alertAction(status, alert.Fingerprint),
+			DedupKey:     alert.Fingerprint,
+			Metadata: map[string]interface{}{
+				"status":      status,
+				"fingerprint": alert.Fingerprint,
+			},
+		}
+		if event.Message == "" {
+			event.Message = event.EventType
+		}
+
+		setIfPresent(event.Metadata, "starts_at", formatAlertTime(alert.StartsAt))
+		if status == domain.AlertStatusResolved {
+			setIfPresent(event.Metadata, "ends_at", formatAlertTime(alert.EndsAt))
+		}
+		setIfPresent(event.Metadata, "generator_url", alert.GeneratorURL)
+		setIfPresent(event.Metadata, "silence_url", alert.SilenceURL)
+		setIfPresent(event.Metadata, "dashboard_url", alert.DashboardURL)
+		setIfPresent(event.Metadata, "panel_url", alert.PanelURL)
+		setIfPresent(event.Metadata, "receiver", webhook.Receiver)
+		setIfPresent(event.Metadata, "group_key", webhook.GroupKey)
+		setIfPresent(event.Metadata, "external_url", webhook.ExternalURL)
+
+		for name, value := range labels {
+			event.Metadata["label."+name] = value
+		}
+		for name, value := range annotations {
+			event.Metadata["annotation."+name] = value
+		}
+		for name, value := range alert.Values {
+			event.Metadata["value."+name] = value
+		}
+
+		events = append(events, event)
+	}
+	return
+}
+
+func TranslateGrafanaLegacy(webhook domain.GrafanaLegacyWebhook) (event domain.IncomingEvent) {
+	status := domain.AlertStatusFiring
+	if strings.EqualFold(webhook.State, "ok") {
+		status = domain.AlertStatusResolved
+	}
+
+	fingerprint := "grafana-rule-" + strconv.FormatInt(webhook.RuleID, 10)
+
+	event = domain.IncomingEvent{
+		Source:       firstLabel(webhook.Tags, alertSourceLabels, GrafanaSource),
+		EventType:    webhook.RuleName,
+		Severity:     firstLabel(webhook.Tags, []string{"severity"}, defaultAlertSeverity),
+		Message:      webhook.Message,
+		PartitionKey: fingerprint,
+		Action:       alertAction(status, fingerprint),
+		DedupKey:     fingerprint,
+		Metadata: map[string]interface{}{
+			"status":        status,
+			"fingerprint":   fingerprint,
+			"grafana_state": webhook.State,
+		},
+	}
+	if event.EventType == "" {
+		event.EventType = defaultAlertType
+	}
+	if event.Message == "" {
+		event.Message = firstNonEmpty(webhook.Title, event.EventType)
+	}
+
+	setIfPresent(event.Metadata, "rule_url", webhook.RuleURL)
+	setIfPresent(event.Metadata, "image_url", webhook.ImageURL)
+	for name, value := range webhook.Tags {
+		event.Metadata["label."+name] = value
+	}
+	for i, match := range webhook.EvalMatches {
+		event.Metadata[fmt.Sprintf("value.%s", firstNonEmpty(match.Metric, strconv.Itoa(i)))] = match.Value
+	}
+	return
+}
+
+func alertAction(status, fingerprint string) (action string) {
+	action = string(domain.ActionTrigger)
+	if status == domain.AlertStatusResolved && fingerprint != "" {
+		action = string(domain.ActionResolve)
+	}
+	return
+}
+
+func mergeLabels(common, specific map[string]string) (merged map[string]string) {
+	merged = make(map[string]string, len(common)+len(specific))
+	for name, value := range common {
+		merged[name] = value
+	}
+	for name, value := range specific {
+		merged[name] = value
+	}
+	return
+}
+
+func firstLabel(labels map[string]string, names []string, fallback string) (value string) {
+	for _, name := range names {
+		if v := strings.TrimSpace(labels[name]); v != "" {
+			value = v
+			return
+		}
+	}
+	value = fallback
+	return
+}
+
+func firstNonEmpty(values ...string) (value string) {
+	for _, v := range values {
+		if v != "" {
+			value = v
+			return
+		}
+	}
+	return
+}
+
+func setIfPresent(metadata map[string]interface{}, key, value string) {
+	if value != "" {
+		metadata[key] = value
+	}
+}
+
+func formatAlertTime(t time.Time) (formatted string) {
+	if !t.IsZero() {
+		formatted = t.UTC().Format(time.RFC3339)
+	}
+	return
+}
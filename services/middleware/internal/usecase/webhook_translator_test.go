@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+func TestTranslateAlertmanager(t *testing.T) {
+	startsAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	endsAt := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	webhook := domain.AlertmanagerWebhook{
+		Status:            domain.AlertStatusFiring,
+		Receiver:          "middleware",
+		GroupKey:          "{}:{alertname=\"HighLatency\"}",
+		ExternalURL:       "http://alertmanager:9093",
+		CommonLabels:      map[string]string{"alertname": "HighLatency", "severity": "critical", "job": "api"},
+		CommonAnnotations: map[string]string{"summary": "latency is high"},
+		Alerts: []domain.AlertmanagerAlert{
+			{
+				Labels:       map[string]string{"instance": "api-1:9090"},
+				StartsAt:     startsAt,
+				EndsAt:       endsAt,
+				GeneratorURL: "http://prometheus/graph",
+				Fingerprint:  "fp-1",
+			},
+			{
+				Status:      domain.AlertStatusResolved,
+				Labels:      map[string]string{"service": "checkout", "severity": "low"},
+				Annotations: map[string]string{"summary": "checkout recovered"},
+				StartsAt:    startsAt,
+				EndsAt:      endsAt,
+				Fingerprint: "fp-2",
+				Values:      map[string]float64{"B": 0.5},
+			},
+			{
+				Status: domain.AlertStatusResolved,
+			},
+		},
+	}
+
+	events := TranslateAlertmanager(webhook, AlertmanagerSource)
+	if len(events) != 3 {
+		t.Fatalf("events = %d, want 3", len(events))
+	}
+
+	tests := []struct {
+		name         string
+		event        domain.IncomingEvent
+		source       string
+		eventType    string
+		severity     string
+		message      string
+		action       string
+		dedupKey     string
+		status       string
+		wantEndsAt   bool
+		wantMetadata map[string]interface{}
+	}{
+		{
+			name:       "firing alert inherits common labels and annotations",
+			event:      events[0],
+			source:     "api",
+			eventType:  "HighLatency",
+			severity:   "critical",
+			message:    "latency is high",
+			action:     string(domain.ActionTrigger),
+			dedupKey:   "fp-1",
+			status:     domain.AlertStatusFiring,
+			wantEndsAt: false,
+			wantMetadata: map[string]interface{}{
+				"starts_at":          "2024-01-01T09:00:00Z",
+				"generator_url":      "http://prometheus/graph",
+				"receiver":           "middleware",
+				"group_key":          "{}:{alertname=\"HighLatency\"}",
+				"external_url":       "http://alertmanager:9093",
+				"label.instance":     "api-1:9090",
+				"label.job":          "api",
+				"annotation.summary": "latency is high",
+			},
+		},
+		{
+			name:       "resolved alert overrides common labels",
+			event:      events[1],
+			source:     "checkout",
+			eventType:  "HighLatency",
+			severity:   "low",
+			message:    "checkout recovered",
+			action:     string(domain.ActionResolve),
+			dedupKey:   "fp-2",
+			status:     domain.AlertStatusResolved,
+			wantEndsAt: true,
+			wantMetadata: map[string]interface{}{
+				"ends_at":            "2024-01-01T10:30:00Z",
+				"label.severity":     "low",
+				"annotation.summary": "checkout recovered",
+				"value.B":            0.5,
+			},
+		},
+		{
+			name:       "resolved alert without fingerprint stays a trigger",
+			event:      events[2],
+			source:     "api",
+			eventType:  "HighLatency",
+			severity:   "critical",
+			message:    "latency is high",
+			action:     string(domain.ActionTrigger),
+			dedupKey:   "",
+			status:     domain.AlertStatusResolved,
+			wantEndsAt: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			if event.Source != tt.source {
+				t.Errorf("Source = %q, want %q", event.Source, tt.source)
+			}
+			if event.EventType != tt.eventType {
+				t.Errorf("EventType = %q, want %q", event.EventType, tt.eventType)
+			}
+			if event.Severity != tt.severity {
+				t.Errorf("Severity = %q, want %q", event.Severity, tt.severity)
+			}
+			if event.Message != tt.message {
+				t.Errorf("Message = %q, want %q", event.Message, tt.message)
+			}
+			if event.Action != tt.action {
+				t.Errorf("Action = %q, want %q", event.Action, tt.action)
+			}
+			if event.DedupKey != tt.dedupKey || event.PartitionKey != tt.dedupKey {
+				t.Errorf("DedupKey = %q, PartitionKey = %q, want %q", event.DedupKey, event.PartitionKey, tt.dedupKey)
+			}
+			if status := event.Metadata["status"]; status != tt.status {
+				t.Errorf("metadata status = %v, want %v", status, tt.status)
+			}
+			if _, ok := event.Metadata["ends_at"]; ok != tt.wantEndsAt {
+				t.Errorf("metadata has ends_at = %v, want %v", ok, tt.wantEndsAt)
+			}
+			for key, want := range tt.wantMetadata {
+				if got := event.Metadata[key]; got != want {
+					t.Errorf("metadata %s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslateAlertmanagerDefaults(t *testing.T) {
+	events := TranslateAlertmanager(domain.AlertmanagerWebhook{
+		Status: domain.AlertStatusFiring,
+		Alerts: []domain.AlertmanagerAlert{{Fingerprint: "fp-1", Labels: map[string]string{"source": "  "}}},
+	}, GrafanaSource)
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1", len(events))
+	}
+
+	event := events[0]
+	if event.Source != GrafanaSource {
+		t.Errorf("Source = %q, want %q", event.Source, GrafanaSource)
+	}
+	if event.EventType != defaultAlertType {
+		t.Errorf("EventType = %q, want %q", event.EventType, defaultAlertType)
+	}
+	if event.Severity != defaultAlertSeverity {
+		t.Errorf("Severity = %q, want %q", event.Severity, defaultAlertSeverity)
+	}
+	if event.Message != defaultAlertType {
+		t.Errorf("Message = %q, want %q", event.Message, defaultAlertType)
+	}
+	if _, ok := event.Metadata["starts_at"]; ok {
+		t.Errorf("metadata starts_at is set for a zero start time")
+	}
+	if err := event.Validate(); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+}
+
+func TestTranslateGrafanaLegacy(t *testing.T) {
+	tests := []struct {
+		name      string
+		webhook   domain.GrafanaLegacyWebhook
+		source    string
+		eventType string
+		message   string
+		action    string
+		status    string
+	}{
+		{
+			name: "alerting rule",
+			webhook: domain.GrafanaLegacyWebhook{
+				RuleID:      7,
+				RuleName:    "Disk usage",
+				State:       "alerting",
+				Message:     "disk is 95% full",
+				Tags:        map[string]string{"service": "db"},
+				EvalMatches: []domain.GrafanaEvalMatch{{Metric: "disk", Value: 95}, {Value: 3}},
+			},
+			source:    "db",
+			eventType: "Disk usage",
+			message:   "disk is 95% full",
+			action:    string(domain.ActionTrigger),
+			status:    domain.AlertStatusFiring,
+		},
+		{
+			name:      "ok state resolves",
+			webhook:   domain.GrafanaLegacyWebhook{RuleID: 7, State: "OK", Title: "[OK] Disk usage"},
+			source:    GrafanaSource,
+			eventType: defaultAlertType,
+			message:   "[OK] Disk usage",
+			action:    string(domain.ActionResolve),
+			status:    domain.AlertStatusResolved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := TranslateGrafanaLegacy(tt.webhook)
+			if event.Source != tt.source {
+				t.Errorf("Source = %q, want %q", event.Source, tt.source)
+			}
+			if event.EventType != tt.eventType {
+				t.Errorf("EventType = %q, want %q", event.EventType, tt.eventType)
+			}
+			if event.Message != tt.message {
+				t.Errorf("Message = %q, want %q", event.Message, tt.message)
+			}
+			if event.Action != tt.action {
+				t.Errorf("Action = %q, want %q", event.Action, tt.action)
+			}
+			if event.DedupKey != "grafana-rule-7" {
+				t.Errorf("DedupKey = %q, want %q", event.DedupKey, "grafana-rule-7")
+			}
+			if status := event.Metadata["status"]; status != tt.status {
+				t.Errorf("metadata status = %v, want %v", status, tt.status)
+			}
+		})
+	}
+
+	event := TranslateGrafanaLegacy(tests[0].webhook)
+	if value := event.Metadata["value.disk"]; value != 95.0 {
+		t.Errorf("metadata value.disk = %v, want 95", value)
+	}
+	if value := event.Metadata["value.1"]; value != 3.0 {
+		t.Errorf("metadata value.1 = %v, want 3", value)
+	}
+}
@@ -0,0 +1,211 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var ErrBulkheadFull = errors.New("destination bulkhead is full")
+
+type BulkheadConfig struct {
+	DefaultLimit int
+	Limits       map[string]int
+	ParkSize     int
+}
+
+type Bulkhead struct {
+	cfg          BulkheadConfig
+	mu           sync.Mutex
+	compartments map[string]*compartment
+	order        []string
+	cursor       int
+}
+
+type compartment struct {
+	limit     int
+	inFlight  int
+	parked    []domain.Event
+	admitted  int64
+	overflows int64
+	rejected  int64
+	paused    bool
+	held      []domain.Event
+	released  chan struct{}
+}
+
+type CompartmentStats struct {
+	Limit     int   `json:"limit"`
+	InFlight  int   `json:"in_flight"`
+	Parked    int   `json:"parked"`
+	Admitted  int64 `json:"admitted"`
+	Overflows int64 `json:"overflows"`
+	Rejected  int64 `json:"rejected"`
+	Paused    bool  `json:"paused"`
+	Held      int   `json:"held"`
+}
+
+const DefaultParkSize = 100
+
+func NewBulkhead(cfg BulkheadConfig) (bulkhead *Bulkhead) {
+	if cfg.ParkSize <= 0 {
+		cfg.ParkSize = DefaultParkSize
+	}
+
+	bulkhead = &Bulkhead{
+		cfg:          cfg,
+		compartments: make(map[string]*compartment),
+	}
+	return
+}
+
+func (b *Bulkhead) Admit(event domain.Event) (admitted bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.compartmentLocked(event.Destination)
+	if c.limit <= 0 || c.inFlight < c.limit {
+		c.inFlight++
+		c.admitted++
+		admitted = true
+		return
+	}
+
+	if len(c.parked) >= b.cfg.ParkSize {
+		c.rejected++
+		err = ErrBulkheadFull
+		return
+	}
+
+	c.parked = append(c.parked, event)
+	c.overflows++
+	return
+}
+
+func (b *Bulkhead) Acquire(ctx context.Context, destination string) (err error) {
+	for {
+		b.mu.Lock()
+		c := b.compartmentLocked(destination)
+		if c.limit <= 0 || c.inFlight < c.limit {
+			c.inFlight++
+			c.admitted++
+			b.mu.Unlock()
+			return
+		}
+		released := c.released
+		b.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+	}
+}
+
+func (b *Bulkhead) Next() (event domain.Event, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := 0; i < len(b.order); i++ {
+		name := b.order[(b.cursor+i)%len(b.order)]
+		c := b.compartments[name]
+		if len(c.parked) == 0 || (c.limit > 0 && c.inFlight >= c.limit) {
+			continue
+		}
+
+		event = c.parked[0]
+		c.parked[0] = domain.Event{}
+		c.parked = c.parked[1:]
+		c.inFlight++
+		c.admitted++
+		b.cursor = (b.cursor + i + 1) % len(b.order)
+		ok = true
+		return
+	}
+	return
+}
+
+func (b *Bulkhead) Release(destination string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.compartmentLocked(destination)
+	if c.inFlight > 0 {
+		c.inFlight--
+	}
+	close(c.released)
+	c.released = make(chan struct{})
+}
+
+func (b *Bulkhead) Hold(event domain.Event) (held bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.compartmentLocked(event.Destination)
+	if !c.paused {
+		return
+	}
+
+	c.held = append(c.held, event)
+	held = true
+	return
+}
+
+func (b *Bulkhead) SetPaused(destination string, paused bool) (released []domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.compartmentLocked(destination)
+	c.paused = paused
+	if !paused {
+		released = c.held
+		c.held = nil
+	}
+	return
+}
+
+func (b *Bulkhead) Stats() (stats map[string]CompartmentStats) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats = make(map[string]CompartmentStats, len(b.compartments))
+	for name, c := range b.compartments {
+		stats[name] = CompartmentStats{
+			Limit:     c.limit,
+			InFlight:  c.inFlight,
+			Parked:    len(c.parked),
+			Admitted:  c.admitted,
+			Overflows: c.overflows,
+			Rejected:  c.rejected,
+			Paused:    c.paused,
+			Held:      len(c.held),
+		}
+	}
+	return
+}
+
+func (b *Bulkhead) compartmentLocked(destination string) (c *compartment) {
+	c, ok := b.compartments[destination]
+	if ok {
+		return
+	}
+
+	limit, ok := b.cfg.Limits[destination]
+	if !ok {
+		limit = b.cfg.DefaultLimit
+	}
+
+	c = &compartment{
+		limit:    limit,
+		released: make(chan struct{}),
+	}
+	b.compartments[destination] = c
+	b.order = append(b.order, destination)
+	sort.Strings(b.order)
+	return
+}
@@ -0,0 +1,311 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+func TestBulkheadAdmitParksAndRejects(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{Limits: map[string]int{"slow": 1}, ParkSize: 1})
+
+	admitted, err := bulkhead.Admit(domain.Event{ID: "1", Destination: "slow"})
+	if !admitted || err != nil {
+		t.Fatalf("first event: admitted %v, err %v", admitted, err)
+	}
+
+	admitted, err = bulkhead.Admit(domain.Event{ID: "2", Destination: "slow"})
+	if admitted || err != nil {
+		t.Fatalf("second event should be parked: admitted %v, err %v", admitted, err)
+	}
+
+	_, err = bulkhead.Admit(domain.Event{ID: "3", Destination: "slow"})
+	if !errors.Is(err, ErrBulkheadFull) {
+		t.Fatalf("third event: err %v, want ErrBulkheadFull", err)
+	}
+
+	admitted, err = bulkhead.Admit(domain.Event{ID: "4", Destination: "fast"})
+	if !admitted || err != nil {
+		t.Fatalf("unlimited destination: admitted %v, err %v", admitted, err)
+	}
+
+	if _, ok := bulkhead.Next(); ok {
+		t.Fatal("Next returned a parked event while the compartment is full")
+	}
+
+	bulkhead.Release("slow")
+	event, ok := bulkhead.Next()
+	if !ok || event.ID != "2" {
+		t.Fatalf("Next = %v, %v, want parked event 2", event.ID, ok)
+	}
+
+	stats := bulkhead.Stats()["slow"]
+	if stats.InFlight != 1 || stats.Parked != 0 || stats.Admitted != 2 || stats.Overflows != 1 || stats.Rejected != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestBulkheadNextRoundRobin(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{DefaultLimit: 1, ParkSize: 10})
+
+	for _, destination := range []string{"a", "b"} {
+		bulkhead.Admit(domain.Event{Destination: destination})
+		for i := 0; i < 2; i++ {
+			bulkhead.Admit(domain.Event{ID: destination, Destination: destination})
+		}
+	}
+	bulkhead.Release("a")
+	bulkhead.Release("b")
+
+	var order []string
+	for {
+		event, ok := bulkhead.Next()
+		if !ok {
+			break
+		}
+		order = append(order, event.ID)
+		bulkhead.Release(event.Destination)
+	}
+
+	want := []string{"a", "b", "a", "b"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestBulkheadAcquireWaitsForRelease(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{DefaultLimit: 1})
+
+	err := bulkhead.Acquire(context.Background(), "d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- bulkhead.Acquire(context.Background(), "d")
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquire returned while the compartment was full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	bulkhead.Release("d")
+	select {
+	case err = <-acquired:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Acquire did not return after Release")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err = bulkhead.Acquire(ctx, "d"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire with expired context: %v", err)
+	}
+}
+
+func TestPoolBulkheadIsolatesSlowDestination(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	delivered := make(map[string]int)
+
+	queue := repository.NewEventQueue(100, 0)
+	startTestPool(t, Config{
+		InitialWorkers: 4,
+		Bulkhead:       BulkheadConfig{Limits: map[string]int{"slow": 1}, ParkSize: 10},
+	}, queue, processorFunc(func(event domain.Event) (err error) {
+		if event.Destination == "slow" {
+			<-release
+		}
+		mu.Lock()
+		delivered[event.Destination]++
+		mu.Unlock()
+		return
+	}))
+	defer close(release)
+
+	for i := 0; i < 3; i++ {
+		queue.Enqueue(context.Background(), domain.Event{Destination: "slow"})
+	}
+	for i := 0; i < 10; i++ {
+		queue.Enqueue(context.Background(), domain.Event{Destination: "fast"})
+	}
+
+	waitFor(t, func() (done bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		done = delivered["fast"] == 10
+		return
+	})
+
+	mu.Lock()
+	slow := delivered["slow"]
+	mu.Unlock()
+	if slow != 0 {
+		t.Errorf("slow deliveries = %d while blocked", slow)
+	}
+}
+
+type failingProcessor struct {
+	processorFunc
+
+	mu     sync.Mutex
+	failed map[string]error
+}
+
+func (p *failingProcessor) FailEvent(ctx context.Context, event domain.Event, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failed[event.ID] = err
+}
+
+func TestPoolBulkheadOverflowFailsWithoutHoldingWorker(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	delivered := make(map[string]int)
+
+	processor := &failingProcessor{
+		processorFunc: func(event domain.Event) (err error) {
+			if event.Destination == "slow" {
+				<-release
+			}
+			mu.Lock()
+			delivered[event.Destination]++
+			mu.Unlock()
+			return
+		},
+		failed: make(map[string]error),
+	}
+
+	queue := repository.NewEventQueue(100, 0)
+	pool := startTestPool(t, Config{
+		InitialWorkers: 2,
+		Bulkhead:       BulkheadConfig{Limits: map[string]int{"slow": 1}, ParkSize: 1},
+	}, queue, processor)
+
+	for _, id := range []string{"slow-1", "slow-2", "slow-3", "slow-4"} {
+		queue.Enqueue(context.Background(), domain.Event{ID: id, Destination: "slow"})
+	}
+	for i := 0; i < 10; i++ {
+		queue.Enqueue(context.Background(), domain.Event{ID: fmt.Sprintf("fast-%d", i), Destination: "fast"})
+	}
+
+	waitFor(t, func() (done bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		done = delivered["fast"] == 10
+		return
+	})
+
+	processor.mu.Lock()
+	for _, id := range []string{"slow-3", "slow-4"} {
+		if !errors.Is(processor.failed[id], ErrBulkheadFull) {
+			t.Errorf("event %s failure = %v, want %v", id, processor.failed[id], ErrBulkheadFull)
+		}
+	}
+	processor.mu.Unlock()
+
+	if stats := pool.Stats().Bulkheads["slow"]; stats.Rejected != 2 || stats.Parked != 1 {
+		t.Errorf("slow stats = %+v, want 2 rejected and 1 parked", stats)
+	}
+
+	close(release)
+	waitFor(t, func() (done bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		done = delivered["slow"] == 2
+		return
+	})
+}
+
+func TestPartitionedPoolFailsEventsWaitingOnShutdown(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	processor := &failingProcessor{
+		processorFunc: func(event domain.Event) (err error) {
+			<-release
+			return
+		},
+		failed: make(map[string]error),
+	}
+
+	queue := repository.NewEventQueue(100, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool, err := NewPool(Config{
+		Partition: PartitionConfig{Key: "source", Lanes: 4},
+		Bulkhead:  BulkheadConfig{Limits: map[string]int{"slow": 1}},
+	}, queue, processor, testLogger())
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	pool.Start(ctx)
+
+	queue.Enqueue(context.Background(), domain.Event{ID: "held", Source: "a", Destination: "slow"})
+	waitFor(t, func() (done bool) {
+		done = pool.Stats().Bulkheads["slow"].InFlight == 1
+		return
+	})
+	for i := 0; i < 8; i++ {
+		queue.Enqueue(context.Background(), domain.Event{ID: fmt.Sprintf("waiting-%d", i), Source: fmt.Sprintf("source-%d", i), Destination: "slow"})
+	}
+	cancel()
+
+	waitFor(t, func() (done bool) {
+		processor.mu.Lock()
+		defer processor.mu.Unlock()
+		done = len(processor.failed) > 0
+		return
+	})
+
+	processor.mu.Lock()
+	defer processor.mu.Unlock()
+	if processor.failed["held"] != nil {
+		t.Errorf("in-flight event failed: %v", processor.failed["held"])
+	}
+}
+
+func TestBulkheadHoldWhilePaused(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{})
+
+	if bulkhead.Hold(domain.Event{ID: "1", Destination: "d"}) {
+		t.Fatal("Hold accepted an event for an active destination")
+	}
+
+	bulkhead.SetPaused("d", true)
+	for _, id := range []string{"1", "2"} {
+		if !bulkhead.Hold(domain.Event{ID: id, Destination: "d"}) {
+			t.Fatalf("Hold rejected event %s for a paused destination", id)
+		}
+	}
+
+	stats := bulkhead.Stats()["d"]
+	if !stats.Paused || stats.Held != 2 {
+		t.Errorf("stats = %+v, want paused with 2 held", stats)
+	}
+
+	released := bulkhead.SetPaused("d", false)
+	if len(released) != 2 || released[0].ID != "1" || released[1].ID != "2" {
+		t.Fatalf("released = %v, want events 1 and 2 in order", released)
+	}
+	if bulkhead.Hold(domain.Event{ID: "3", Destination: "d"}) {
+		t.Error("Hold accepted an event after the destination resumed")
+	}
+}
@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+)
+
+var (
+	ErrPartitioned         = errors.New("worker pool size is fixed in partitioned mode")
+	ErrInvalidPartitionKey = errors.New("partition key must be source, event_type or metadata.<field>")
+)
+
+type PartitionConfig struct {
+	Key        string
+	Lanes      int
+	LaneBuffer int
+}
+
+type PartitionKeyFunc func(event domain.Event) (key string)
+
+const DefaultLaneBuffer = 100
+
+func (cfg PartitionConfig) Enabled() (enabled bool) {
+	enabled = cfg.Key != ""
+	return
+}
+
+func NewPartitionKeyFunc(spec string) (keyFunc PartitionKeyFunc, err error) {
+	spec = strings.TrimSpace(spec)
+
+	var field func(event domain.Event) (key string)
+	switch {
+	case spec == "source":
+		field = func(event domain.Event) (key string) {
+			key = event.Source
+			return
+		}
+	case spec == "event_type":
+		field = func(event domain.Event) (key string) {
+			key = event.EventType
+			return
+		}
+	case strings.HasPrefix(spec, "metadata.") && len(spec) > len("metadata."):
+		name := strings.TrimPrefix(spec, "metadata.")
+		field = func(event domain.Event) (key string) {
+			value, ok := event.Metadata[name]
+			if ok && value != nil {
+				key = fmt.Sprint(value)
+			}
+			return
+		}
+	default:
+		err = fmt.Errorf("%w: %q", ErrInvalidPartitionKey, spec)
+		return
+	}
+
+	keyFunc = func(event domain.Event) (key string) {
+		key = event.PartitionKey
+		if key == "" {
+			key = field(event)
+		}
+		if key == "" {
+			key = event.ID
+		}
+		return
+	}
+	return
+}
+
+func (p *Pool) partitioned() (enabled bool) {
+	enabled = p.cfg.Partition.Enabled()
+	return
+}
+
+func (p *Pool) startLanes(ctx context.Context) {
+	lanes := make([]chan domain.Event, p.cfg.Partition.Lanes)
+	for i := range lanes {
+		lanes[i] = make(chan domain.Event, p.cfg.Partition.LaneBuffer)
+	}
+
+	p.mu.Lock()
+	p.runCtx = ctx
+	for _, lane := range lanes {
+		p.nextID++
+		state := &workerState{
+			id:     p.nextID,
+			cancel: func() {},
+			since:  time.Now(),
+		}
+		p.workers[state.id] = state
+
+		p.wg.Add(1)
+		go p.lane(ctx, state, lane)
+	}
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go p.dispatch(ctx, lanes)
+}
+
+func (p *Pool) dispatch(ctx context.Context, lanes []chan domain.Event) {
+	defer p.wg.Done()
+	defer func() {
+		for _, lane := range lanes {
+			close(lane)
+		}
+	}()
+
+	for {
+		if !p.waitWhilePaused(ctx) {
+			p.logger.InfoContext(ctx, "partition dispatcher shutting down")
+			return
+		}
+
+		waitStart := time.Now()
+		event, ok := p.queue.Dequeue(ctx)
+		p.counters.recordWait(time.Since(waitStart))
+		if !ok {
+			p.logger.InfoContext(ctx, "partition dispatcher shutting down")
+			return
+		}
+
+		hash := fnv.New32a()
+		hash.Write([]byte(p.partitionKey(event)))
+		lane := lanes[hash.Sum32()%uint32(len(lanes))]
+
+		select {
+		case lane <- event:
+		case <-ctx.Done():
+			p.fail(ctx, event, errors.New("event dropped during partition dispatch shutdown"))
+			return
+		}
+	}
+}
+
+func (p *Pool) lane(ctx context.Context, state *workerState, lane <-chan domain.Event) {
+	defer p.wg.Done()
+	defer p.remove(state.id)
+
+	laneCtx := context.WithValue(ctx, "worker_id", state.id)
+	p.logger.InfoContext(laneCtx, "partition lane started")
+
+	for event := range lane {
+		if p.bulkhead.Hold(event) {
+			continue
+		}
+
+		err := p.bulkhead.Acquire(ctx, event.Destination)
+		if err != nil {
+			p.fail(laneCtx, event, fmt.Errorf("event dropped while waiting for destination bulkhead: %w", err))
+			continue
+		}
+
+		p.process(laneCtx, state, event)
+	}
+
+	p.logger.InfoContext(laneCtx, "partition lane shutting down")
+}
@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+func TestNewPartitionKeyFunc(t *testing.T) {
+	event := domain.Event{
+		ID:        "evt-1",
+		Source:    "db",
+		EventType: "lag",
+		Metadata:  map[string]interface{}{"cluster": "eu-1", "shard": 3},
+	}
+
+	cases := map[string]string{
+		"source":           "db",
+		"event_type":       "lag",
+		" source ":         "db",
+		"metadata.cluster": "eu-1",
+		"metadata.shard":   "3",
+		"metadata.missing": "evt-1",
+	}
+	for spec, want := range cases {
+		keyFunc, err := NewPartitionKeyFunc(spec)
+		if err != nil {
+			t.Errorf("NewPartitionKeyFunc(%q) returned error: %v", spec, err)
+			continue
+		}
+		if got := keyFunc(event); got != want {
+			t.Errorf("key for %q = %q, want %q", spec, got, want)
+		}
+	}
+
+	keyFunc, err := NewPartitionKeyFunc("source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.PartitionKey = "explicit"
+	if got := keyFunc(event); got != "explicit" {
+		t.Errorf("explicit partition key ignored: %q", got)
+	}
+}
+
+func TestNewPartitionKeyFuncRejectsUnknownSpec(t *testing.T) {
+	for _, spec := range []string{"unknown", "metadata.", "Source", "metadata"} {
+		if _, err := NewPartitionKeyFunc(spec); !errors.Is(err, ErrInvalidPartitionKey) {
+			t.Errorf("NewPartitionKeyFunc(%q) error = %v, want %v", spec, err, ErrInvalidPartitionKey)
+		}
+	}
+
+	_, err := NewPool(Config{Partition: PartitionConfig{Key: "unknown"}}, repository.NewEventQueue(10, 0), processorFunc(nil), testLogger())
+	if !errors.Is(err, ErrInvalidPartitionKey) {
+		t.Errorf("NewPool error = %v, want %v", err, ErrInvalidPartitionKey)
+	}
+}
+
+func TestPartitionedPoolPreservesOrderPerKey(t *testing.T) {
+	const keys, perKey = 5, 40
+
+	var mu sync.Mutex
+	seen := make(map[string][]int)
+
+	queue := repository.NewEventQueue(keys*perKey, 0)
+	pool := startTestPool(t, Config{
+		InitialWorkers: 4,
+		Partition:      PartitionConfig{Key: "source", Lanes: 3},
+	}, queue, processorFunc(func(event domain.Event) (err error) {
+		time.Sleep(time.Duration(rand.Intn(200)) * time.Microsecond)
+
+		mu.Lock()
+		seen[event.Source] = append(seen[event.Source], event.Metadata["seq"].(int))
+		mu.Unlock()
+		return
+	}))
+
+	if stats := pool.Stats(); !stats.Partitioned || stats.Workers != 3 {
+		t.Fatalf("stats = %+v, want 3 partitioned lanes", stats)
+	}
+	if err := pool.SetTarget(5); err != ErrPartitioned {
+		t.Errorf("SetTarget error = %v, want ErrPartitioned", err)
+	}
+
+	for seq := 0; seq < perKey; seq++ {
+		for key := 0; key < keys; key++ {
+			queue.Enqueue(context.Background(), domain.Event{
+				ID:       fmt.Sprintf("%d-%d", key, seq),
+				Source:   fmt.Sprintf("source-%d", key),
+				Metadata: map[string]interface{}{"seq": seq},
+			})
+		}
+	}
+
+	waitFor(t, func() (done bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		total := 0
+		for _, sequence := range seen {
+			total += len(sequence)
+		}
+		done = total == keys*perKey
+		return
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	for source, sequence := range seen {
+		for i, seq := range sequence {
+			if seq != i {
+				t.Fatalf("%s delivered out of order: %v", source, sequence)
+			}
+		}
+	}
+}
@@ -2,18 +2,53 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/smartcom/integration-platform/pkg/health"
 	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
 	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
 )
 
+var (
+	ErrInvalidTarget = errors.New("target worker count is outside the configured range")
+	ErrNoWorkers     = errors.New("no workers are running")
+	ErrPaused        = errors.New("event consumption is paused")
+)
+
 type Pool struct {
-	workerCount int
-	queue       *repository.EventQueue
-	processor   domain.EventProcessor
-	logger      WorkerLogger
-	wg          sync.WaitGroup
+	cfg          Config
+	queue        *repository.EventQueue
+	processor    domain.EventProcessor
+	logger       WorkerLogger
+	bulkhead     *Bulkhead
+	partitionKey PartitionKeyFunc
+	wg           sync.WaitGroup
+
+	mu        sync.Mutex
+	runCtx    context.Context
+	workers   map[int]*workerState
+	nextID    int
+	target    int
+	override  bool
+	lastScale time.Time
+	signals   ScalingSignals
+	paused    bool
+	resumed   chan struct{}
+
+	counters signalCounters
+}
+
+type Config struct {
+	InitialWorkers int
+	MinWorkers     int
+	MaxWorkers     int
+	Scaling        ScalingConfig
+	Bulkhead       BulkheadConfig
+	Partition      PartitionConfig
 }
 
 type WorkerLogger interface {
@@ -21,59 +56,469 @@ type WorkerLogger interface {
 	ErrorContext(ctx context.Context, msg string, args ...any)
 }
 
+type EventFailer interface {
+	FailEvent(ctx context.Context, event domain.Event, err error)
+}
+
+type workerState struct {
+	id       int
+	cancel   context.CancelFunc
+	busy     bool
+	retiring bool
+	eventID  string
+	since    time.Time
+}
+
+type WorkerInfo struct {
+	ID       int           `json:"id"`
+	State    string        `json:"state"`
+	EventID  string        `json:"event_id,omitempty"`
+	InFlight time.Duration `json:"in_flight_ns,omitempty"`
+	Since    time.Time     `json:"since"`
+}
+
+type PoolStats struct {
+	Workers       int            `json:"workers"`
+	Busy          int            `json:"busy"`
+	Retiring      int            `json:"retiring"`
+	Target        int            `json:"target"`
+	MinWorkers    int            `json:"min_workers"`
+	MaxWorkers    int            `json:"max_workers"`
+	Autoscaling   bool           `json:"autoscaling"`
+	Override      bool           `json:"override"`
+	Partitioned   bool           `json:"partitioned"`
+	Paused        bool           `json:"paused"`
+	QueueDepth    int            `json:"queue_depth"`
+	QueueCapacity int            `json:"queue_capacity"`
+	LastScale     time.Time      `json:"last_scale"`
+	Signals       ScalingSignals `json:"signals"`
+
+	Bulkheads map[string]CompartmentStats `json:"bulkheads"`
+}
+
 const DefaultWorkerCount = 10
 
-func NewPool(workerCount int, queue *repository.EventQueue, processor domain.EventProcessor, logger WorkerLogger) (pool *Pool) {
-	if workerCount <= 0 {
-		workerCount = DefaultWorkerCount
+func NewPool(cfg Config, queue *repository.EventQueue, processor domain.EventProcessor, logger WorkerLogger) (pool *Pool, err error) {
+	if cfg.InitialWorkers <= 0 {
+		cfg.InitialWorkers = DefaultWorkerCount
+	}
+	if cfg.MinWorkers <= 0 || cfg.MinWorkers > cfg.InitialWorkers {
+		cfg.MinWorkers = cfg.InitialWorkers
+	}
+	if cfg.MaxWorkers < cfg.InitialWorkers {
+		cfg.MaxWorkers = cfg.InitialWorkers
+	}
+	cfg.Scaling = cfg.Scaling.withDefaults()
+
+	var partitionKey PartitionKeyFunc
+	if cfg.Partition.Enabled() {
+		partitionKey, err = NewPartitionKeyFunc(cfg.Partition.Key)
+		if err != nil {
+			return
+		}
+		if cfg.Partition.Lanes <= 0 {
+			cfg.Partition.Lanes = cfg.InitialWorkers
+		}
+		if cfg.Partition.LaneBuffer <= 0 {
+			cfg.Partition.LaneBuffer = DefaultLaneBuffer
+		}
+		cfg.InitialWorkers = cfg.Partition.Lanes
+		cfg.MinWorkers = cfg.Partition.Lanes
+		cfg.MaxWorkers = cfg.Partition.Lanes
 	}
 
 	pool = &Pool{
-		workerCount: workerCount,
-		queue:       queue,
-		processor:   processor,
-		logger:      logger,
+		cfg:          cfg,
+		queue:        queue,
+		processor:    processor,
+		logger:       logger,
+		bulkhead:     NewBulkhead(cfg.Bulkhead),
+		partitionKey: partitionKey,
+		workers:      make(map[int]*workerState),
+		target:       cfg.InitialWorkers,
+		resumed:      make(chan struct{}),
 	}
 	return
 }
 
 func (p *Pool) Start(ctx context.Context) {
-	for i := 0; i < p.workerCount; i++ {
-		p.wg.Add(1)
-		workerID := i + 1
-		go p.worker(ctx, workerID)
+	if p.partitioned() {
+		p.startLanes(ctx)
+		p.logger.InfoContext(ctx, "worker pool started in partitioned mode",
+			"lanes", p.cfg.Partition.Lanes,
+			"partition_key", p.cfg.Partition.Key,
+		)
+		return
+	}
+
+	p.mu.Lock()
+	p.runCtx = ctx
+	p.resizeLocked()
+	p.mu.Unlock()
+
+	if p.autoscalingEnabled() {
+		go p.autoscale(ctx)
 	}
 
-	p.logger.InfoContext(ctx, "worker pool started", "worker_count", p.workerCount)
+	p.logger.InfoContext(ctx, "worker pool started",
+		"worker_count", p.cfg.InitialWorkers,
+		"min_workers", p.cfg.MinWorkers,
+		"max_workers", p.cfg.MaxWorkers,
+		"autoscaling", p.autoscalingEnabled(),
+	)
 }
 
-func (p *Pool) worker(ctx context.Context, workerID int) {
+func (p *Pool) worker(ctx context.Context, state *workerState) {
 	defer p.wg.Done()
+	defer p.remove(state.id)
 
-	workerCtx := context.WithValue(ctx, "worker_id", workerID)
+	workerCtx := context.WithValue(ctx, "worker_id", state.id)
 	p.logger.InfoContext(workerCtx, "worker started")
 
 	for {
-		event, ok := p.queue.Dequeue(ctx)
+		event, ok := p.bulkhead.Next()
 		if !ok {
-			p.logger.InfoContext(workerCtx, "worker shutting down")
-			return
+			if ctx.Err() != nil {
+				p.logger.InfoContext(workerCtx, "worker retired")
+				return
+			}
+
+			if !p.waitWhilePaused(ctx) {
+				p.logger.InfoContext(workerCtx, "worker retired")
+				return
+			}
+
+			waitStart := time.Now()
+			event, ok = p.queue.Dequeue(ctx)
+			p.counters.recordWait(time.Since(waitStart))
+			if !ok {
+				p.logger.InfoContext(workerCtx, "worker shutting down")
+				return
+			}
+
+			if p.bulkhead.Hold(event) {
+				continue
+			}
+
+			admitted, err := p.bulkhead.Admit(event)
+			if err != nil {
+				p.fail(workerCtx, event, err)
+				continue
+			}
+			if !admitted {
+				continue
+			}
 		}
 
-		err := p.processor.ProcessEvent(event)
+		p.process(workerCtx, state, event)
+	}
+}
+
+func (p *Pool) process(ctx context.Context, state *workerState, event domain.Event) {
+	defer p.bulkhead.Release(event.Destination)
+
+	p.markBusy(state, event.ID)
+	start := time.Now()
+	err := p.processor.ProcessEvent(event)
+	p.counters.recordDelivery(time.Since(start))
+	p.markIdle(state)
+
+	if errors.Is(err, repository.ErrDestinationDisabled) {
+		p.hold(ctx, event, err)
+		return
+	}
+	if err != nil {
+		p.logger.ErrorContext(ctx, "failed to process event",
+			"event_id", event.ID,
+			"destination", event.Destination,
+			"error", err.Error(),
+		)
+	}
+}
+
+func (p *Pool) hold(ctx context.Context, event domain.Event, err error) {
+	if !p.bulkhead.Hold(event) {
+		p.fail(ctx, event, err)
+		return
+	}
+
+	p.logger.InfoContext(ctx, "event held for disabled destination",
+		"event_id", event.ID,
+		"destination", event.Destination,
+	)
+}
+
+func (p *Pool) SetDestinationEnabled(name string, enabled bool) {
+	released := p.bulkhead.SetPaused(name, !enabled)
+	if len(released) == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	ctx := p.runCtx
+	p.mu.Unlock()
+
+	go p.requeue(ctx, name, released)
+}
+
+func (p *Pool) requeue(ctx context.Context, destination string, events []domain.Event) {
+	p.logger.InfoContext(ctx, "releasing held events", "destination", destination, "count", len(events))
+
+	for _, event := range events {
+		err := p.queue.Enqueue(ctx, event)
+		for errors.Is(err, repository.ErrQueueFull) {
+			err = p.queue.Enqueue(ctx, event)
+		}
 		if err != nil {
-			p.logger.ErrorContext(workerCtx, "failed to process event",
-				"event_id", event.ID,
-				"error", err.Error(),
-			)
+			p.fail(ctx, event, fmt.Errorf("failed to release held event: %w", err))
 		}
 	}
 }
 
+func (p *Pool) fail(ctx context.Context, event domain.Event, err error) {
+	p.logger.ErrorContext(ctx, "event not delivered",
+		"event_id", event.ID,
+		"destination", event.Destination,
+		"error", err.Error(),
+	)
+
+	if failer, ok := p.processor.(EventFailer); ok {
+		failer.FailEvent(ctx, event, err)
+	}
+}
+
+func (p *Pool) waitWhilePaused(ctx context.Context) (running bool) {
+	p.mu.Lock()
+	paused := p.paused
+	resumed := p.resumed
+	p.mu.Unlock()
+
+	if !paused {
+		running = true
+		return
+	}
+
+	select {
+	case <-resumed:
+		running = true
+	case <-ctx.Done():
+	}
+	return
+}
+
+func (p *Pool) Pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.paused {
+		p.paused = true
+		p.resumed = make(chan struct{})
+	}
+}
+
+func (p *Pool) Resume() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paused {
+		p.paused = false
+		close(p.resumed)
+	}
+}
+
+func (p *Pool) Workers() (workers []WorkerInfo) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	workers = make([]WorkerInfo, 0, len(p.workers))
+	for _, state := range p.workers {
+		info := WorkerInfo{
+			ID:    state.id,
+			State: "idle",
+			Since: state.since,
+		}
+		if state.busy {
+			info.State = "busy"
+			info.EventID = state.eventID
+			info.InFlight = now.Sub(state.since)
+		}
+		if state.retiring {
+			info.State = "retiring"
+		}
+		workers = append(workers, info)
+	}
+
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].ID < workers[j].ID
+	})
+	return
+}
+
+func (p *Pool) markBusy(state *workerState, eventID string) {
+	p.mu.Lock()
+	state.busy = true
+	state.eventID = eventID
+	state.since = time.Now()
+	p.mu.Unlock()
+}
+
+func (p *Pool) markIdle(state *workerState) {
+	p.mu.Lock()
+	state.busy = false
+	state.eventID = ""
+	state.since = time.Now()
+	p.mu.Unlock()
+}
+
+func (p *Pool) remove(id int) {
+	p.mu.Lock()
+	delete(p.workers, id)
+	p.mu.Unlock()
+}
+
+func (p *Pool) spawnLocked() {
+	p.nextID++
+	workerCtx, cancel := context.WithCancel(p.runCtx)
+	state := &workerState{
+		id:     p.nextID,
+		cancel: cancel,
+		since:  time.Now(),
+	}
+	p.workers[state.id] = state
+
+	p.wg.Add(1)
+	go p.worker(workerCtx, state)
+}
+
+func (p *Pool) resizeLocked() {
+	if p.runCtx == nil || p.runCtx.Err() != nil {
+		return
+	}
+
+	active := p.activeLocked()
+	for i := len(active); i < p.target; i++ {
+		p.spawnLocked()
+	}
+
+	excess := len(active) - p.target
+	if excess <= 0 {
+		return
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].busy != active[j].busy {
+			return !active[i].busy
+		}
+		return active[i].id > active[j].id
+	})
+
+	for _, state := range active[:excess] {
+		state.retiring = true
+		state.cancel()
+	}
+}
+
+func (p *Pool) activeLocked() (active []*workerState) {
+	for _, state := range p.workers {
+		if !state.retiring {
+			active = append(active, state)
+		}
+	}
+	return
+}
+
+func (p *Pool) SetTarget(target int) (err error) {
+	if p.partitioned() {
+		err = ErrPartitioned
+		return
+	}
+	if target < p.cfg.MinWorkers || target > p.cfg.MaxWorkers {
+		err = fmt.Errorf("%w: %d is not between %d and %d", ErrInvalidTarget, target, p.cfg.MinWorkers, p.cfg.MaxWorkers)
+		return
+	}
+
+	p.mu.Lock()
+	p.override = true
+	p.target = target
+	p.lastScale = time.Now()
+	p.resizeLocked()
+	p.mu.Unlock()
+
+	p.logger.InfoContext(context.Background(), "worker pool target overridden", "target", target)
+	return
+}
+
+func (p *Pool) ClearOverride() {
+	p.mu.Lock()
+	p.override = false
+	p.target = clamp(p.target, p.cfg.MinWorkers, p.cfg.MaxWorkers)
+	p.resizeLocked()
+	p.mu.Unlock()
+
+	p.logger.InfoContext(context.Background(), "worker pool target override cleared")
+}
+
+func (p *Pool) Stats() (stats PoolStats) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, state := range p.workers {
+		if state.retiring {
+			stats.Retiring++
+			continue
+		}
+		stats.Workers++
+		if state.busy {
+			stats.Busy++
+		}
+	}
+
+	stats.Target = p.target
+	stats.MinWorkers = p.cfg.MinWorkers
+	stats.MaxWorkers = p.cfg.MaxWorkers
+	stats.Autoscaling = p.autoscalingEnabled() && !p.override
+	stats.Override = p.override
+	stats.Partitioned = p.partitioned()
+	stats.Paused = p.paused
+	stats.QueueDepth = p.queue.Len()
+	stats.QueueCapacity = p.queue.Cap()
+	stats.LastScale = p.lastScale
+	stats.Signals = p.signals
+	stats.Bulkheads = p.bulkhead.Stats()
+	return
+}
+
+func (p *Pool) LivenessCheck() (check health.CheckFunc) {
+	check = func(ctx context.Context) (err error) {
+		if p.Stats().Workers == 0 {
+			err = ErrNoWorkers
+		}
+		return
+	}
+	return
+}
+
+func (p *Pool) ReadinessCheck() (check health.CheckFunc) {
+	check = func(ctx context.Context) (err error) {
+		stats := p.Stats()
+		if stats.Workers == 0 {
+			err = ErrNoWorkers
+			return
+		}
+		if stats.Paused {
+			err = ErrPaused
+		}
+		return
+	}
+	return
+}
+
 func (p *Pool) Shutdown(ctx context.Context) {
 	p.logger.InfoContext(ctx, "shutting down worker pool")
 
 	p.queue.Close()
+	p.Resume()
 
 	done := make(chan struct{})
 	go func() {
@@ -88,3 +533,14 @@ func (p *Pool) Shutdown(ctx context.Context) {
 		p.logger.ErrorContext(ctx, "worker pool shutdown timeout")
 	}
 }
+
+func clamp(value, lower, upper int) (result int) {
+	result = value
+	if result < lower {
+		result = lower
+	}
+	if result > upper {
+		result = upper
+	}
+	return
+}
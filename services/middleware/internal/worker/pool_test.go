@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/pkg/logger"
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+type processorFunc func(event domain.Event) (err error)
+
+func (f processorFunc) ProcessEvent(event domain.Event) (err error) {
+	err = f(event)
+	return
+}
+
+func testLogger() (log *logger.Logger) {
+	log = logger.New(io.Discard, slog.LevelError)
+	return
+}
+
+func startTestPool(t *testing.T, cfg Config, queue *repository.EventQueue, processor domain.EventProcessor) (pool *Pool) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool, err := NewPool(cfg, queue, processor, testLogger())
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	pool.Start(ctx)
+
+	t.Cleanup(func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		pool.Shutdown(shutdownCtx)
+		cancel()
+	})
+	return
+}
+
+func waitFor(t *testing.T, condition func() (done bool)) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPoolSetTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  int
+		wantErr bool
+	}{
+		{name: "zero", target: 0, wantErr: true},
+		{name: "below min", target: 1, wantErr: true},
+		{name: "min", target: 2},
+		{name: "within range", target: 5},
+		{name: "max", target: 8},
+		{name: "above max", target: 9, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := NewPool(Config{InitialWorkers: 4, MinWorkers: 2, MaxWorkers: 8}, repository.NewEventQueue(10, 0), processorFunc(nil), testLogger())
+			if err != nil {
+				t.Fatalf("NewPool returned error: %v", err)
+			}
+
+			err = pool.SetTarget(tt.target)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTarget) {
+					t.Errorf("SetTarget(%d) error = %v, want %v", tt.target, err, ErrInvalidTarget)
+				}
+				if stats := pool.Stats(); stats.Target != 4 || stats.Override {
+					t.Errorf("stats = target %d, override %v, want target 4 without override", stats.Target, stats.Override)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SetTarget(%d) returned error: %v", tt.target, err)
+			}
+			if stats := pool.Stats(); stats.Target != tt.target || !stats.Override {
+				t.Errorf("stats = target %d, override %v, want target %d with override", stats.Target, stats.Override, tt.target)
+			}
+
+			pool.ClearOverride()
+			if stats := pool.Stats(); stats.Target != tt.target || stats.Override {
+				t.Errorf("after ClearOverride: target %d, override %v, want target %d without override", stats.Target, stats.Override, tt.target)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type ScalingConfig struct {
+	Interval         time.Duration
+	UpCooldown       time.Duration
+	DownCooldown     time.Duration
+	Step             int
+	UpQueueRatio     float64
+	DownQueueRatio   float64
+	LatencyTarget    time.Duration
+	IdleWait         time.Duration
+	DownStableRounds int
+}
+
+type ScalingSignals struct {
+	QueueDepth     int           `json:"queue_depth"`
+	QueueRatio     float64       `json:"queue_ratio"`
+	AvgDequeueWait time.Duration `json:"avg_dequeue_wait_ns"`
+	AvgLatency     time.Duration `json:"avg_latency_ns"`
+	Processed      int64         `json:"processed"`
+	Workers        int           `json:"workers"`
+	Busy           int           `json:"busy"`
+	Decision       string        `json:"decision"`
+}
+
+type signalCounters struct {
+	waitNanos    atomic.Int64
+	waits        atomic.Int64
+	latencyNanos atomic.Int64
+	deliveries   atomic.Int64
+}
+
+func (c *signalCounters) recordWait(d time.Duration) {
+	c.waitNanos.Add(int64(d))
+	c.waits.Add(1)
+}
+
+func (c *signalCounters) recordDelivery(d time.Duration) {
+	c.latencyNanos.Add(int64(d))
+	c.deliveries.Add(1)
+}
+
+func (cfg ScalingConfig) withDefaults() (result ScalingConfig) {
+	result = cfg
+	if result.Interval <= 0 {
+		result.Interval = 5 * time.Second
+	}
+	if result.UpCooldown <= 0 {
+		result.UpCooldown = 15 * time.Second
+	}
+	if result.DownCooldown <= 0 {
+		result.DownCooldown = 60 * time.Second
+	}
+	if result.Step <= 0 {
+		result.Step = 2
+	}
+	if result.UpQueueRatio <= 0 {
+		result.UpQueueRatio = 0.25
+	}
+	if result.DownQueueRatio <= 0 || result.DownQueueRatio >= result.UpQueueRatio {
+		result.DownQueueRatio = result.UpQueueRatio / 5
+	}
+	if result.LatencyTarget <= 0 {
+		result.LatencyTarget = time.Second
+	}
+	if result.IdleWait <= 0 {
+		result.IdleWait = 500 * time.Millisecond
+	}
+	if result.DownStableRounds <= 0 {
+		result.DownStableRounds = 3
+	}
+	return
+}
+
+func (p *Pool) autoscalingEnabled() (enabled bool) {
+	enabled = p.cfg.MaxWorkers > p.cfg.MinWorkers
+	return
+}
+
+func (p *Pool) autoscale(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Scaling.Interval)
+	defer ticker.Stop()
+
+	intervalStart := time.Now()
+	downRounds := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			signals := p.collectSignals(intervalStart, now)
+			intervalStart = now
+			downRounds = p.evaluate(ctx, signals, now, downRounds)
+		}
+	}
+}
+
+func (p *Pool) collectSignals(intervalStart, now time.Time) (signals ScalingSignals) {
+	waitNanos := p.counters.waitNanos.Swap(0)
+	waits := p.counters.waits.Swap(0)
+	latencyNanos := p.counters.latencyNanos.Swap(0)
+	deliveries := p.counters.deliveries.Swap(0)
+
+	p.mu.Lock()
+	for _, state := range p.workers {
+		if state.retiring {
+			continue
+		}
+		signals.Workers++
+		if state.busy {
+			signals.Busy++
+			continue
+		}
+		idleSince := state.since
+		if idleSince.Before(intervalStart) {
+			idleSince = intervalStart
+		}
+		waitNanos += int64(now.Sub(idleSince))
+		waits++
+	}
+	p.mu.Unlock()
+
+	signals.QueueDepth = p.queue.Len()
+	if capacity := p.queue.Cap(); capacity > 0 {
+		signals.QueueRatio = float64(signals.QueueDepth) / float64(capacity)
+	}
+	if waits > 0 {
+		signals.AvgDequeueWait = time.Duration(waitNanos / waits)
+	}
+	if deliveries > 0 {
+		signals.AvgLatency = time.Duration(latencyNanos / deliveries)
+	}
+	signals.Processed = deliveries
+	return
+}
+
+func (p *Pool) evaluate(ctx context.Context, signals ScalingSignals, now time.Time, downRounds int) (nextDownRounds int) {
+	scaling := p.cfg.Scaling
+
+	wantUp := signals.QueueRatio >= scaling.UpQueueRatio ||
+		(signals.QueueDepth > 0 && signals.Busy == signals.Workers) ||
+		(signals.QueueDepth > 0 && signals.AvgLatency >= scaling.LatencyTarget)
+	wantDown := !wantUp &&
+		signals.QueueRatio <= scaling.DownQueueRatio &&
+		signals.AvgDequeueWait >= scaling.IdleWait &&
+		signals.Busy < signals.Workers
+
+	if wantDown {
+		nextDownRounds = downRounds + 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	signals.Decision = "hold"
+	defer func() {
+		p.signals = signals
+	}()
+
+	if p.override {
+		signals.Decision = "override"
+		return
+	}
+
+	previous := p.target
+	switch {
+	case wantUp && now.Sub(p.lastScale) >= scaling.UpCooldown:
+		p.target = clamp(p.target+scaling.Step, p.cfg.MinWorkers, p.cfg.MaxWorkers)
+	case wantDown && nextDownRounds >= scaling.DownStableRounds && now.Sub(p.lastScale) >= scaling.DownCooldown:
+		p.target = clamp(p.target-1, p.cfg.MinWorkers, p.cfg.MaxWorkers)
+	}
+
+	if p.target == previous {
+		return
+	}
+
+	if p.target > previous {
+		signals.Decision = "scale_up"
+	} else {
+		signals.Decision = "scale_down"
+	}
+	nextDownRounds = 0
+	p.lastScale = now
+	p.resizeLocked()
+
+	p.logger.InfoContext(ctx, "worker pool rescaled",
+		"decision", signals.Decision,
+		"previous_target", previous,
+		"target", p.target,
+		"queue_depth", signals.QueueDepth,
+		"avg_dequeue_wait", signals.AvgDequeueWait.String(),
+		"avg_latency", signals.AvgLatency.String(),
+	)
+	return
+}
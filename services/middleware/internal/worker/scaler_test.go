@@ -0,0 +1,253 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcom/integration-platform/services/middleware/internal/domain"
+	"github.com/smartcom/integration-platform/services/middleware/internal/repository"
+)
+
+func testScalingPool(t *testing.T) (pool *Pool) {
+	t.Helper()
+
+	pool, err := NewPool(Config{
+		InitialWorkers: 4,
+		MinWorkers:     2,
+		MaxWorkers:     10,
+		Scaling: ScalingConfig{
+			UpCooldown:       15 * time.Second,
+			DownCooldown:     time.Minute,
+			Step:             2,
+			UpQueueRatio:     0.25,
+			DownQueueRatio:   0.05,
+			LatencyTarget:    time.Second,
+			IdleWait:         500 * time.Millisecond,
+			DownStableRounds: 3,
+		},
+	}, repository.NewEventQueue(100, 0), processorFunc(nil), testLogger())
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	return
+}
+
+func TestPoolEvaluate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	idle := ScalingSignals{Workers: 4, Busy: 1, AvgDequeueWait: time.Second}
+
+	tests := []struct {
+		name       string
+		target     int
+		override   bool
+		sinceScale time.Duration
+		signals    ScalingSignals
+		downRounds int
+
+		wantDecision   string
+		wantTarget     int
+		wantDownRounds int
+	}{
+		{
+			name:         "queue ratio scales up",
+			target:       4,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{QueueDepth: 30, QueueRatio: 0.3, Workers: 4, Busy: 2},
+			wantDecision: "scale_up",
+			wantTarget:   6,
+		},
+		{
+			name:         "all workers busy with queued events scales up",
+			target:       4,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{QueueDepth: 1, QueueRatio: 0.01, Workers: 4, Busy: 4},
+			wantDecision: "scale_up",
+			wantTarget:   6,
+		},
+		{
+			name:         "slow deliveries with queued events scale up",
+			target:       4,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{QueueDepth: 1, QueueRatio: 0.01, Workers: 4, Busy: 1, AvgLatency: 2 * time.Second},
+			wantDecision: "scale_up",
+			wantTarget:   6,
+		},
+		{
+			name:         "scale up is clamped to max",
+			target:       9,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{QueueDepth: 50, QueueRatio: 0.5, Workers: 9, Busy: 9},
+			wantDecision: "scale_up",
+			wantTarget:   10,
+		},
+		{
+			name:         "scale up waits for cooldown",
+			target:       4,
+			sinceScale:   10 * time.Second,
+			signals:      ScalingSignals{QueueDepth: 30, QueueRatio: 0.3, Workers: 4, Busy: 4},
+			wantDecision: "hold",
+			wantTarget:   4,
+		},
+		{
+			name:         "at max holds",
+			target:       10,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{QueueDepth: 50, QueueRatio: 0.5, Workers: 10, Busy: 10},
+			wantDecision: "hold",
+			wantTarget:   10,
+		},
+		{
+			name:           "first idle round holds",
+			target:         4,
+			sinceScale:     time.Hour,
+			signals:        idle,
+			wantDecision:   "hold",
+			wantTarget:     4,
+			wantDownRounds: 1,
+		},
+		{
+			name:           "idle for stable rounds scales down",
+			target:         4,
+			sinceScale:     time.Hour,
+			signals:        idle,
+			downRounds:     2,
+			wantDecision:   "scale_down",
+			wantTarget:     3,
+			wantDownRounds: 0,
+		},
+		{
+			name:           "scale down waits for cooldown",
+			target:         4,
+			sinceScale:     30 * time.Second,
+			signals:        idle,
+			downRounds:     2,
+			wantDecision:   "hold",
+			wantTarget:     4,
+			wantDownRounds: 3,
+		},
+		{
+			name:           "scale down is clamped to min",
+			target:         2,
+			sinceScale:     time.Hour,
+			signals:        ScalingSignals{Workers: 2, Busy: 0, AvgDequeueWait: time.Second},
+			downRounds:     5,
+			wantDecision:   "hold",
+			wantTarget:     2,
+			wantDownRounds: 6,
+		},
+		{
+			name:         "short dequeue wait is not idle",
+			target:       4,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{Workers: 4, Busy: 1, AvgDequeueWait: 100 * time.Millisecond},
+			downRounds:   2,
+			wantDecision: "hold",
+			wantTarget:   4,
+		},
+		{
+			name:         "all workers busy is not idle",
+			target:       4,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{Workers: 4, Busy: 4, AvgDequeueWait: time.Second},
+			downRounds:   2,
+			wantDecision: "hold",
+			wantTarget:   4,
+		},
+		{
+			name:         "override ignores scale up",
+			target:       4,
+			override:     true,
+			sinceScale:   time.Hour,
+			signals:      ScalingSignals{QueueDepth: 50, QueueRatio: 0.5, Workers: 4, Busy: 4},
+			wantDecision: "override",
+			wantTarget:   4,
+		},
+		{
+			name:           "override ignores scale down but counts idle rounds",
+			target:         4,
+			override:       true,
+			sinceScale:     time.Hour,
+			signals:        idle,
+			downRounds:     2,
+			wantDecision:   "override",
+			wantTarget:     4,
+			wantDownRounds: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := testScalingPool(t)
+			pool.target = tt.target
+			pool.override = tt.override
+			pool.lastScale = now.Add(-tt.sinceScale)
+
+			downRounds := pool.evaluate(context.Background(), tt.signals, now, tt.downRounds)
+
+			stats := pool.Stats()
+			if stats.Signals.Decision != tt.wantDecision {
+				t.Errorf("decision = %q, want %q", stats.Signals.Decision, tt.wantDecision)
+			}
+			if stats.Target != tt.wantTarget {
+				t.Errorf("target = %d, want %d", stats.Target, tt.wantTarget)
+			}
+			if downRounds != tt.wantDownRounds {
+				t.Errorf("down rounds = %d, want %d", downRounds, tt.wantDownRounds)
+			}
+
+			wantLastScale := now.Add(-tt.sinceScale)
+			if tt.wantDecision == "scale_up" || tt.wantDecision == "scale_down" {
+				wantLastScale = now
+			}
+			if !stats.LastScale.Equal(wantLastScale) {
+				t.Errorf("last scale = %v, want %v", stats.LastScale, wantLastScale)
+			}
+		})
+	}
+}
+
+func TestPoolCollectSignals(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start.Add(4 * time.Second)
+
+	queue := repository.NewEventQueue(10, 0)
+	for i := 0; i < 5; i++ {
+		queue.Enqueue(context.Background(), domain.Event{})
+	}
+
+	pool, err := NewPool(Config{InitialWorkers: 4}, queue, processorFunc(nil), testLogger())
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	pool.workers = map[int]*workerState{
+		1: {id: 1, busy: true, since: start.Add(-time.Minute)},
+		2: {id: 2, since: start.Add(-time.Minute)},
+		3: {id: 3, since: start.Add(2 * time.Second)},
+		4: {id: 4, retiring: true, since: start.Add(-time.Minute)},
+	}
+	pool.counters.recordWait(100 * time.Millisecond)
+	pool.counters.recordWait(100 * time.Millisecond)
+	pool.counters.recordDelivery(300 * time.Millisecond)
+	pool.counters.recordDelivery(100 * time.Millisecond)
+
+	signals := pool.collectSignals(start, now)
+
+	want := ScalingSignals{
+		QueueDepth:     5,
+		QueueRatio:     0.5,
+		AvgDequeueWait: (200*time.Millisecond + 4*time.Second + 2*time.Second) / 4,
+		AvgLatency:     200 * time.Millisecond,
+		Processed:      2,
+		Workers:        3,
+		Busy:           1,
+	}
+	if signals != want {
+		t.Errorf("signals = %+v, want %+v", signals, want)
+	}
+
+	signals = pool.collectSignals(now, now)
+	if signals.Processed != 0 || signals.AvgLatency != 0 || signals.AvgDequeueWait != 0 {
+		t.Errorf("second collection = %+v, want reset counters", signals)
+	}
+}